@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"auth-service/internal/config"
 	"auth-service/internal/database"
+	"auth-service/internal/infrastructure/client"
+	"auth-service/internal/repository"
 	"auth-service/internal/router"
 	"auth-service/pkg/utils"
 
@@ -15,6 +19,9 @@ import (
 
 	fiberSwagger "github.com/swaggo/fiber-swagger"
 
+	"hospital-shared/events"
+	"hospital-shared/health"
+	"hospital-shared/jwt"
 	"hospital-shared/metrics"
 )
 
@@ -36,22 +43,43 @@ func main() {
 		log.Fatalf("migration failed: %v", err)
 	}
 
+	if err := database.SeedPlatformAdmin(dbInstance.SQL, cfg.PlatformAdmin); err != nil {
+		log.Fatalf("platform admin seed failed: %v", err)
+	}
+
 	app := fiber.New()
 
 	app.Use(metrics.PrometheusMiddleware())
 	app.Get("/metrics", metrics.PrometheusHandler())
 
+	// Redis düşerse servis çalışmaya devam eder, durum /health üzerinden raporlanır
+	dbInstance.RedisMonitor.Start(context.Background())
+	app.Get("/health", health.Handler(
+		health.Check{Name: "postgres", Critical: true, Func: dbInstance.PingSQL},
+		health.Check{Name: "redis", Func: dbInstance.RedisMonitor.Check},
+	))
+
 	app.Get("/swagger/*", fiberSwagger.WrapHandler)
 
+	// Outbox olayları Redis Streams'e aktarılır
+	broker := events.NewRedisStreamsBroker(dbInstance.RedisMonitor, events.DefaultStream)
+	events.NewRelay(dbInstance.SQL, broker, repository.EventSource).Start(context.Background())
+
+	// Aktif olmayan hastanelerin tokenları reddedilir
+	jwtCfg := utils.MapToSharedJWTConfig(&cfg)
+	hospitalClient := client.NewHospitalClient(cfg.Url.BaseUrl, cfg.Internal.Token)
+	jwtCfg.HospitalStatus = jwt.CachedHospitalStatus(hospitalClient.GetHospitalStatus, 30*time.Second, 5*time.Minute)
+
 	deps := router.RouterDeps{
 		App:             app,
 		DB:              dbInstance,
 		Config:          &cfg,
-		JWTSharedConfig: utils.MapToSharedJWTConfig(&cfg),
+		JWTSharedConfig: jwtCfg,
 	}
 
 	router.AuthRoutes(deps)
 	router.SubUserRoutes(deps)
+	router.PlatformRoutes(deps)
 
 	for _, r := range app.GetRoutes() {
 		fmt.Println(r.Method, r.Path)
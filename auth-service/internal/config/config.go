@@ -12,6 +12,9 @@ type Config struct {
 	Redis    RedisConfig
 	JWT      JWTConfig
 	Url      HospitalService `mapstructure:"hospital_service"`
+
+	PlatformAdmin PlatformAdminConfig `mapstructure:"platform_admin"`
+	Internal      InternalConfig      `mapstructure:"internal"`
 }
 
 type ServerConfig struct {
@@ -40,10 +43,25 @@ type JWTConfig struct {
 	RefreshTokenExpiry string `mapstructure:"refresh_token_expiry"`
 }
 
+// PlatformAdminConfig ilk platform yöneticisinin bilgileri, email boşsa seed yapılmaz
+type PlatformAdminConfig struct {
+	FirstName string `mapstructure:"first_name"`
+	LastName  string `mapstructure:"last_name"`
+	TC        string `mapstructure:"tc"`
+	Email     string `mapstructure:"email"`
+	Phone     string `mapstructure:"phone"`
+	Password  string `mapstructure:"password"`
+}
+
 type HospitalService struct {
 	BaseUrl string `mapstructure:"base_url"`
 }
 
+// InternalConfig servisler arası endpointleri koruyan paylaşılan anahtar, tüm servislerde aynı olmalı
+type InternalConfig struct {
+	Token string `mapstructure:"token"`
+}
+
 func LoadConfig(path string) (config Config, err error) {
 	viper.AddConfigPath(path)
 	viper.SetConfigName("config")
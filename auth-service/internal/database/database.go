@@ -1,20 +1,22 @@
 package database
 
 import (
-	"context"
 	"fmt"
 	"time"
 
 	"auth-service/internal/config"
 
+	"hospital-shared/cache"
+
 	"github.com/go-redis/redis/v8"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 type Database struct {
-	SQL   *gorm.DB
-	Redis *redis.Client
+	SQL          *gorm.DB
+	Redis        *redis.Client
+	RedisMonitor *cache.Monitor
 }
 
 func NewDatabase(cfg *config.Config) (*Database, error) {
@@ -23,17 +25,25 @@ func NewDatabase(cfg *config.Config) (*Database, error) {
 		return nil, fmt.Errorf("failed to connect to Postgres: %w", err)
 	}
 
-	rdb, err := connectRedis(cfg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
-	}
+	// Redis erişilemezse servis yine açılır; Redis gerektiren akışlar (reset kodları) 503 döner
+	rdb := newRedisClient(cfg)
 
 	return &Database{
-		SQL:   db,
-		Redis: rdb,
+		SQL:          db,
+		Redis:        rdb,
+		RedisMonitor: cache.NewMonitor(rdb, "auth", 10*time.Second),
 	}, nil
 }
 
+// PingSQL sağlık kontrolü için Postgres bağlantısını dener
+func (d *Database) PingSQL() error {
+	sqlDB, err := d.SQL.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Ping()
+}
+
 func connectPostgres(cfg *config.Config) (*gorm.DB, error) {
 	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
 		cfg.Database.Host,
@@ -65,19 +75,10 @@ func connectPostgres(cfg *config.Config) (*gorm.DB, error) {
 	return db, nil
 }
 
-func connectRedis(cfg *config.Config) (*redis.Client, error) {
-	rdb := redis.NewClient(&redis.Options{
+func newRedisClient(cfg *config.Config) *redis.Client {
+	return redis.NewClient(&redis.Options{
 		Addr:     cfg.Redis.Addr,
 		Password: cfg.Redis.Password,
 		DB:       cfg.Redis.DB,
 	})
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	if _, err := rdb.Ping(ctx).Result(); err != nil {
-		return nil, err
-	}
-
-	return rdb, nil
 }
@@ -1,9 +1,15 @@
 package database
 
 import (
+	"errors"
 	"fmt"
 
+	"auth-service/internal/config"
 	"auth-service/internal/models"
+	"auth-service/pkg/utils"
+	"hospital-shared/events"
+	"hospital-shared/jwt"
+
 	"gorm.io/gorm"
 )
 
@@ -11,9 +17,51 @@ func RunMigrations(db *gorm.DB) error {
 	fmt.Println("Running database migrations...")
 
 	// Burada modelleri ekliyoruz
-	return db.AutoMigrate(
+	if err := db.AutoMigrate(
 
 		&models.Authority{},
-		
-	)
+	); err != nil {
+		return err
+	}
+	return events.Migrate(db)
+}
+
+// SeedPlatformAdmin config'de tanımlı platform yöneticisini yoksa oluşturur
+func SeedPlatformAdmin(db *gorm.DB, cfg config.PlatformAdminConfig) error {
+	if cfg.Email == "" {
+		return nil
+	}
+	if cfg.Password == "" || cfg.Phone == "" || cfg.TC == "" {
+		return errors.New("platform admin requires tc, phone and password")
+	}
+
+	var count int64
+	if err := db.Model(&models.Authority{}).Where("role = ?", jwt.PlatformAdminRole).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	hashedPassword, err := utils.HashPassword(cfg.Password)
+	if err != nil {
+		return err
+	}
+
+	admin := &models.Authority{
+		FirstName:  cfg.FirstName,
+		LastName:   cfg.LastName,
+		TC:         cfg.TC,
+		Email:      cfg.Email,
+		Phone:      cfg.Phone,
+		Password:   hashedPassword,
+		Role:       jwt.PlatformAdminRole,
+		HospitalID: 0,
+	}
+	if err := db.Create(admin).Error; err != nil {
+		return fmt.Errorf("failed to create platform admin: %w", err)
+	}
+
+	fmt.Println("Platform admin created successfully!")
+	return nil
 }
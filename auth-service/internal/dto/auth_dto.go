@@ -3,19 +3,21 @@ package dto
 import "time"
 
 type RegisterRequest struct {
-	HospitalName   string `json:"hospital_name"`
-	TaxNumber      string `json:"tax_number"`
-	HospitalEmail  string `json:"hospital_email"`
-	HospitalPhone  string `json:"hospital_phone"`
-	Address        string `json:"address"`
-	CityID         uint   `json:"city_id"`
-	DistrictID     uint   `json:"district_id"`
-	AuthorityFName string `json:"authority_fname"`
-	AuthorityLName string `json:"authority_lname"`
-	AuthorityTC    string `json:"authority_tc"`
-	AuthorityEmail string `json:"authority_email"`
-	AuthorityPhone string `json:"authority_phone"`
-	Password       string `json:"password"`
+	HospitalName   string   `json:"hospital_name"`
+	TaxNumber      string   `json:"tax_number"`
+	HospitalEmail  string   `json:"hospital_email"`
+	HospitalPhone  string   `json:"hospital_phone"`
+	Address        string   `json:"address"`
+	CityID         uint     `json:"city_id"`
+	DistrictID     uint     `json:"district_id"`
+	Latitude       *float64 `json:"latitude"`
+	Longitude      *float64 `json:"longitude"`
+	AuthorityFName string   `json:"authority_fname"`
+	AuthorityLName string   `json:"authority_lname"`
+	AuthorityTC    string   `json:"authority_tc"`
+	AuthorityEmail string   `json:"authority_email"`
+	AuthorityPhone string   `json:"authority_phone"`
+	Password       string   `json:"password"`
 }
 
 type LoginRequest struct {
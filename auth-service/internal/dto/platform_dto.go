@@ -0,0 +1,14 @@
+package dto
+
+type AuthorityListFilter struct {
+	HospitalID *uint
+	Role       string
+	Search     string
+}
+
+type AuthorityListResponse struct {
+	Authorities []AuthorityResponse `json:"authorities"`
+	Total       int                 `json:"total"`
+	Page        int                 `json:"page"`
+	Size        int                 `json:"size"`
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"time"
 
 	"auth-service/internal/config"
@@ -160,6 +161,7 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 // @Param       forgot body dto.ForgotPasswordRequest true "Forgot password info"
 // @Success     200 {object} dto.ForgotPasswordResponse
 // @Failure     400 {object} map[string]string
+// @Failure     503 {object} map[string]string
 // @Router      /api/auth/forgot-password [post]
 func (h *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
 	var req dto.ForgotPasswordRequest
@@ -171,6 +173,9 @@ func (h *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
 	resp, err := h.authUsecase.ForgotPassword(&req)
 	if err != nil {
 		metrics.ForgotPasswordFailCounter.Inc()
+		if errors.Is(err, usecase.ErrPasswordResetUnavailable) {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": err.Error()})
+		}
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 	metrics.ForgotPasswordSuccessCounter.Inc()
@@ -187,6 +192,7 @@ func (h *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
 // @Param       reset body dto.ResetPasswordRequest true "Reset password info"
 // @Success     200 {object} map[string]string
 // @Failure     400 {object} map[string]string
+// @Failure     503 {object} map[string]string
 // @Router      /api/auth/reset-password [post]
 func (h *AuthHandler) ResetPassword(c *fiber.Ctx) error {
 	var req dto.ResetPasswordRequest
@@ -197,6 +203,9 @@ func (h *AuthHandler) ResetPassword(c *fiber.Ctx) error {
 
 	if err := h.authUsecase.ResetPassword(&req); err != nil {
 		metrics.ResetPasswordFailCounter.Inc()
+		if errors.Is(err, usecase.ErrPasswordResetUnavailable) {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": err.Error()})
+		}
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 	metrics.ResetPasswordSuccessCounter.Inc()
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"strconv"
+
+	"auth-service/internal/config"
+	"auth-service/internal/dto"
+	"auth-service/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PlatformHandler struct {
+	platformUsecase usecase.PlatformUsecase
+	config          *config.Config
+}
+
+func NewPlatformHandler(platformUsecase usecase.PlatformUsecase, cfg *config.Config) *PlatformHandler {
+	return &PlatformHandler{
+		platformUsecase: platformUsecase,
+		config:          cfg,
+	}
+}
+
+// ListAuthorities godoc
+// @Summary     Tüm hastanelerin yetkililerini listeler (platform yöneticisi)
+// @Description Lists authorities across hospitals with filters and pagination
+// @Tags        Platform
+// @Produce     json
+// @Param       page query int false "Page number"
+// @Param       size query int false "Page size (max 100)"
+// @Param       hospital_id query int false "Hospital ID"
+// @Param       role query string false "Role"
+// @Param       search query string false "Name, email, phone or TC"
+// @Success     200 {object} dto.AuthorityListResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/platform/authorities [get]
+func (h *PlatformHandler) ListAuthorities(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	size, _ := strconv.Atoi(c.Query("size", "10"))
+
+	filter := dto.AuthorityListFilter{
+		Role:   c.Query("role", ""),
+		Search: c.Query("search", ""),
+	}
+	if v := c.Query("hospital_id", ""); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid hospital_id"})
+		}
+		hid := uint(id)
+		filter.HospitalID = &hid
+	}
+
+	resp, err := h.platformUsecase.ListAuthorities(filter, page, size)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// GetAuthority godoc
+// @Summary     Yetkili detayını getirir (platform yöneticisi)
+// @Description Returns a single authority of any hospital
+// @Tags        Platform
+// @Produce     json
+// @Param       id path int true "Authority ID"
+// @Success     200 {object} dto.AuthorityResponse
+// @Failure     404 {object} map[string]string
+// @Router      /api/platform/authorities/{id} [get]
+func (h *PlatformHandler) GetAuthority(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid authority ID"})
+	}
+
+	resp, err := h.platformUsecase.GetAuthority(uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// ListAuthorityRefs hospital servisinin tutarlılık kontrolü için kullanılır - JWT yerine servisler arası anahtar ister
+func (h *PlatformHandler) ListAuthorityRefs(c *fiber.Ctx) error {
+	afterID, _ := strconv.ParseUint(c.Query("after_id", "0"), 10, 64)
+	limit, _ := strconv.Atoi(c.Query("limit", "0"))
+
+	page, err := h.platformUsecase.ListAuthorityRefs(uint(afterID), limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(page)
+}
+
+// ListHospitalContacts personnel servisinin bildirim göndermesi için kullanılır - JWT yerine servisler arası anahtar ister
+func (h *PlatformHandler) ListHospitalContacts(c *fiber.Ctx) error {
+	hospitalID, err := strconv.ParseUint(c.Params("hospitalId"), 10, 64)
+	if err != nil || hospitalID == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid hospital ID"})
+	}
+
+	contacts, err := h.platformUsecase.ListHospitalContacts(uint(hospitalID), c.Query("role", ""))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(contacts)
+}
@@ -8,10 +8,13 @@ import (
 	"time"
 
 	dt "hospital-shared/dto"
+	"hospital-shared/jwt"
+	"hospital-shared/middleware"
 )
 
 type HospitalClient interface {
 	CreateHospital(req *dt.CreateHospitalRequest) (*dt.HospitalResponse, error)
+	GetHospitalStatus(hospitalID uint) (string, error)
 }
 
 type hospitalClient struct {
@@ -19,11 +22,12 @@ type hospitalClient struct {
 	httpClient *http.Client
 }
 
-func NewHospitalClient(baseURL string) HospitalClient {
+func NewHospitalClient(baseURL, internalToken string) HospitalClient {
 	return &hospitalClient{
 		baseURL: baseURL,
 		httpClient: &http.Client{
-			Timeout: time.Second * 5,
+			Timeout:   time.Second * 5,
+			Transport: middleware.InternalTransport(internalToken),
 		},
 	}
 }
@@ -49,3 +53,27 @@ func (c *hospitalClient) CreateHospital(req *dt.CreateHospitalRequest) (*dt.Hosp
 
 	return &hospitalResp, nil
 }
+
+func (c *hospitalClient) GetHospitalStatus(hospitalID uint) (string, error) {
+	url := fmt.Sprintf("%s/api/hospital/%d/status", c.baseURL, hospitalID)
+
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", jwt.ErrHospitalNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("hospital service returned status %d", resp.StatusCode)
+	}
+
+	var statusResp dt.HospitalStatusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&statusResp); err != nil {
+		return "", err
+	}
+
+	return statusResp.Status, nil
+}
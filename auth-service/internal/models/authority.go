@@ -10,6 +10,6 @@ type Authority struct {
 	Email      string `gorm:"unique;not null"`
 	Phone      string `gorm:"unique;not null"`
 	Password   string `gorm:"not null"`
-	Role       string `gorm:"not null;default:'yetkili'"` // yetkili, calisan
-	HospitalID uint   `gorm:"not null"`
+	Role       string `gorm:"not null;default:'yetkili'"` // yetkili, calisan, platform_admin
+	HospitalID uint   `gorm:"not null"`                   // platform_admin için 0
 }
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"auth-service/internal/dto"
+	"auth-service/internal/models"
+	"hospital-shared/jwt"
+
+	"gorm.io/gorm"
+)
+
+type PlatformRepository interface {
+	ListAuthorities(filter dto.AuthorityListFilter, page, size int) ([]models.Authority, error)
+	CountAuthorities(filter dto.AuthorityListFilter) (int64, error)
+	GetAuthorityByID(id uint) (*models.Authority, error)
+	ListAuthoritiesAfter(afterID uint, limit int) ([]models.Authority, error)
+	ListHospitalAuthorities(hospitalID uint, role string) ([]models.Authority, error)
+}
+
+type platformRepository struct {
+	db *gorm.DB
+}
+
+func NewPlatformRepository(db *gorm.DB) PlatformRepository {
+	return &platformRepository{db: db}
+}
+
+func (r *platformRepository) ListAuthorities(filter dto.AuthorityListFilter, page, size int) ([]models.Authority, error) {
+	var authorities []models.Authority
+	err := r.filtered(filter).
+		Order("id").
+		Offset((page - 1) * size).Limit(size).
+		Find(&authorities).Error
+	return authorities, err
+}
+
+func (r *platformRepository) CountAuthorities(filter dto.AuthorityListFilter) (int64, error) {
+	var total int64
+	err := r.filtered(filter).Count(&total).Error
+	return total, err
+}
+
+func (r *platformRepository) GetAuthorityByID(id uint) (*models.Authority, error) {
+	var authority models.Authority
+	if err := r.db.First(&authority, id).Error; err != nil {
+		return nil, err
+	}
+	return &authority, nil
+}
+
+// Platform yöneticileri hastaneye bağlı olmadığından dahil edilmez
+func (r *platformRepository) ListAuthoritiesAfter(afterID uint, limit int) ([]models.Authority, error) {
+	var authorities []models.Authority
+	err := r.db.Where("id > ? AND role <> ?", afterID, jwt.PlatformAdminRole).
+		Order("id").
+		Limit(limit).
+		Find(&authorities).Error
+	return authorities, err
+}
+
+// ListHospitalAuthorities hastanenin verilen roldeki kullanıcılarını döner; role boşsa hepsini
+func (r *platformRepository) ListHospitalAuthorities(hospitalID uint, role string) ([]models.Authority, error) {
+	query := r.db.Where("hospital_id = ? AND role <> ?", hospitalID, jwt.PlatformAdminRole)
+	if role != "" {
+		query = query.Where("role = ?", role)
+	}
+	var authorities []models.Authority
+	err := query.Order("id").Find(&authorities).Error
+	return authorities, err
+}
+
+// Listeleme ve sayım aynı filtreyi kullanır
+func (r *platformRepository) filtered(filter dto.AuthorityListFilter) *gorm.DB {
+	query := r.db.Model(&models.Authority{})
+
+	if filter.HospitalID != nil {
+		query = query.Where("hospital_id = ?", *filter.HospitalID)
+	}
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+	if filter.Search != "" {
+		like := "%" + filter.Search + "%"
+		query = query.Where("first_name ILIKE ? OR last_name ILIKE ? OR email ILIKE ? OR phone ILIKE ? OR tc ILIKE ?",
+			like, like, like, like, like)
+	}
+	return query
+}
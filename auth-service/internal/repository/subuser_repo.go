@@ -2,9 +2,14 @@ package repository
 
 import (
 	"auth-service/internal/models"
+	"hospital-shared/events"
+
 	"gorm.io/gorm"
 )
 
+// EventSource outbox olaylarında servis adı olarak kullanılır
+const EventSource = "auth"
+
 type SubUserRepository interface {
 	IsAuthorityExists(tc, email, phone string) (bool, error)
 	CreateAuthority(authority *models.Authority) error
@@ -65,5 +70,14 @@ func (r *subUserRepository) Update(user *models.Authority) error {
 }
 
 func (r *subUserRepository) Delete(user *models.Authority) error {
-	return r.db.Delete(user).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(user).Error; err != nil {
+			return err
+		}
+		return events.Enqueue(tx, EventSource, events.AuthorityDeleted, events.AuthorityDeletedPayload{
+			AuthorityID: user.ID,
+			HospitalID:  user.HospitalID,
+			Role:        user.Role,
+		})
+	})
 }
@@ -10,8 +10,8 @@ import (
 
 func AuthRoutes(deps RouterDeps) {
 	authRepo := repository.NewAuthRepository(deps.DB.SQL)
-	hospitalClient := client.NewHospitalClient(deps.Config.Url.BaseUrl)
-	authUsecase := usecase.NewAuthUsecase(authRepo, deps.DB.Redis, hospitalClient)
+	hospitalClient := client.NewHospitalClient(deps.Config.Url.BaseUrl, deps.Config.Internal.Token)
+	authUsecase := usecase.NewAuthUsecase(authRepo, deps.DB.RedisMonitor, hospitalClient)
 	authHandler := handler.NewAuthHandler(authUsecase, deps.Config)
 
 	api := deps.App.Group("/api")
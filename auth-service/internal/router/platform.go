@@ -0,0 +1,29 @@
+package router
+
+import (
+	"auth-service/internal/handler"
+	"auth-service/internal/repository"
+	"auth-service/internal/usecase"
+	"hospital-shared/jwt"
+	"hospital-shared/middleware"
+)
+
+// PlatformRoutes hastaneye bağlı olmayan platform yöneticisi endpointleri
+func PlatformRoutes(deps RouterDeps) {
+	platformRepo := repository.NewPlatformRepository(deps.DB.SQL)
+	platformUsecase := usecase.NewPlatformUsecase(platformRepo)
+	platformHandler := handler.NewPlatformHandler(platformUsecase, deps.Config)
+
+	api := deps.App.Group("/api")
+
+	// Mikroservis arası iletişim için JWT yerine servisler arası paylaşılan anahtar ister - hospital servisinin tutarlılık kontrolü kullanır
+	internalAuth := middleware.InternalAuth(deps.Config.Internal.Token)
+	api.Get("/auth/internal/authorities", internalAuth, platformHandler.ListAuthorityRefs)
+	// Personnel servisi sertifika süresi bildirimlerini hastanenin yetkililerine gönderirken kullanır
+	api.Get("/auth/internal/hospitals/:hospitalId/contacts", internalAuth, platformHandler.ListHospitalContacts)
+
+	platformGroup := api.Group("/platform", middleware.AdminRateLimiter(), jwt.PlatformAuthRequired(deps.JWTSharedConfig))
+
+	platformGroup.Get("/authorities", platformHandler.ListAuthorities)
+	platformGroup.Get("/authorities/:id", platformHandler.GetAuthority)
+}
@@ -11,6 +11,7 @@ import (
 	"auth-service/internal/models"
 	"auth-service/internal/repository"
 	"auth-service/pkg/utils"
+	"hospital-shared/cache"
 	dt "hospital-shared/dto"
 	"hospital-shared/jwt"
 
@@ -25,13 +26,16 @@ type AuthUsecase interface {
 	ResetPassword(req *dto.ResetPasswordRequest) error
 }
 
+// ErrPasswordResetUnavailable reset kodları Redis'te tutulur; Redis yokken akış kapalıdır
+var ErrPasswordResetUnavailable = errors.New("password reset is temporarily unavailable")
+
 type authUsecase struct {
 	authRepo       repository.AuthRepository
-	redis          *redis.Client
+	redis          *cache.Monitor
 	hospitalClient client.HospitalClient
 }
 
-func NewAuthUsecase(r repository.AuthRepository, redis *redis.Client, hc client.HospitalClient) AuthUsecase {
+func NewAuthUsecase(r repository.AuthRepository, redis *cache.Monitor, hc client.HospitalClient) AuthUsecase {
 	return &authUsecase{
 		authRepo:       r,
 		redis:          redis,
@@ -59,6 +63,8 @@ func (u *authUsecase) Register(req *dto.RegisterRequest) (*models.Authority, err
 		Address:    req.Address,
 		CityID:     req.CityID,
 		DistrictID: req.DistrictID,
+		Latitude:   req.Latitude,
+		Longitude:  req.Longitude,
 	})
 	if err != nil {
 		return nil, err
@@ -96,6 +102,21 @@ func (u *authUsecase) Login(req *dto.LoginRequest, cfg *config.Config) (*dto.Log
 		return nil, errors.New("invalid credentials")
 	}
 
+	// Platform yöneticisi dışındaki kullanıcılar sadece aktif hastanelerde giriş yapabilir
+	if authority.HospitalID != 0 {
+		status, err := u.hospitalClient.GetHospitalStatus(authority.HospitalID)
+		if err != nil {
+			return nil, errors.New("cannot verify hospital status")
+		}
+		switch status {
+		case jwt.HospitalStatusActive:
+		case jwt.HospitalStatusPending:
+			return nil, errors.New("hospital registration is pending approval")
+		default:
+			return nil, errors.New("hospital is " + status)
+		}
+	}
+
 	// Token üret
 	jwtCfg := utils.MapToSharedJWTConfig(cfg)
 	tokenPair, err := jwt.GenerateTokenPair(authority.ID, authority.HospitalID, authority.Role, jwtCfg)
@@ -119,11 +140,16 @@ func (u *authUsecase) ForgotPassword(req *dto.ForgotPasswordRequest) (*dto.Forgo
 		return nil, err
 	}
 
+	if !u.redis.Available() {
+		return nil, ErrPasswordResetUnavailable
+	}
+
 	code := utils.GenerateResetCode()
 
 	ctx := context.Background()
-	if err := u.redis.Set(ctx, "reset_code:"+req.Phone, code, 5*time.Minute).Err(); err != nil {
-		return nil, err
+	if err := u.redis.Client().Set(ctx, "reset_code:"+req.Phone, code, 5*time.Minute).Err(); err != nil {
+		u.redis.MarkFailed(err)
+		return nil, ErrPasswordResetUnavailable
 	}
 
 	return &dto.ForgotPasswordResponse{Code: code}, nil
@@ -134,8 +160,16 @@ func (u *authUsecase) ResetPassword(req *dto.ResetPasswordRequest) error {
 		return errors.New("passwords do not match")
 	}
 
+	if !u.redis.Available() {
+		return ErrPasswordResetUnavailable
+	}
+
 	ctx := context.Background()
-	storedCode, err := u.redis.Get(ctx, "reset_code:"+req.Phone).Result()
+	storedCode, err := u.redis.Client().Get(ctx, "reset_code:"+req.Phone).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		u.redis.MarkFailed(err)
+		return ErrPasswordResetUnavailable
+	}
 	if err != nil || storedCode != req.Code {
 		return errors.New("invalid or expired code")
 	}
@@ -154,6 +188,6 @@ func (u *authUsecase) ResetPassword(req *dto.ResetPasswordRequest) error {
 		return err
 	}
 
-	_ = u.redis.Del(ctx, "reset_code:"+req.Phone).Err()
+	_ = u.redis.Client().Del(ctx, "reset_code:"+req.Phone).Err()
 	return nil
 }
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"auth-service/internal/dto"
+	"auth-service/internal/models"
+	"auth-service/internal/repository"
+	dt "hospital-shared/dto"
+)
+
+type PlatformUsecase interface {
+	ListAuthorities(filter dto.AuthorityListFilter, page, size int) (*dto.AuthorityListResponse, error)
+	GetAuthority(id uint) (*dto.AuthorityResponse, error)
+	ListAuthorityRefs(afterID uint, limit int) (*dt.AuthorityRefPage, error)
+	ListHospitalContacts(hospitalID uint, role string) ([]dt.AuthorityContact, error)
+}
+
+// Platform listelerinde tek sayfada dönebilecek en fazla kayıt
+const maxPlatformPageSize = 100
+
+type platformUsecase struct {
+	repo repository.PlatformRepository
+}
+
+func NewPlatformUsecase(repo repository.PlatformRepository) PlatformUsecase {
+	return &platformUsecase{repo: repo}
+}
+
+func (u *platformUsecase) ListAuthorities(filter dto.AuthorityListFilter, page, size int) (*dto.AuthorityListResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = 10
+	}
+	if size > maxPlatformPageSize {
+		size = maxPlatformPageSize
+	}
+
+	total, err := u.repo.CountAuthorities(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	authorities, err := u.repo.ListAuthorities(filter, page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.AuthorityResponse, 0, len(authorities))
+	for i := range authorities {
+		resp = append(resp, *toAuthorityResponse(&authorities[i]))
+	}
+
+	return &dto.AuthorityListResponse{
+		Authorities: resp,
+		Total:       int(total),
+		Page:        page,
+		Size:        size,
+	}, nil
+}
+
+func (u *platformUsecase) GetAuthority(id uint) (*dto.AuthorityResponse, error) {
+	authority, err := u.repo.GetAuthorityByID(id)
+	if err != nil {
+		return nil, errors.New("authority not found")
+	}
+	return toAuthorityResponse(authority), nil
+}
+
+func toAuthorityResponse(a *models.Authority) *dto.AuthorityResponse {
+	var deletedAt *time.Time
+	if a.DeletedAt.Valid {
+		deletedAt = &a.DeletedAt.Time
+	}
+	return &dto.AuthorityResponse{
+		ID:         a.ID,
+		FirstName:  a.FirstName,
+		LastName:   a.LastName,
+		TC:         a.TC,
+		Email:      a.Email,
+		Phone:      a.Phone,
+		Role:       a.Role,
+		HospitalID: a.HospitalID,
+		CreatedAt:  a.CreatedAt,
+		UpdatedAt:  a.UpdatedAt,
+		DeletedAt:  deletedAt,
+	}
+}
+
+func (u *platformUsecase) ListAuthorityRefs(afterID uint, limit int) (*dt.AuthorityRefPage, error) {
+	if limit < 1 || limit > dt.MaxRefPageSize {
+		limit = dt.MaxRefPageSize
+	}
+
+	authorities, err := u.repo.ListAuthoritiesAfter(afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &dt.AuthorityRefPage{Items: make([]dt.AuthorityRef, 0, len(authorities))}
+	for _, a := range authorities {
+		page.Items = append(page.Items, dt.AuthorityRef{ID: a.ID, HospitalID: a.HospitalID, Role: a.Role})
+	}
+	if len(authorities) == limit {
+		page.NextAfterID = authorities[len(authorities)-1].ID
+	}
+	return page, nil
+}
+
+// ListHospitalContacts diğer servislerin hastane kullanıcılarına bildirim gönderebilmesi içindir
+func (u *platformUsecase) ListHospitalContacts(hospitalID uint, role string) ([]dt.AuthorityContact, error) {
+	authorities, err := u.repo.ListHospitalAuthorities(hospitalID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts := make([]dt.AuthorityContact, 0, len(authorities))
+	for _, a := range authorities {
+		contacts = append(contacts, dt.AuthorityContact{
+			ID:        a.ID,
+			FirstName: a.FirstName,
+			LastName:  a.LastName,
+			Email:     a.Email,
+			Role:      a.Role,
+		})
+	}
+	return contacts, nil
+}
@@ -0,0 +1,62 @@
+// import-locations gömülü il/ilçe veri setini veritabanına aktarır.
+// Komut idempotenttir: mevcut kayıtların ID'leri korunur, eksikler eklenir.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	"hospital-service/internal/config"
+	"hospital-service/internal/database"
+	"hospital-service/internal/models"
+	"hospital-service/internal/usecase"
+	"hospital-shared/cache"
+)
+
+func main() {
+	configPath := flag.String("config", "./configs", "config directory")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("cannot load config: %v", err)
+	}
+
+	db, err := database.NewPostgres(&cfg)
+	if err != nil {
+		log.Fatalf("cannot connect to database: %v", err)
+	}
+
+	if err := db.AutoMigrate(&models.City{}, &models.District{}); err != nil {
+		log.Fatalf("migration failed: %v", err)
+	}
+
+	result, err := database.ImportLocations(db)
+	if err != nil {
+		log.Fatalf("import failed: %v", err)
+	}
+	log.Printf("cities created: %d, cities updated: %d, districts created: %d, districts located: %d",
+		result.CitiesCreated, result.CitiesUpdated, result.DistrictsCreated, result.DistrictsLocated)
+
+	// Önbellekteki lokasyon listeleri TTL dolmadan güncellensin. Silme yapılamazsa veri
+	// içe aktarılmış olsa da komut hata koduyla çıkar; eski listeler TTL dolana kadar görünür.
+	rdb, err := database.NewRedis(&cfg)
+	if err != nil {
+		log.Fatalf("locations imported but location cache could not be cleared, redis unavailable: %v", err)
+	}
+	defer rdb.Close()
+
+	c := cache.New(cache.NewMonitor(rdb, "hospital", time.Minute), "hospital")
+	failed := false
+	for _, entity := range []cache.Entity{usecase.CityCacheEntity, usecase.DistrictCacheEntity} {
+		if err := c.InvalidateEntity(context.Background(), entity); err != nil {
+			log.Printf("failed to clear %s cache: %v", entity.Name, err)
+			failed = true
+		}
+	}
+	if failed || c.PendingInvalidations() > 0 {
+		log.Fatalf("locations imported but location cache was not cleared; cached lists stay stale until their TTL expires, rerun the command when redis is reachable")
+	}
+}
@@ -1,11 +1,16 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"hospital-service/internal/config"
+	"hospital-service/internal/consumer"
 	"hospital-service/internal/database"
+	"hospital-service/internal/repository"
 	"hospital-service/internal/router"
 	"hospital-service/pkg/utils"
 
@@ -15,6 +20,10 @@ import (
 
 	fiberSwagger "github.com/swaggo/fiber-swagger"
 
+	"hospital-shared/cache"
+	"hospital-shared/events"
+	"hospital-shared/health"
+	"hospital-shared/jwt"
 	"hospital-shared/metrics"
 )
 
@@ -41,18 +50,49 @@ func main() {
 	app.Use(metrics.PrometheusMiddleware())
 	app.Get("/metrics", metrics.PrometheusHandler())
 
+	// Redis düşerse servis çalışmaya devam eder, durum /health üzerinden raporlanır
+	dbInstance.RedisMonitor.Start(context.Background())
+	app.Get("/health", health.Handler(
+		health.Check{Name: "postgres", Critical: true, Func: dbInstance.PingSQL},
+		health.Check{Name: "redis", Func: dbInstance.RedisMonitor.Check},
+	))
+
 	app.Get("/swagger/*", fiberSwagger.WrapHandler)
 
+	// Outbox olayları Redis Streams'e aktarılır, diğer servislerin olayları tüketilir
+	broker := events.NewRedisStreamsBroker(dbInstance.RedisMonitor, events.DefaultStream)
+	events.NewRelay(dbInstance.SQL, broker, repository.EventSource).Start(context.Background())
+	eventConsumer := events.NewConsumer(dbInstance.SQL, broker, repository.EventSource)
+	consumer.Register(eventConsumer)
+	eventConsumer.Start(context.Background())
+
+	// Aktif olmayan hastanelerin tokenları reddedilir, durum doğrudan veritabanından okunur
+	jwtCfg := utils.MapToSharedJWTConfig(&cfg)
+	hospitalRepo := repository.NewHospitalRepository(dbInstance.SQL)
+	jwtCfg.HospitalStatus = jwt.CachedHospitalStatus(func(hospitalID uint) (string, error) {
+		hospital, err := hospitalRepo.GetByID(hospitalID)
+		if errors.Is(err, repository.ErrHospitalNotFound) {
+			return "", jwt.ErrHospitalNotFound
+		}
+		if err != nil {
+			return "", err
+		}
+		return hospital.Status, nil
+	}, 30*time.Second, 5*time.Minute)
+
 	deps := router.RouterDeps{
 		App:             app,
 		DB:              dbInstance,
 		Config:          &cfg,
-		JWTSharedConfig: utils.MapToSharedJWTConfig(&cfg),
+		JWTSharedConfig: jwtCfg,
+		Cache:           cache.New(dbInstance.RedisMonitor, "hospital"),
 	}
 
 	router.HospitalRoutes(deps)
 	router.PolyclinicRoutes(deps)
 	router.LocationRoutes(deps)
+	router.PlatformRoutes(deps)
+	router.PublicRoutes(deps)
 
 	for _, r := range app.GetRoutes() {
 		fmt.Println(r.Method, r.Path)
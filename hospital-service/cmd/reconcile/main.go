@@ -0,0 +1,120 @@
+// reconcile servisler arası kopuk referansları hastane bazında raporlar.
+// -repair ile kurallar uygulanır, -interval ile zamanlanmış iş olarak çalışır.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"hospital-service/internal/config"
+	"hospital-service/internal/database"
+	"hospital-service/internal/infrastructure/client"
+	"hospital-service/internal/reconcile"
+	"hospital-service/internal/repository"
+)
+
+func main() {
+	configPath := flag.String("config", "./configs", "config directory")
+	repair := flag.String("repair", "", "comma separated repair rules: unassign-staff, flag-orphans, all")
+	interval := flag.Duration("interval", 0, "run repeatedly with this interval (0 runs once)")
+	orphanGrace := flag.Duration("orphan-grace", 24*time.Hour, "minimum age before a hospital without authorities is reported")
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	flag.Parse()
+
+	opts := reconcile.Options{OrphanGrace: *orphanGrace}
+	for _, rule := range strings.Split(*repair, ",") {
+		switch strings.TrimSpace(rule) {
+		case "":
+		case "unassign-staff":
+			opts.UnassignStaff = true
+		case "flag-orphans":
+			opts.FlagOrphans = true
+		case "all":
+			opts.UnassignStaff = true
+			opts.FlagOrphans = true
+		default:
+			log.Fatalf("unknown repair rule: %s", rule)
+		}
+	}
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("cannot load config: %v", err)
+	}
+
+	db, err := database.NewPostgres(&cfg)
+	if err != nil {
+		log.Fatalf("cannot connect to database: %v", err)
+	}
+
+	r := reconcile.New(
+		repository.NewHospitalRepository(db),
+		repository.NewPolyclinicRepository(db),
+		client.NewAuthClient(cfg.Auth.BaseUrl, cfg.Internal.Token),
+		client.NewPersonnelClient(cfg.Url.BaseUrl, cfg.Internal.Token),
+		opts,
+	)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	for {
+		report, err := r.Run(ctx)
+		if err != nil {
+			if *interval == 0 {
+				log.Fatalf("reconciliation failed: %v", err)
+			}
+			log.Printf("reconciliation failed: %v", err)
+		} else {
+			printReport(report, *asJSON)
+		}
+
+		if *interval == 0 {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(*interval):
+		}
+	}
+}
+
+func printReport(report *reconcile.Report, asJSON bool) {
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			log.Printf("cannot encode report: %v", err)
+		}
+		return
+	}
+
+	for _, hr := range report.Hospitals {
+		name := hr.HospitalName
+		if !hr.Exists {
+			name = "(missing)"
+		}
+		fmt.Printf("hospital %d %s\n", hr.HospitalID, name)
+		for _, issue := range hr.Issues {
+			status := ""
+			switch {
+			case issue.Repaired:
+				status = " [repaired]"
+			case issue.RepairError != "":
+				status = " [repair failed: " + issue.RepairError + "]"
+			}
+			fmt.Printf("  %-28s %s%s\n", issue.Kind, issue.Detail, status)
+		}
+	}
+	fmt.Printf("checked in %s, %d hospitals with issues, counts: %v\n",
+		report.FinishedAt.Sub(report.StartedAt).Round(time.Millisecond), len(report.Hospitals), report.Counts)
+}
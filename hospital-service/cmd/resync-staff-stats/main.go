@@ -0,0 +1,69 @@
+// resync-staff-stats personel sayısı projeksiyonunu personnel servisinden baştan kurar.
+// Projeksiyon personnel servisinin açılışta yayınladığı sayımlarla dolar; bu komut yalnızca
+// olaylar kaybolduğunda ya da projeksiyon beklenmedik şekilde bozulduğunda çalıştırılır.
+package main
+
+import (
+	"flag"
+	"log"
+
+	"hospital-service/internal/config"
+	"hospital-service/internal/database"
+	"hospital-service/internal/infrastructure/client"
+	"hospital-service/internal/models"
+	"hospital-service/internal/repository"
+	dt "hospital-shared/dto"
+)
+
+// personnel servisinin toplu istatistik endpointi en fazla 200 ID kabul eder
+const batchSize = 200
+
+func main() {
+	configPath := flag.String("config", "./configs", "config directory")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("cannot load config: %v", err)
+	}
+
+	db, err := database.NewPostgres(&cfg)
+	if err != nil {
+		log.Fatalf("cannot connect to database: %v", err)
+	}
+
+	if err := db.AutoMigrate(&models.PolyclinicStaffStat{}, &models.PolyclinicStaffGroupStat{}); err != nil {
+		log.Fatalf("migration failed: %v", err)
+	}
+
+	var ids []uint
+	if err := db.Model(&models.HospitalPolyclinic{}).Order("id").Pluck("id", &ids).Error; err != nil {
+		log.Fatalf("cannot list hospital polyclinics: %v", err)
+	}
+
+	personnelClient := client.NewPersonnelClient(cfg.Url.BaseUrl, cfg.Internal.Token)
+	stats := repository.NewStaffStatsRepository(db)
+
+	for start := 0; start < len(ids); start += batchSize {
+		end := min(start+batchSize, len(ids))
+		batch, err := personnelClient.GetPersonnelStatsBatch(ids[start:end])
+		if err != nil {
+			log.Fatalf("cannot fetch personnel stats: %v", err)
+		}
+
+		rows := make([]dt.PolyclinicPersonnelStats, 0, len(batch))
+		for _, id := range ids[start:end] {
+			row := batch[id]
+			row.HospitalPolyclinicID = id
+			rows = append(rows, row)
+		}
+		if err := stats.Replace(rows); err != nil {
+			log.Fatalf("cannot store personnel stats: %v", err)
+		}
+	}
+
+	if err := stats.DeleteOrphans(); err != nil {
+		log.Fatalf("cannot remove stale stats: %v", err)
+	}
+	log.Printf("personnel stats resynced for %d hospital polyclinics", len(ids))
+}
@@ -167,9 +167,1062 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/hospital/nearby": {
+            "get": {
+                "description": "Returns active hospitals within the radius sorted by distance, optionally filtered by polyclinic",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Hospital"
+                ],
+                "summary": "Konuma en yakın hastaneleri listeler",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Latitude",
+                        "name": "lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude",
+                        "name": "lng",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Radius in km (default 10, max 200)",
+                        "name": "radius",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Polyclinic ID",
+                        "name": "polyclinic_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.NearbyHospitalListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/platform/hospitals": {
+            "get": {
+                "description": "Lists and searches hospitals across the platform",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Tüm hastaneleri listeler ve arar (platform yöneticisi)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (max 100)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hospital name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tax number",
+                        "name": "tax_number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "City ID",
+                        "name": "city_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "District ID",
+                        "name": "district_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Polyclinic ID",
+                        "name": "polyclinic_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Registered on or after (YYYY-MM-DD)",
+                        "name": "registered_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Registered before (YYYY-MM-DD)",
+                        "name": "registered_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only hospitals flagged for deletion by reconciliation",
+                        "name": "deletion_flagged",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HospitalListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/platform/hospitals/{id}": {
+            "get": {
+                "description": "Returns any hospital by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Hastane detayını getirir (platform yöneticisi)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hospital ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HospitalResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/platform/hospitals/{id}/approve": {
+            "put": {
+                "description": "Approves a pending hospital registration and notifies the registrant",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Bekleyen hastane kaydını onaylar (platform yöneticisi)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hospital ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HospitalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/platform/hospitals/{id}/close": {
+            "put": {
+                "description": "Closes a hospital permanently with a reason",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Hastaneyi kapatır (platform yöneticisi)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hospital ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.HospitalStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HospitalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/platform/hospitals/{id}/reactivate": {
+            "put": {
+                "description": "Reactivates a suspended hospital",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Askıdaki hastaneyi tekrar aktif eder (platform yöneticisi)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hospital ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HospitalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/platform/hospitals/{id}/reject": {
+            "put": {
+                "description": "Rejects a pending hospital registration and notifies the registrant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Bekleyen hastane kaydını reddeder (platform yöneticisi)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hospital ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.HospitalStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HospitalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/platform/hospitals/{id}/suspend": {
+            "put": {
+                "description": "Suspends an active hospital with a reason",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Hastaneyi askıya alır (platform yöneticisi)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hospital ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.HospitalStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HospitalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/platform/polyclinics": {
+            "get": {
+                "description": "Lists the global polyclinic catalog with branch codes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Poliklinik kataloğunu listeler (platform yöneticisi)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.PolyclinicLookup"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a polyclinic with an official branch code to the global catalog",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Kataloğa poliklinik ekler (platform yöneticisi)",
+                "parameters": [
+                    {
+                        "description": "Polyclinic info",
+                        "name": "polyclinic",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PolyclinicRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PolyclinicLookup"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/platform/polyclinics/{id}": {
+            "put": {
+                "description": "Updates name or branch code of a catalog polyclinic",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Katalogdaki polikliniği günceller (platform yöneticisi)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Polyclinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Polyclinic info",
+                        "name": "polyclinic",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PolyclinicRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PolyclinicLookup"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a catalog polyclinic that no hospital uses",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Katalogdan poliklinik siler (platform yöneticisi)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Polyclinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
         "/api/polyclinic/add": {
             "post": {
-                "description": "Adds a polyclinic to the hospital",
+                "description": "Adds a polyclinic to the hospital",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Polyclinic"
+                ],
+                "summary": "Hastaneye poliklinik ekler",
+                "parameters": [
+                    {
+                        "description": "Polyclinic info",
+                        "name": "polyclinic",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AddHospitalPolyclinicRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HospitalPolyclinicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/polyclinic/all": {
+            "get": {
+                "description": "Returns all polyclinics",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Polyclinic"
+                ],
+                "summary": "Tüm poliklinikleri listeler",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.PolyclinicLookup"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/polyclinic/hospital-polyclinics": {
+            "get": {
+                "description": "Lists hospital's polyclinics with pagination. Personnel counts come from a local projection and can be used for sorting and filtering.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Polyclinic"
+                ],
+                "summary": "Hastanenin polikliniklerini listeler (sayfalı)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort field (name, total_personnel)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order (asc, desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum total personnel",
+                        "name": "min_personnel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum total personnel",
+                        "name": "max_personnel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only polyclinics without staff of this job group",
+                        "name": "missing_job_group_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HospitalPolyclinicListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/polyclinic/hospital-polyclinics/{id}": {
+            "delete": {
+                "description": "Removes a polyclinic from the hospital. Fails with 409 while staff are assigned unless reassign_to or force is given. Staff are moved or unassigned asynchronously by the personnel service.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Polyclinic"
+                ],
+                "summary": "Hastaneden poliklinik siler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hospital Polyclinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Hospital Polyclinic ID to move assigned staff to",
+                        "name": "reassign_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Unassign staff from the polyclinic",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/polyclinic/hospital-polyclinics/{id}/capacity": {
+            "put": {
+                "description": "Updates the daily patient capacity of a hospital polyclinic",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Polyclinic"
+                ],
+                "summary": "Polikliniğin günlük kapasitesini günceller",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hospital Polyclinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Capacity info",
+                        "name": "capacity",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateCapacityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/polyclinic/hospital-polyclinics/{id}/holidays": {
+            "post": {
+                "description": "Adds a closed day or a day with special hours to a hospital polyclinic",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Polyclinic"
+                ],
+                "summary": "Polikliniğe tatil veya özel gün ekler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hospital Polyclinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Holiday info",
+                        "name": "holiday",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.HolidayRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HolidayResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/polyclinic/hospital-polyclinics/{id}/holidays/{holidayId}": {
+            "delete": {
+                "description": "Deletes a holiday or special day of a hospital polyclinic",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Polyclinic"
+                ],
+                "summary": "Poliklinik tatilini siler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hospital Polyclinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Holiday ID",
+                        "name": "holidayId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/polyclinic/hospital-polyclinics/{id}/opening-hours": {
+            "put": {
+                "description": "Replaces the weekly opening hours of a hospital polyclinic. Weekday 0 is Sunday.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Polyclinic"
+                ],
+                "summary": "Polikliniğin haftalık çalışma saatlerini günceller",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hospital Polyclinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Opening hours",
+                        "name": "hours",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateOpeningHoursRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.OpeningHour"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/polyclinic/hospital-polyclinics/{id}/rooms": {
+            "post": {
+                "description": "Assigns an exam room to a hospital polyclinic. Floor and number are unique per hospital.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Polyclinic"
+                ],
+                "summary": "Polikliniğe muayene odası atar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hospital Polyclinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Room info",
+                        "name": "room",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ExamRoomRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ExamRoomResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/polyclinic/hospital-polyclinics/{id}/rooms/{roomId}": {
+            "put": {
+                "description": "Updates an exam room of a hospital polyclinic",
                 "consumes": [
                     "application/json"
                 ],
@@ -179,23 +1232,83 @@ const docTemplate = `{
                 "tags": [
                     "Polyclinic"
                 ],
-                "summary": "Hastaneye poliklinik ekler",
+                "summary": "Muayene odasını günceller",
                 "parameters": [
                     {
-                        "description": "Polyclinic info",
-                        "name": "polyclinic",
+                        "type": "integer",
+                        "description": "Hospital Polyclinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "roomId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Room info",
+                        "name": "room",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.AddHospitalPolyclinicRequest"
+                            "$ref": "#/definitions/dto.ExamRoomRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.HospitalPolyclinicResponse"
+                            "$ref": "#/definitions/dto.ExamRoomResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes an exam room from a hospital polyclinic",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Polyclinic"
+                ],
+                "summary": "Muayene odasını siler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hospital Polyclinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "roomId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -210,58 +1323,138 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/polyclinic/all": {
+        "/api/polyclinic/hospital-polyclinics/{id}/units": {
             "get": {
-                "description": "Returns all polyclinics",
+                "description": "Lists local sub-units defined under a hospital polyclinic",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Polyclinic"
                 ],
-                "summary": "Tüm poliklinikleri listeler",
+                "summary": "Hastane polikliniğinin yerel birimlerini listeler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hospital Polyclinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.PolyclinicLookup"
+                                "$ref": "#/definitions/dto.HospitalPolyclinicUnitResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a local sub-unit with display name and code under a hospital polyclinic",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Polyclinic"
+                ],
+                "summary": "Hastane polikliniğine yerel birim ekler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hospital Polyclinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Unit info",
+                        "name": "unit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.HospitalPolyclinicUnitRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HospitalPolyclinicUnitResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
                 }
             }
         },
-        "/api/polyclinic/list": {
-            "get": {
-                "description": "Lists hospital's polyclinics with pagination",
+        "/api/polyclinic/hospital-polyclinics/{id}/units/{unitId}": {
+            "put": {
+                "description": "Updates a local sub-unit of a hospital polyclinic",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Polyclinic"
                 ],
-                "summary": "Hastanenin polikliniklerini listeler (sayfalı)",
+                "summary": "Yerel birimi günceller",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Hospital Polyclinic ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Page size",
-                        "name": "size",
-                        "in": "query"
+                        "description": "Unit ID",
+                        "name": "unitId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Unit info",
+                        "name": "unit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.HospitalPolyclinicUnitRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.HospitalPolyclinicListResponse"
+                            "$ref": "#/definitions/dto.HospitalPolyclinicUnitResponse"
                         }
                     },
                     "400": {
@@ -274,18 +1467,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/polyclinic/remove/{id}": {
+            },
             "delete": {
-                "description": "Removes a polyclinic from the hospital",
+                "description": "Deletes a local sub-unit of a hospital polyclinic",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Polyclinic"
                 ],
-                "summary": "Hastaneden poliklinik siler",
+                "summary": "Yerel birimi siler",
                 "parameters": [
                     {
                         "type": "integer",
@@ -293,6 +1484,13 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Unit ID",
+                        "name": "unitId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -316,59 +1514,263 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.AddHospitalPolyclinicRequest": {
+        },
+        "/api/public/hospitals": {
+            "get": {
+                "description": "Lists active hospitals for patients and partner systems without internal fields",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public"
+                ],
+                "summary": "Hastane rehberinde arama yapar (kimlik doğrulama gerekmez)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (max 50)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hospital name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "City ID",
+                        "name": "city_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "District ID",
+                        "name": "district_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Polyclinic ID",
+                        "name": "polyclinic_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PublicHospitalListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AddHospitalPolyclinicRequest": {
+            "type": "object",
+            "properties": {
+                "polyclinic_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CityLookup": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "plate_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.DistrictLookup": {
             "type": "object",
             "properties": {
-                "polyclinic_id": {
+                "city_id": {
                     "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "dto.CityLookup": {
+        "dto.ExamRoomRequest": {
+            "type": "object",
+            "properties": {
+                "floor": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ExamRoomResponse": {
             "type": "object",
             "properties": {
+                "floor": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
                 "name": {
                     "type": "string"
+                },
+                "number": {
+                    "type": "string"
                 }
             }
         },
-        "dto.DistrictLookup": {
+        "dto.HolidayRequest": {
             "type": "object",
             "properties": {
-                "city_id": {
-                    "type": "integer"
+                "closed": {
+                    "type": "boolean"
+                },
+                "closes_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "opens_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.HolidayResponse": {
+            "type": "object",
+            "properties": {
+                "closed": {
+                    "type": "boolean"
+                },
+                "closes_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "opens_at": {
                     "type": "string"
                 }
             }
         },
+        "dto.HospitalListResponse": {
+            "type": "object",
+            "properties": {
+                "hospitals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.HospitalResponse"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "dto.HospitalPolyclinicDetail": {
             "type": "object",
             "properties": {
+                "daily_capacity": {
+                    "type": "integer"
+                },
+                "holidays": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.HolidayResponse"
+                    }
+                },
                 "id": {
                     "type": "integer"
                 },
+                "opening_hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OpeningHour"
+                    }
+                },
                 "personnel_groups": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/dto.PolyclinicPersonnelGroup"
                     }
                 },
+                "polyclinic_code": {
+                    "type": "string"
+                },
                 "polyclinic_name": {
                     "type": "string"
                 },
+                "rooms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ExamRoomResponse"
+                    }
+                },
                 "total_personnel": {
                     "type": "integer"
+                },
+                "units": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.HospitalPolyclinicUnitResponse"
+                    }
                 }
             }
         },
@@ -406,18 +1808,55 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.HospitalPolyclinicUnitRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.HospitalPolyclinicUnitResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "hospital_polyclinic_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.HospitalResponse": {
             "type": "object",
             "properties": {
                 "address": {
                     "type": "string"
                 },
+                "approved_at": {
+                    "type": "string"
+                },
                 "city_id": {
                     "type": "integer"
                 },
                 "city_name": {
                     "type": "string"
                 },
+                "created_at": {
+                    "type": "string"
+                },
+                "deletion_flagged_at": {
+                    "type": "string"
+                },
                 "district_id": {
                     "type": "integer"
                 },
@@ -430,20 +1869,110 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
                 "name": {
                     "type": "string"
                 },
                 "phone": {
                     "type": "string"
                 },
+                "status": {
+                    "type": "string"
+                },
+                "status_reason": {
+                    "type": "string"
+                },
                 "tax_number": {
                     "type": "string"
                 }
             }
         },
+        "dto.HospitalStatusRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.NearbyHospitalListResponse": {
+            "type": "object",
+            "properties": {
+                "hospitals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.NearbyHospitalResponse"
+                    }
+                },
+                "radius_km": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.NearbyHospitalResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "approximate": {
+                    "description": "Hastanenin kendi koordinatı yoksa ilçe/il merkezi kullanılır",
+                    "type": "boolean"
+                },
+                "city_name": {
+                    "type": "string"
+                },
+                "distance_km": {
+                    "type": "number"
+                },
+                "district_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.OpeningHour": {
+            "type": "object",
+            "properties": {
+                "closes_at": {
+                    "description": "HH:MM",
+                    "type": "string"
+                },
+                "opens_at": {
+                    "description": "HH:MM",
+                    "type": "string"
+                },
+                "weekday": {
+                    "description": "0 = Pazar ... 6 = Cumartesi",
+                    "type": "integer"
+                }
+            }
+        },
         "dto.PolyclinicLookup": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -463,6 +1992,86 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.PolyclinicRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PublicHospitalListResponse": {
+            "type": "object",
+            "properties": {
+                "hospitals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PublicHospitalResponse"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.PublicHospitalResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "city_id": {
+                    "type": "integer"
+                },
+                "city_name": {
+                    "type": "string"
+                },
+                "district_id": {
+                    "type": "integer"
+                },
+                "district_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "polyclinics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.UpdateCapacityRequest": {
+            "type": "object",
+            "properties": {
+                "daily_capacity": {
+                    "type": "integer"
+                }
+            }
+        },
         "dto.UpdateHospitalRequest": {
             "type": "object",
             "properties": {
@@ -472,12 +2081,22 @@ const docTemplate = `{
                 "city_id": {
                     "type": "integer"
                 },
+                "clear_coordinates": {
+                    "type": "boolean"
+                },
                 "district_id": {
                     "type": "integer"
                 },
                 "email": {
                     "type": "string"
                 },
+                "latitude": {
+                    "description": "Koordinatlar gönderilmezse değişmez; silmek için clear_coordinates kullanılır",
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -488,6 +2107,17 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "dto.UpdateOpeningHoursRequest": {
+            "type": "object",
+            "properties": {
+                "hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OpeningHour"
+                    }
+                }
+            }
         }
     }
 }`
@@ -12,6 +12,9 @@ type Config struct {
 	Redis    RedisConfig
 	JWT      JWTConfig
 	Url      PersonnelService `mapstructure:"personnel_service"`
+	Auth     AuthService      `mapstructure:"auth_service"`
+	SMTP     SMTPConfig       `mapstructure:"smtp"`
+	Internal InternalConfig   `mapstructure:"internal"`
 }
 
 type ServerConfig struct {
@@ -40,10 +43,30 @@ type JWTConfig struct {
 	RefreshTokenExpiry string `mapstructure:"refresh_token_expiry"`
 }
 
+// SMTPConfig bildirim e-postaları için, host boşsa bildirimler sadece loglanır
+// Alanları notification.SMTPConfig ile aynı tutulmalı, notifier oluşturulurken ona dönüştürülür
+type SMTPConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     string `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	From     string `mapstructure:"from"`
+}
+
 type PersonnelService struct {
 	BaseUrl string `mapstructure:"base_url"`
 }
 
+// AuthService tutarlılık kontrolünde yetkili kayıtlarını okumak için kullanılır
+type AuthService struct {
+	BaseUrl string `mapstructure:"base_url"`
+}
+
+// InternalConfig servisler arası endpointleri koruyan paylaşılan anahtar, tüm servislerde aynı olmalı
+type InternalConfig struct {
+	Token string `mapstructure:"token"`
+}
+
 func LoadConfig(path string) (config Config, err error) {
 	viper.AddConfigPath(path)
 	viper.SetConfigName("config")
@@ -0,0 +1,69 @@
+// Package consumer hospital servisinin diğer servislerden gelen olaylara tepkilerini içerir
+package consumer
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"hospital-service/internal/models"
+	"hospital-service/internal/repository"
+	"hospital-shared/events"
+
+	"gorm.io/gorm"
+)
+
+func Register(c *events.Consumer) {
+	c.On(events.StaffAssigned, onStaffAssigned)
+	c.On(events.PolyclinicStaffCounted, onPolyclinicStaffCounted)
+}
+
+// Personel sayısı projeksiyonu personnel servisinin mutlak sayımlarıyla güncellenir
+func onPolyclinicStaffCounted(ctx context.Context, tx *gorm.DB, e events.Event) error {
+	var p events.PolyclinicStaffCountedPayload
+	if err := e.Decode(&p); err != nil {
+		return err
+	}
+	return repository.NewStaffStatsRepository(tx).Apply(p.CountedAt, p.Stats)
+}
+
+func onStaffAssigned(ctx context.Context, tx *gorm.DB, e events.Event) error {
+	var p events.StaffAssignedPayload
+	if err := e.Decode(&p); err != nil {
+		return err
+	}
+	if p.HospitalPolyclinicID == nil {
+		return nil
+	}
+	return checkAssignmentTarget(tx, p)
+}
+
+// Personel, kaldırılmakta olan bir polikliniğe eşzamanlı atanmış olabilir. Bu durumda kaldırma
+// olayı aynı taşıma hedefiyle tekrar yayınlanır ve personnel servisi personeli taşır ya da
+// atamasını kaldırır.
+func checkAssignmentTarget(tx *gorm.DB, p events.StaffAssignedPayload) error {
+	var hp models.HospitalPolyclinic
+	err := tx.Unscoped().First(&hp, *p.HospitalPolyclinicID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Printf("staff %d assigned to unknown hospital polyclinic %d", p.StaffID, *p.HospitalPolyclinicID)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if hp.HospitalID != p.HospitalID {
+		log.Printf("staff %d of hospital %d assigned to hospital polyclinic %d of hospital %d",
+			p.StaffID, p.HospitalID, hp.ID, hp.HospitalID)
+		return nil
+	}
+	if !hp.DeletedAt.Valid {
+		return nil
+	}
+	return events.Enqueue(tx, repository.EventSource, events.HospitalPolyclinicRemoved, events.HospitalPolyclinicRemovedPayload{
+		HospitalID:           hp.HospitalID,
+		HospitalPolyclinicID: hp.ID,
+		PolyclinicID:         hp.PolyclinicID,
+		ReassignTo:           hp.RemovalReassignTo,
+	})
+}
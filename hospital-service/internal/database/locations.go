@@ -0,0 +1,192 @@
+package database
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"hospital-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+//go:embed data/turkey_locations.json
+var turkeyLocations []byte
+
+// İlçe merkez koordinatları plaka koduna göre gruplanır; veri setinde olmayan ilçeler boş kalır
+//
+//go:embed data/district_coordinates.json
+var districtCoordinates []byte
+
+// ProvinceData gömülü veri dosyasındaki bir ili ve ilçelerini temsil eder
+type ProvinceData struct {
+	PlateCode int      `json:"plate_code"`
+	Name      string   `json:"name"`
+	Latitude  float64  `json:"latitude"`
+	Longitude float64  `json:"longitude"`
+	Districts []string `json:"districts"`
+}
+
+// LocationImportResult içe aktarım sırasında yapılan değişiklikleri özetler
+type LocationImportResult struct {
+	CitiesCreated    int
+	CitiesUpdated    int
+	DistrictsCreated int
+	DistrictsLocated int
+}
+
+// LoadProvinces gömülü il/ilçe veri setini çözer
+func LoadProvinces() ([]ProvinceData, error) {
+	var provinces []ProvinceData
+	if err := json.Unmarshal(turkeyLocations, &provinces); err != nil {
+		return nil, fmt.Errorf("failed to parse location dataset: %w", err)
+	}
+	return provinces, nil
+}
+
+// ImportLocations il ve ilçeleri veri setine göre günceller.
+// Kayıtlar hiçbir zaman silinmez ve mevcut ID'ler korunur; böylece
+// hastanelerdeki CityID/DistrictID referansları bozulmaz. Tekrar
+// çalıştırıldığında yalnızca eksik kayıtlar eklenir.
+func ImportLocations(db *gorm.DB) (*LocationImportResult, error) {
+	provinces, err := LoadProvinces()
+	if err != nil {
+		return nil, err
+	}
+	coordinates, err := loadDistrictCoordinates()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &LocationImportResult{}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, p := range provinces {
+			city, created, updated, err := upsertCity(tx, p)
+			if err != nil {
+				return err
+			}
+			if created {
+				result.CitiesCreated++
+			}
+			if updated {
+				result.CitiesUpdated++
+			}
+
+			n, err := insertMissingDistricts(tx, city.ID, p.Districts)
+			if err != nil {
+				return err
+			}
+			result.DistrictsCreated += n
+
+			located, err := fillDistrictCoordinates(tx, city.ID, p, coordinates[p.PlateCode])
+			if err != nil {
+				return err
+			}
+			result.DistrictsLocated += located
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// upsertCity ili önce plaka koduna, bulunamazsa isme göre eşleştirir.
+// İsimle eşleşme plaka kodu olmadan eklenmiş eski seed kayıtlarını kapsar.
+func upsertCity(tx *gorm.DB, p ProvinceData) (*models.City, bool, bool, error) {
+	var city models.City
+	err := tx.Where("plate_code = ?", p.PlateCode).First(&city).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = tx.Where("name = ?", p.Name).First(&city).Error
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		plate, lat, lng := p.PlateCode, p.Latitude, p.Longitude
+		city = models.City{Name: p.Name, PlateCode: &plate, Latitude: &lat, Longitude: &lng}
+		if err := tx.Create(&city).Error; err != nil {
+			return nil, false, false, fmt.Errorf("failed to create city %s: %w", p.Name, err)
+		}
+		return &city, true, false, nil
+	}
+	if err != nil {
+		return nil, false, false, err
+	}
+
+	if city.Name == p.Name && city.PlateCode != nil && *city.PlateCode == p.PlateCode &&
+		city.Latitude != nil && *city.Latitude == p.Latitude &&
+		city.Longitude != nil && *city.Longitude == p.Longitude {
+		return &city, false, false, nil
+	}
+
+	plate, lat, lng := p.PlateCode, p.Latitude, p.Longitude
+	updates := models.City{Name: p.Name, PlateCode: &plate, Latitude: &lat, Longitude: &lng}
+	if err := tx.Model(&city).Updates(updates).Error; err != nil {
+		return nil, false, false, fmt.Errorf("failed to update city %s: %w", p.Name, err)
+	}
+	return &city, false, true, nil
+}
+
+func insertMissingDistricts(tx *gorm.DB, cityID uint, names []string) (int, error) {
+	var existing []string
+	if err := tx.Model(&models.District{}).Where("city_id = ?", cityID).Pluck("name", &existing).Error; err != nil {
+		return 0, err
+	}
+
+	known := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		known[name] = true
+	}
+
+	created := 0
+	for _, name := range names {
+		if known[name] {
+			continue
+		}
+		district := models.District{Name: name, CityID: cityID}
+		if err := tx.Create(&district).Error; err != nil {
+			return 0, fmt.Errorf("failed to create district %s: %w", name, err)
+		}
+		created++
+	}
+	return created, nil
+}
+
+// loadDistrictCoordinates gömülü ilçe koordinatlarını plaka kodu ve ilçe adına göre döner
+func loadDistrictCoordinates() (map[int]map[string][2]float64, error) {
+	var byPlate map[int]map[string][2]float64
+	if err := json.Unmarshal(districtCoordinates, &byPlate); err != nil {
+		return nil, fmt.Errorf("failed to parse district coordinates: %w", err)
+	}
+	return byPlate, nil
+}
+
+// fillDistrictCoordinates koordinatı olmayan ilçelere veri setindeki ilçe merkezini yazar.
+// "Merkez" ilçesi il merkeziyle aynı yerdedir. Elle girilmiş koordinatlar ezilmez.
+func fillDistrictCoordinates(tx *gorm.DB, cityID uint, p ProvinceData, coords map[string][2]float64) (int, error) {
+	if _, ok := coords["Merkez"]; !ok {
+		coords = withCentralDistrict(coords, p)
+	}
+
+	located := 0
+	for name, c := range coords {
+		res := tx.Model(&models.District{}).
+			Where("city_id = ? AND name = ? AND latitude IS NULL", cityID, name).
+			Updates(map[string]interface{}{"latitude": c[0], "longitude": c[1]})
+		if res.Error != nil {
+			return 0, fmt.Errorf("failed to set coordinates of district %s: %w", name, res.Error)
+		}
+		located += int(res.RowsAffected)
+	}
+	return located, nil
+}
+
+func withCentralDistrict(coords map[string][2]float64, p ProvinceData) map[string][2]float64 {
+	merged := make(map[string][2]float64, len(coords)+1)
+	for name, c := range coords {
+		merged[name] = c
+	}
+	merged["Merkez"] = [2]float64{p.Latitude, p.Longitude}
+	return merged
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"hospital-service/internal/models"
+	"hospital-shared/events"
 
 	"gorm.io/gorm"
 )
@@ -18,65 +19,37 @@ func RunMigrations(db *gorm.DB) error {
 		&models.District{},
 		&models.Polyclinic{},
 		&models.HospitalPolyclinic{},
+		&models.HospitalPolyclinicUnit{},
+		&models.PolyclinicOpeningHour{},
+		&models.PolyclinicHoliday{},
+		&models.ExamRoom{},
+		&models.PolyclinicStaffStat{},
+		&models.PolyclinicStaffGroupStat{},
 	)
 	if err != nil {
 		return err
 	}
+	if err := events.Migrate(db); err != nil {
+		return err
+	}
 
 	// Seed data ekleme
 	return seedData(db)
 }
 
 func seedData(db *gorm.DB) error {
-	// Şehir verilerini kontrol et, yoksa ekle
-	var cityCount int64
-	db.Model(&models.City{}).Count(&cityCount)
-
-	if cityCount == 0 {
-		cities := []models.City{
-			{Name: "İstanbul"},
-			{Name: "Ankara"},
-			{Name: "İzmir"},
-			{Name: "Bursa"},
-			{Name: "Antalya"},
-		}
-
-		for _, city := range cities {
-			if err := db.Create(&city).Error; err != nil {
-				return fmt.Errorf("failed to create city %s: %w", city.Name, err)
-			}
+	// İl/ilçe verileri plaka kodu ve koordinatı olan kayıt yoksa gömülü veri setinden yüklenir.
+	// Sonraki güncellemeler için cmd/import-locations komutu kullanılır.
+	var importedCount int64
+	db.Model(&models.City{}).Where("plate_code IS NOT NULL AND latitude IS NOT NULL").Count(&importedCount)
+
+	if importedCount == 0 {
+		result, err := ImportLocations(db)
+		if err != nil {
+			return fmt.Errorf("failed to import locations: %w", err)
 		}
-
-		// İlçe verilerini ekle
-		districts := []models.District{
-			// İstanbul ilçeleri (city_id: 1)
-			{Name: "Kadıköy", CityID: 1},
-			{Name: "Beşiktaş", CityID: 1},
-			{Name: "Şişli", CityID: 1},
-			{Name: "Üsküdar", CityID: 1},
-			// Ankara ilçeleri (city_id: 2)
-			{Name: "Çankaya", CityID: 2},
-			{Name: "Keçiören", CityID: 2},
-			{Name: "Yenimahalle", CityID: 2},
-			// İzmir ilçeleri (city_id: 3)
-			{Name: "Konak", CityID: 3},
-			{Name: "Karşıyaka", CityID: 3},
-			{Name: "Bornova", CityID: 3},
-			// Bursa ilçeleri (city_id: 4)
-			{Name: "Osmangazi", CityID: 4},
-			{Name: "Nilüfer", CityID: 4},
-			// Antalya ilçeleri (city_id: 5)
-			{Name: "Muratpaşa", CityID: 5},
-			{Name: "Kepez", CityID: 5},
-		}
-
-		for _, district := range districts {
-			if err := db.Create(&district).Error; err != nil {
-				return fmt.Errorf("failed to create district %s: %w", district.Name, err)
-			}
-		}
-
-		fmt.Println("Seed data created successfully!")
+		fmt.Printf("Location data imported: %d cities created, %d updated, %d districts created, %d districts located\n",
+			result.CitiesCreated, result.CitiesUpdated, result.DistrictsCreated, result.DistrictsLocated)
 	}
 
 	// Polyclinic seed data ekleme
@@ -1,16 +1,26 @@
 package dto
 
+import "time"
+
 type HospitalResponse struct {
-	ID           uint   `json:"id"`
-	Name         string `json:"name"`
-	TaxNumber    string `json:"tax_number"`
-	Email        string `json:"email"`
-	Phone        string `json:"phone"`
-	Address      string `json:"address"`
-	CityID       uint   `json:"city_id"`
-	CityName     string `json:"city_name"`
-	DistrictID   uint   `json:"district_id"`
-	DistrictName string `json:"district_name"`
+	ID           uint       `json:"id"`
+	Name         string     `json:"name"`
+	TaxNumber    string     `json:"tax_number"`
+	Email        string     `json:"email"`
+	Phone        string     `json:"phone"`
+	Address      string     `json:"address"`
+	CityID       uint       `json:"city_id"`
+	CityName     string     `json:"city_name"`
+	DistrictID   uint       `json:"district_id"`
+	DistrictName string     `json:"district_name"`
+	Latitude     *float64   `json:"latitude,omitempty"`
+	Longitude    *float64   `json:"longitude,omitempty"`
+	Status       string     `json:"status"`
+	StatusReason string     `json:"status_reason,omitempty"`
+	ApprovedAt   *time.Time `json:"approved_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+
+	DeletionFlaggedAt *time.Time `json:"deletion_flagged_at,omitempty"`
 }
 
 type UpdateHospitalRequest struct {
@@ -21,4 +31,82 @@ type UpdateHospitalRequest struct {
 	Address    string `json:"address"`
 	CityID     uint   `json:"city_id"`
 	DistrictID uint   `json:"district_id"`
-}
\ No newline at end of file
+	// Koordinatlar gönderilmezse değişmez; silmek için clear_coordinates kullanılır
+	Latitude         *float64 `json:"latitude"`
+	Longitude        *float64 `json:"longitude"`
+	ClearCoordinates bool     `json:"clear_coordinates"`
+}
+
+type HospitalListFilter struct {
+	Name           string
+	TaxNumber      string
+	CityID         *uint
+	DistrictID     *uint
+	PolyclinicID   *uint
+	Status         string
+	RegisteredFrom *time.Time
+	RegisteredTo   *time.Time
+
+	DeletionFlagged bool
+	// ExcludeDeletionFlagged tutarlılık kontrolünün silinmek üzere işaretlediği hastaneleri gizler
+	ExcludeDeletionFlagged bool
+}
+
+type HospitalListResponse struct {
+	Hospitals []HospitalResponse `json:"hospitals"`
+	Total     int                `json:"total"`
+	Page      int                `json:"page"`
+	Size      int                `json:"size"`
+}
+
+type HospitalStatusRequest struct {
+	Reason string `json:"reason"`
+}
+
+type NearbyHospitalFilter struct {
+	Latitude     float64
+	Longitude    float64
+	RadiusKm     float64
+	PolyclinicID *uint
+}
+
+type NearbyHospitalResponse struct {
+	ID           uint    `json:"id"`
+	Name         string  `json:"name"`
+	Phone        string  `json:"phone"`
+	Address      string  `json:"address"`
+	CityName     string  `json:"city_name"`
+	DistrictName string  `json:"district_name"`
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
+	DistanceKm   float64 `json:"distance_km"`
+	// Hastanenin kendi koordinatı yoksa ilçe/il merkezi kullanılır
+	Approximate bool `json:"approximate"`
+}
+
+type NearbyHospitalListResponse struct {
+	Hospitals []NearbyHospitalResponse `json:"hospitals"`
+	RadiusKm  float64                  `json:"radius_km"`
+}
+
+// PublicHospitalResponse herkese açık dizinde gösterilen alanlar; vergi numarası ve durum bilgileri yer almaz
+type PublicHospitalResponse struct {
+	ID           uint     `json:"id"`
+	Name         string   `json:"name"`
+	Phone        string   `json:"phone"`
+	Address      string   `json:"address"`
+	CityID       uint     `json:"city_id"`
+	CityName     string   `json:"city_name"`
+	DistrictID   uint     `json:"district_id"`
+	DistrictName string   `json:"district_name"`
+	Latitude     *float64 `json:"latitude,omitempty"`
+	Longitude    *float64 `json:"longitude,omitempty"`
+	Polyclinics  []string `json:"polyclinics"`
+}
+
+type PublicHospitalListResponse struct {
+	Hospitals []PublicHospitalResponse `json:"hospitals"`
+	Total     int                      `json:"total"`
+	Page      int                      `json:"page"`
+	Size      int                      `json:"size"`
+}
@@ -1,8 +1,9 @@
 package dto
 
 type CityLookup struct {
-	ID   uint   `json:"id"`
-	Name string `json:"name"`
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	PlateCode int    `json:"plate_code,omitempty"`
 }
 
 type DistrictLookup struct {
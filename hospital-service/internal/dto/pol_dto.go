@@ -3,6 +3,24 @@ package dto
 type PolyclinicLookup struct {
 	ID   uint   `json:"id"`
 	Name string `json:"name"`
+	Code string `json:"code,omitempty"`
+}
+
+type PolyclinicRequest struct {
+	Name string `json:"name"`
+	Code string `json:"code"`
+}
+
+type HospitalPolyclinicUnitRequest struct {
+	Name string `json:"name"`
+	Code string `json:"code"`
+}
+
+type HospitalPolyclinicUnitResponse struct {
+	ID                   uint   `json:"id"`
+	HospitalPolyclinicID uint   `json:"hospital_polyclinic_id"`
+	Name                 string `json:"name"`
+	Code                 string `json:"code,omitempty"`
 }
 
 type AddHospitalPolyclinicRequest struct {
@@ -21,10 +39,30 @@ type PolyclinicPersonnelGroup struct {
 }
 
 type HospitalPolyclinicDetail struct {
-	ID              uint                      `json:"id"`
-	PolyclinicName  string                    `json:"polyclinic_name"`
-	TotalPersonnel  int                       `json:"total_personnel"`
-	PersonnelGroups []PolyclinicPersonnelGroup `json:"personnel_groups"`
+	ID              uint                             `json:"id"`
+	PolyclinicName  string                           `json:"polyclinic_name"`
+	PolyclinicCode  string                           `json:"polyclinic_code,omitempty"`
+	Units           []HospitalPolyclinicUnitResponse `json:"units"`
+	DailyCapacity   int                              `json:"daily_capacity"`
+	OpeningHours    []OpeningHour                    `json:"opening_hours"`
+	Holidays        []HolidayResponse                `json:"holidays"`
+	Rooms           []ExamRoomResponse               `json:"rooms"`
+	TotalPersonnel  int                              `json:"total_personnel"`
+	PersonnelGroups []PolyclinicPersonnelGroup       `json:"personnel_groups"`
+}
+
+const (
+	SortByName           = "name"
+	SortByTotalPersonnel = "total_personnel"
+)
+
+// HospitalPolyclinicListFilter personel sayılarına göre sıralama ve filtreleme seçenekleridir
+type HospitalPolyclinicListFilter struct {
+	Sort              string // name, total_personnel
+	Order             string // asc, desc
+	MinPersonnel      *int
+	MaxPersonnel      *int
+	MissingJobGroupID *uint // bu meslek grubundan personeli olmayan poliklinikler (ör. doktoru olmayanlar)
 }
 
 type HospitalPolyclinicListResponse struct {
@@ -32,4 +70,54 @@ type HospitalPolyclinicListResponse struct {
 	Total       int                        `json:"total"`
 	Page        int                        `json:"page"`
 	Size        int                        `json:"size"`
-}
\ No newline at end of file
+}
+
+type OpeningHour struct {
+	Weekday  int    `json:"weekday"`   // 0 = Pazar ... 6 = Cumartesi
+	OpensAt  string `json:"opens_at"`  // HH:MM
+	ClosesAt string `json:"closes_at"` // HH:MM
+}
+
+type UpdateOpeningHoursRequest struct {
+	Hours []OpeningHour `json:"hours"`
+}
+
+// RemoveHospitalPolyclinicOptions polikliniğe atanmış personel varken kaldırmanın nasıl yapılacağını belirler
+type RemoveHospitalPolyclinicOptions struct {
+	ReassignTo *uint // personel bu hastane polikliniğine taşınır
+	Force      bool  // personelin poliklinik ataması kaldırılır
+}
+
+type UpdateCapacityRequest struct {
+	DailyCapacity int `json:"daily_capacity"`
+}
+
+type HolidayRequest struct {
+	Date        string `json:"date"` // YYYY-MM-DD
+	Closed      bool   `json:"closed"`
+	OpensAt     string `json:"opens_at"`
+	ClosesAt    string `json:"closes_at"`
+	Description string `json:"description"`
+}
+
+type HolidayResponse struct {
+	ID          uint   `json:"id"`
+	Date        string `json:"date"`
+	Closed      bool   `json:"closed"`
+	OpensAt     string `json:"opens_at,omitempty"`
+	ClosesAt    string `json:"closes_at,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type ExamRoomRequest struct {
+	Floor  string `json:"floor"`
+	Number string `json:"number"`
+	Name   string `json:"name"`
+}
+
+type ExamRoomResponse struct {
+	ID     uint   `json:"id"`
+	Floor  string `json:"floor"`
+	Number string `json:"number"`
+	Name   string `json:"name,omitempty"`
+}
@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"errors"
+	"strconv"
+
 	"hospital-service/internal/config"
 	"hospital-service/internal/dto"
 	"hospital-service/internal/usecase"
@@ -56,6 +59,62 @@ func (h *HospitalHandler) CreateHospital(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(resp)
 }
 
+func (h *HospitalHandler) GetHospitalStatus(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid hospital ID"})
+	}
+
+	status, err := h.hospitalUsecase.GetHospitalStatus(uint(id))
+	if errors.Is(err, usecase.ErrHospitalNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(dt.HospitalStatusResponse{ID: uint(id), Status: status})
+}
+
+// NearbyHospitals godoc
+// @Summary     Konuma en yakın hastaneleri listeler
+// @Description Returns active hospitals within the radius sorted by distance, optionally filtered by polyclinic
+// @Tags        Hospital
+// @Produce     json
+// @Param       lat query number true "Latitude"
+// @Param       lng query number true "Longitude"
+// @Param       radius query number false "Radius in km (default 10, max 200)"
+// @Param       polyclinic_id query int false "Polyclinic ID"
+// @Success     200 {object} dto.NearbyHospitalListResponse
+// @Failure     400 {object} map[string]string
+// @Failure     401 {object} map[string]string
+// @Router      /api/hospital/nearby [get]
+func (h *HospitalHandler) NearbyHospitals(c *fiber.Ctx) error {
+	lat, err := strconv.ParseFloat(c.Query("lat"), 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid lat"})
+	}
+	lng, err := strconv.ParseFloat(c.Query("lng"), 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid lng"})
+	}
+
+	filter := dto.NearbyHospitalFilter{Latitude: lat, Longitude: lng}
+	if raw := c.Query("radius"); raw != "" {
+		if filter.RadiusKm, err = strconv.ParseFloat(raw, 64); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid radius"})
+		}
+	}
+	if filter.PolyclinicID, err = queryUint(c, "polyclinic_id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid polyclinic_id"})
+	}
+
+	resp, err := h.hospitalUsecase.FindNearbyHospitals(filter)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
 // UpdateHospitalMe godoc
 // @Summary     Giriş yapan kullanıcının hastane bilgisini günceller
 // @Description Updates the hospital info of the authenticated user
@@ -4,6 +4,7 @@ import (
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
+	"hospital-service/internal/dto"
 	"hospital-service/internal/usecase"
 )
 
@@ -23,6 +24,7 @@ func NewLocationHandler(usecase usecase.LocationUsecase) *LocationHandler {
 // @Success     200 {array} dto.CityLookup
 // @Router      /api/cities [get]
 func (h *LocationHandler) ListCities(c *fiber.Ctx) error {
+	var resp []dto.CityLookup
 	resp, err := h.usecase.ListAllCities()
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
@@ -44,7 +46,8 @@ func (h *LocationHandler) ListDistrictsByCity(c *fiber.Ctx) error {
 	if err != nil || cityID == 0 {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid city_id"})
 	}
-	resp, err := h.usecase.ListDistrictsByCity(uint(cityID))
+	var resp []dto.DistrictLookup
+	resp, err = h.usecase.ListDistrictsByCity(uint(cityID))
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
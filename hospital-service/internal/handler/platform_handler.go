@@ -0,0 +1,362 @@
+package handler
+
+import (
+	"strconv"
+	"time"
+
+	"hospital-service/internal/config"
+	"hospital-service/internal/dto"
+	"hospital-service/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PlatformHandler struct {
+	hospitalUsecase   usecase.HospitalUsecase
+	polyclinicUsecase usecase.PolyclinicUsecase
+	config            *config.Config
+}
+
+func NewPlatformHandler(hospitalUsecase usecase.HospitalUsecase, polyclinicUsecase usecase.PolyclinicUsecase, cfg *config.Config) *PlatformHandler {
+	return &PlatformHandler{
+		hospitalUsecase:   hospitalUsecase,
+		polyclinicUsecase: polyclinicUsecase,
+		config:            cfg,
+	}
+}
+
+// ListHospitals godoc
+// @Summary     Tüm hastaneleri listeler ve arar (platform yöneticisi)
+// @Description Lists and searches hospitals across the platform
+// @Tags        Platform
+// @Produce     json
+// @Param       page query int false "Page number"
+// @Param       size query int false "Page size (max 100)"
+// @Param       name query string false "Hospital name"
+// @Param       tax_number query string false "Tax number"
+// @Param       city_id query int false "City ID"
+// @Param       district_id query int false "District ID"
+// @Param       polyclinic_id query int false "Polyclinic ID"
+// @Param       status query string false "Status"
+// @Param       registered_from query string false "Registered on or after (YYYY-MM-DD)"
+// @Param       registered_to query string false "Registered before (YYYY-MM-DD)"
+// @Param       deletion_flagged query bool false "Only hospitals flagged for deletion by reconciliation"
+// @Success     200 {object} dto.HospitalListResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/platform/hospitals [get]
+func (h *PlatformHandler) ListHospitals(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	size, _ := strconv.Atoi(c.Query("size", "10"))
+
+	filter := dto.HospitalListFilter{
+		Name:      c.Query("name", ""),
+		TaxNumber: c.Query("tax_number", ""),
+		Status:    c.Query("status", ""),
+
+		DeletionFlagged: c.QueryBool("deletion_flagged"),
+	}
+
+	var err error
+	if filter.CityID, err = queryUint(c, "city_id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid city_id"})
+	}
+	if filter.DistrictID, err = queryUint(c, "district_id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid district_id"})
+	}
+	if filter.PolyclinicID, err = queryUint(c, "polyclinic_id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid polyclinic_id"})
+	}
+	if filter.RegisteredFrom, err = queryDate(c, "registered_from"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid registered_from"})
+	}
+	if filter.RegisteredTo, err = queryDate(c, "registered_to"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid registered_to"})
+	}
+
+	resp, err := h.hospitalUsecase.ListHospitals(filter, page, size)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// GetHospital godoc
+// @Summary     Hastane detayını getirir (platform yöneticisi)
+// @Description Returns any hospital by ID
+// @Tags        Platform
+// @Produce     json
+// @Param       id path int true "Hospital ID"
+// @Success     200 {object} dto.HospitalResponse
+// @Failure     404 {object} map[string]string
+// @Router      /api/platform/hospitals/{id} [get]
+func (h *PlatformHandler) GetHospital(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid hospital ID"})
+	}
+
+	resp, err := h.hospitalUsecase.GetHospitalByID(uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// ApproveHospital godoc
+// @Summary     Bekleyen hastane kaydını onaylar (platform yöneticisi)
+// @Description Approves a pending hospital registration and notifies the registrant
+// @Tags        Platform
+// @Produce     json
+// @Param       id path int true "Hospital ID"
+// @Success     200 {object} dto.HospitalResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/platform/hospitals/{id}/approve [put]
+func (h *PlatformHandler) ApproveHospital(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid hospital ID"})
+	}
+
+	resp, err := h.hospitalUsecase.ApproveHospital(uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// RejectHospital godoc
+// @Summary     Bekleyen hastane kaydını reddeder (platform yöneticisi)
+// @Description Rejects a pending hospital registration and notifies the registrant
+// @Tags        Platform
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Hospital ID"
+// @Param       body body dto.HospitalStatusRequest true "Reason"
+// @Success     200 {object} dto.HospitalResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/platform/hospitals/{id}/reject [put]
+func (h *PlatformHandler) RejectHospital(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid hospital ID"})
+	}
+
+	req := new(dto.HospitalStatusRequest)
+	if err := c.BodyParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	resp, err := h.hospitalUsecase.RejectHospital(uint(id), req.Reason)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// SuspendHospital godoc
+// @Summary     Hastaneyi askıya alır (platform yöneticisi)
+// @Description Suspends an active hospital with a reason
+// @Tags        Platform
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Hospital ID"
+// @Param       body body dto.HospitalStatusRequest true "Reason"
+// @Success     200 {object} dto.HospitalResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/platform/hospitals/{id}/suspend [put]
+func (h *PlatformHandler) SuspendHospital(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid hospital ID"})
+	}
+
+	req := new(dto.HospitalStatusRequest)
+	if err := c.BodyParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	resp, err := h.hospitalUsecase.SuspendHospital(uint(id), req.Reason)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// ReactivateHospital godoc
+// @Summary     Askıdaki hastaneyi tekrar aktif eder (platform yöneticisi)
+// @Description Reactivates a suspended hospital
+// @Tags        Platform
+// @Produce     json
+// @Param       id path int true "Hospital ID"
+// @Success     200 {object} dto.HospitalResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/platform/hospitals/{id}/reactivate [put]
+func (h *PlatformHandler) ReactivateHospital(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid hospital ID"})
+	}
+
+	resp, err := h.hospitalUsecase.ReactivateHospital(uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// CloseHospital godoc
+// @Summary     Hastaneyi kapatır (platform yöneticisi)
+// @Description Closes a hospital permanently with a reason
+// @Tags        Platform
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Hospital ID"
+// @Param       body body dto.HospitalStatusRequest true "Reason"
+// @Success     200 {object} dto.HospitalResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/platform/hospitals/{id}/close [put]
+func (h *PlatformHandler) CloseHospital(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid hospital ID"})
+	}
+
+	req := new(dto.HospitalStatusRequest)
+	if err := c.BodyParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	resp, err := h.hospitalUsecase.CloseHospital(uint(id), req.Reason)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// ListPolyclinics godoc
+// @Summary     Poliklinik kataloğunu listeler (platform yöneticisi)
+// @Description Lists the global polyclinic catalog with branch codes
+// @Tags        Platform
+// @Produce     json
+// @Success     200 {array} dto.PolyclinicLookup
+// @Router      /api/platform/polyclinics [get]
+func (h *PlatformHandler) ListPolyclinics(c *fiber.Ctx) error {
+	resp, err := h.polyclinicUsecase.ListAllPolyclinics()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// CreatePolyclinic godoc
+// @Summary     Kataloğa poliklinik ekler (platform yöneticisi)
+// @Description Adds a polyclinic with an official branch code to the global catalog
+// @Tags        Platform
+// @Accept      json
+// @Produce     json
+// @Param       polyclinic body dto.PolyclinicRequest true "Polyclinic info"
+// @Success     201 {object} dto.PolyclinicLookup
+// @Failure     400 {object} map[string]string
+// @Router      /api/platform/polyclinics [post]
+func (h *PlatformHandler) CreatePolyclinic(c *fiber.Ctx) error {
+	req := new(dto.PolyclinicRequest)
+	if err := c.BodyParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	resp, err := h.polyclinicUsecase.CreatePolyclinic(req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// UpdatePolyclinic godoc
+// @Summary     Katalogdaki polikliniği günceller (platform yöneticisi)
+// @Description Updates name or branch code of a catalog polyclinic
+// @Tags        Platform
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Polyclinic ID"
+// @Param       polyclinic body dto.PolyclinicRequest true "Polyclinic info"
+// @Success     200 {object} dto.PolyclinicLookup
+// @Failure     400 {object} map[string]string
+// @Router      /api/platform/polyclinics/{id} [put]
+func (h *PlatformHandler) UpdatePolyclinic(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid polyclinic ID"})
+	}
+
+	req := new(dto.PolyclinicRequest)
+	if err := c.BodyParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	resp, err := h.polyclinicUsecase.UpdatePolyclinic(uint(id), req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// DeletePolyclinic godoc
+// @Summary     Katalogdan poliklinik siler (platform yöneticisi)
+// @Description Deletes a catalog polyclinic that no hospital uses
+// @Tags        Platform
+// @Produce     json
+// @Param       id path int true "Polyclinic ID"
+// @Success     200 {object} map[string]string
+// @Failure     400 {object} map[string]string
+// @Router      /api/platform/polyclinics/{id} [delete]
+func (h *PlatformHandler) DeletePolyclinic(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid polyclinic ID"})
+	}
+
+	if err := h.polyclinicUsecase.DeletePolyclinic(uint(id)); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"message": "Polyclinic deleted"})
+}
+
+// queryUint opsiyonel pozitif sayı query parametresini okur
+func queryUint(c *fiber.Ctx, key string) (*uint, error) {
+	v := c.Query(key, "")
+	if v == "" {
+		return nil, nil
+	}
+	id, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	u := uint(id)
+	return &u, nil
+}
+
+// queryInt opsiyonel tam sayı query parametresini okur
+func queryInt(c *fiber.Ctx, key string) (*int, error) {
+	v := c.Query(key, "")
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// queryDate opsiyonel YYYY-MM-DD query parametresini okur
+func queryDate(c *fiber.Ctx, key string) (*time.Time, error) {
+	v := c.Query(key, "")
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
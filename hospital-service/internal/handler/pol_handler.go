@@ -1,11 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"strconv"
 
 	"hospital-service/internal/config"
 	"hospital-service/internal/dto"
 	"hospital-service/internal/usecase"
+	dt "hospital-shared/dto"
 	"hospital-shared/jwt"
 
 	"github.com/gofiber/fiber/v2"
@@ -70,24 +72,48 @@ func (h *PolyclinicHandler) AddHospitalPolyclinic(c *fiber.Ctx) error {
 
 // ListHospitalPolyclinic godoc
 // @Summary     Hastanenin polikliniklerini listeler (sayfalı)
-// @Description Lists hospital's polyclinics with pagination
+// @Description Lists hospital's polyclinics with pagination. Personnel counts come from a local projection and can be used for sorting and filtering.
 // @Tags        Polyclinic
 // @Produce     json
 // @Param       page query int false "Page number"
 // @Param       size query int false "Page size"
+// @Param       sort query string false "Sort field (name, total_personnel)"
+// @Param       order query string false "Sort order (asc, desc)"
+// @Param       min_personnel query int false "Minimum total personnel"
+// @Param       max_personnel query int false "Maximum total personnel"
+// @Param       missing_job_group_id query int false "Only polyclinics without staff of this job group"
 // @Success     200 {object} dto.HospitalPolyclinicListResponse
 // @Failure     400 {object} map[string]string
-// @Router      /api/polyclinic/list [get]
+// @Failure     500 {object} map[string]string
+// @Router      /api/polyclinic/hospital-polyclinics [get]
 func (h *PolyclinicHandler) ListHospitalPolyclinic(c *fiber.Ctx) error {
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	size, _ := strconv.Atoi(c.Query("size", "10"))
 
+	filter := dto.HospitalPolyclinicListFilter{
+		Sort:  c.Query("sort"),
+		Order: c.Query("order"),
+	}
+	var err error
+	if filter.MinPersonnel, err = queryInt(c, "min_personnel"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid min_personnel"})
+	}
+	if filter.MaxPersonnel, err = queryInt(c, "max_personnel"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid max_personnel"})
+	}
+	if filter.MissingJobGroupID, err = queryUint(c, "missing_job_group_id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid missing_job_group_id"})
+	}
+
 	user := jwt.GetUserInfo(c)
 	if user == nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
 	}
 
-	resp, err := h.polyclinicUsecase.ListHospitalPolyclinic(user.HospitalID, page, size)
+	resp, err := h.polyclinicUsecase.ListHospitalPolyclinic(user.HospitalID, filter, page, size)
+	if errors.Is(err, usecase.ErrInvalidHospitalPolyclinicFilter) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
@@ -97,26 +123,41 @@ func (h *PolyclinicHandler) ListHospitalPolyclinic(c *fiber.Ctx) error {
 
 // RemoveHospitalPolyclinic godoc
 // @Summary     Hastaneden poliklinik siler
-// @Description Removes a polyclinic from the hospital
+// @Description Removes a polyclinic from the hospital. Fails with 409 while staff are assigned unless reassign_to or force is given. Staff are moved or unassigned asynchronously by the personnel service.
 // @Tags        Polyclinic
 // @Produce     json
 // @Param       id path int true "Hospital Polyclinic ID"
+// @Param       reassign_to query int false "Hospital Polyclinic ID to move assigned staff to"
+// @Param       force query bool false "Unassign staff from the polyclinic"
 // @Success     200 {object} map[string]string
 // @Failure     400 {object} map[string]string
-// @Router      /api/polyclinic/remove/{id} [delete]
+// @Failure     409 {object} map[string]string
+// @Failure     503 {object} map[string]string
+// @Router      /api/polyclinic/hospital-polyclinics/{id} [delete]
 func (h *PolyclinicHandler) RemoveHospitalPolyclinic(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
 	}
 
+	var opts dto.RemoveHospitalPolyclinicOptions
+	if opts.ReassignTo, err = queryUint(c, "reassign_to"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid reassign_to"})
+	}
+	opts.Force = c.QueryBool("force")
+
 	user := jwt.GetUserInfo(c)
 	if user == nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
 	}
 
-	err = h.polyclinicUsecase.RemoveHospitalPolyclinic(uint(id), user.HospitalID)
-	if err != nil {
+	err = h.polyclinicUsecase.RemoveHospitalPolyclinic(uint(id), user.HospitalID, opts)
+	switch {
+	case errors.Is(err, usecase.ErrStaffCountUnavailable):
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, usecase.ErrHospitalPolyclinicHasStaff):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	case err != nil:
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 
@@ -136,3 +177,162 @@ func (h *PolyclinicHandler) GetHospitalPolyclinic(c *fiber.Ctx) error {
 
 	return c.JSON(hp)
 }
+
+// ListHospitalPolyclinicRefs personel servisinin toplu içe aktarımı için kullanılır - JWT yerine servisler arası anahtar ister
+func (h *PolyclinicHandler) ListHospitalPolyclinicRefs(c *fiber.Ctx) error {
+	hospitalID, err := strconv.ParseUint(c.Params("hospitalId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid ID"})
+	}
+
+	refs, err := h.polyclinicUsecase.ListHospitalPolyclinicRefs(uint(hospitalID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(refs)
+}
+
+// GetHospitalPolyclinicsBatch personel servisinin liste sayfasındaki poliklinik adlarını çözmesi için kullanılır - JWT yerine servisler arası anahtar ister
+func (h *PolyclinicHandler) GetHospitalPolyclinicsBatch(c *fiber.Ctx) error {
+	var req dt.HospitalPolyclinicBatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	resp, err := h.polyclinicUsecase.GetHospitalPolyclinicsBatch(req.HospitalID, req.HospitalPolyclinicIDs)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// ListUnits godoc
+// @Summary     Hastane polikliniğinin yerel birimlerini listeler
+// @Description Lists local sub-units defined under a hospital polyclinic
+// @Tags        Polyclinic
+// @Produce     json
+// @Param       id path int true "Hospital Polyclinic ID"
+// @Success     200 {array} dto.HospitalPolyclinicUnitResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/polyclinic/hospital-polyclinics/{id}/units [get]
+func (h *PolyclinicHandler) ListUnits(c *fiber.Ctx) error {
+	hpID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.polyclinicUsecase.ListUnits(uint(hpID), user.HospitalID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// AddUnit godoc
+// @Summary     Hastane polikliniğine yerel birim ekler
+// @Description Adds a local sub-unit with display name and code under a hospital polyclinic
+// @Tags        Polyclinic
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Hospital Polyclinic ID"
+// @Param       unit body dto.HospitalPolyclinicUnitRequest true "Unit info"
+// @Success     201 {object} dto.HospitalPolyclinicUnitResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/polyclinic/hospital-polyclinics/{id}/units [post]
+func (h *PolyclinicHandler) AddUnit(c *fiber.Ctx) error {
+	hpID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
+	}
+
+	req := new(dto.HospitalPolyclinicUnitRequest)
+	if err := c.BodyParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.polyclinicUsecase.AddUnit(uint(hpID), user.HospitalID, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// UpdateUnit godoc
+// @Summary     Yerel birimi günceller
+// @Description Updates a local sub-unit of a hospital polyclinic
+// @Tags        Polyclinic
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Hospital Polyclinic ID"
+// @Param       unitId path int true "Unit ID"
+// @Param       unit body dto.HospitalPolyclinicUnitRequest true "Unit info"
+// @Success     200 {object} dto.HospitalPolyclinicUnitResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/polyclinic/hospital-polyclinics/{id}/units/{unitId} [put]
+func (h *PolyclinicHandler) UpdateUnit(c *fiber.Ctx) error {
+	hpID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
+	}
+	unitID, err := strconv.ParseUint(c.Params("unitId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid unit id"})
+	}
+
+	req := new(dto.HospitalPolyclinicUnitRequest)
+	if err := c.BodyParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.polyclinicUsecase.UpdateUnit(uint(hpID), uint(unitID), user.HospitalID, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// DeleteUnit godoc
+// @Summary     Yerel birimi siler
+// @Description Deletes a local sub-unit of a hospital polyclinic
+// @Tags        Polyclinic
+// @Produce     json
+// @Param       id path int true "Hospital Polyclinic ID"
+// @Param       unitId path int true "Unit ID"
+// @Success     200 {object} map[string]string
+// @Failure     400 {object} map[string]string
+// @Router      /api/polyclinic/hospital-polyclinics/{id}/units/{unitId} [delete]
+func (h *PolyclinicHandler) DeleteUnit(c *fiber.Ctx) error {
+	hpID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
+	}
+	unitID, err := strconv.ParseUint(c.Params("unitId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid unit id"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	if err := h.polyclinicUsecase.DeleteUnit(uint(hpID), uint(unitID), user.HospitalID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"message": "Unit deleted"})
+}
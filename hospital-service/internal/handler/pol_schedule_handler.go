@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"strconv"
+
+	"hospital-service/internal/dto"
+	"hospital-shared/jwt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// UpdateCapacity godoc
+// @Summary     Polikliniğin günlük kapasitesini günceller
+// @Description Updates the daily patient capacity of a hospital polyclinic
+// @Tags        Polyclinic
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Hospital Polyclinic ID"
+// @Param       capacity body dto.UpdateCapacityRequest true "Capacity info"
+// @Success     200 {object} map[string]string
+// @Failure     400 {object} map[string]string
+// @Router      /api/polyclinic/hospital-polyclinics/{id}/capacity [put]
+func (h *PolyclinicHandler) UpdateCapacity(c *fiber.Ctx) error {
+	hpID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
+	}
+
+	req := new(dto.UpdateCapacityRequest)
+	if err := c.BodyParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	if err := h.polyclinicUsecase.UpdateCapacity(uint(hpID), user.HospitalID, req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"message": "Capacity updated"})
+}
+
+// UpdateOpeningHours godoc
+// @Summary     Polikliniğin haftalık çalışma saatlerini günceller
+// @Description Replaces the weekly opening hours of a hospital polyclinic. Weekday 0 is Sunday.
+// @Tags        Polyclinic
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Hospital Polyclinic ID"
+// @Param       hours body dto.UpdateOpeningHoursRequest true "Opening hours"
+// @Success     200 {array} dto.OpeningHour
+// @Failure     400 {object} map[string]string
+// @Router      /api/polyclinic/hospital-polyclinics/{id}/opening-hours [put]
+func (h *PolyclinicHandler) UpdateOpeningHours(c *fiber.Ctx) error {
+	hpID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
+	}
+
+	req := new(dto.UpdateOpeningHoursRequest)
+	if err := c.BodyParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.polyclinicUsecase.UpdateOpeningHours(uint(hpID), user.HospitalID, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// AddHoliday godoc
+// @Summary     Polikliniğe tatil veya özel gün ekler
+// @Description Adds a closed day or a day with special hours to a hospital polyclinic
+// @Tags        Polyclinic
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Hospital Polyclinic ID"
+// @Param       holiday body dto.HolidayRequest true "Holiday info"
+// @Success     201 {object} dto.HolidayResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/polyclinic/hospital-polyclinics/{id}/holidays [post]
+func (h *PolyclinicHandler) AddHoliday(c *fiber.Ctx) error {
+	hpID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
+	}
+
+	req := new(dto.HolidayRequest)
+	if err := c.BodyParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.polyclinicUsecase.AddHoliday(uint(hpID), user.HospitalID, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// DeleteHoliday godoc
+// @Summary     Poliklinik tatilini siler
+// @Description Deletes a holiday or special day of a hospital polyclinic
+// @Tags        Polyclinic
+// @Produce     json
+// @Param       id path int true "Hospital Polyclinic ID"
+// @Param       holidayId path int true "Holiday ID"
+// @Success     200 {object} map[string]string
+// @Failure     400 {object} map[string]string
+// @Router      /api/polyclinic/hospital-polyclinics/{id}/holidays/{holidayId} [delete]
+func (h *PolyclinicHandler) DeleteHoliday(c *fiber.Ctx) error {
+	hpID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
+	}
+	holidayID, err := strconv.ParseUint(c.Params("holidayId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid holiday id"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	if err := h.polyclinicUsecase.DeleteHoliday(uint(hpID), uint(holidayID), user.HospitalID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"message": "Holiday deleted"})
+}
+
+// AddExamRoom godoc
+// @Summary     Polikliniğe muayene odası atar
+// @Description Assigns an exam room to a hospital polyclinic. Floor and number are unique per hospital.
+// @Tags        Polyclinic
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Hospital Polyclinic ID"
+// @Param       room body dto.ExamRoomRequest true "Room info"
+// @Success     201 {object} dto.ExamRoomResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/polyclinic/hospital-polyclinics/{id}/rooms [post]
+func (h *PolyclinicHandler) AddExamRoom(c *fiber.Ctx) error {
+	hpID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
+	}
+
+	req := new(dto.ExamRoomRequest)
+	if err := c.BodyParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.polyclinicUsecase.AddExamRoom(uint(hpID), user.HospitalID, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// UpdateExamRoom godoc
+// @Summary     Muayene odasını günceller
+// @Description Updates an exam room of a hospital polyclinic
+// @Tags        Polyclinic
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Hospital Polyclinic ID"
+// @Param       roomId path int true "Room ID"
+// @Param       room body dto.ExamRoomRequest true "Room info"
+// @Success     200 {object} dto.ExamRoomResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/polyclinic/hospital-polyclinics/{id}/rooms/{roomId} [put]
+func (h *PolyclinicHandler) UpdateExamRoom(c *fiber.Ctx) error {
+	hpID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
+	}
+	roomID, err := strconv.ParseUint(c.Params("roomId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid room id"})
+	}
+
+	req := new(dto.ExamRoomRequest)
+	if err := c.BodyParser(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.polyclinicUsecase.UpdateExamRoom(uint(hpID), uint(roomID), user.HospitalID, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// DeleteExamRoom godoc
+// @Summary     Muayene odasını siler
+// @Description Removes an exam room from a hospital polyclinic
+// @Tags        Polyclinic
+// @Produce     json
+// @Param       id path int true "Hospital Polyclinic ID"
+// @Param       roomId path int true "Room ID"
+// @Success     200 {object} map[string]string
+// @Failure     400 {object} map[string]string
+// @Router      /api/polyclinic/hospital-polyclinics/{id}/rooms/{roomId} [delete]
+func (h *PolyclinicHandler) DeleteExamRoom(c *fiber.Ctx) error {
+	hpID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
+	}
+	roomID, err := strconv.ParseUint(c.Params("roomId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid room id"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	if err := h.polyclinicUsecase.DeleteExamRoom(uint(hpID), uint(roomID), user.HospitalID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"message": "Exam room deleted"})
+}
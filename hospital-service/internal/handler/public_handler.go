@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"strconv"
+
+	"hospital-service/internal/dto"
+	"hospital-service/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PublicHandler struct {
+	publicUsecase usecase.PublicUsecase
+}
+
+func NewPublicHandler(publicUsecase usecase.PublicUsecase) *PublicHandler {
+	return &PublicHandler{
+		publicUsecase: publicUsecase,
+	}
+}
+
+// SearchHospitals godoc
+// @Summary     Hastane rehberinde arama yapar (kimlik doğrulama gerekmez)
+// @Description Lists active hospitals for patients and partner systems without internal fields
+// @Tags        Public
+// @Produce     json
+// @Param       page query int false "Page number"
+// @Param       size query int false "Page size (max 50)"
+// @Param       name query string false "Hospital name"
+// @Param       city_id query int false "City ID"
+// @Param       district_id query int false "District ID"
+// @Param       polyclinic_id query int false "Polyclinic ID"
+// @Success     200 {object} dto.PublicHospitalListResponse
+// @Failure     400 {object} map[string]string
+// @Failure     429 {object} map[string]string
+// @Router      /api/public/hospitals [get]
+func (h *PublicHandler) SearchHospitals(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	size, _ := strconv.Atoi(c.Query("size", "10"))
+
+	filter := dto.HospitalListFilter{
+		Name: c.Query("name", ""),
+	}
+
+	var err error
+	if filter.CityID, err = queryUint(c, "city_id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid city_id"})
+	}
+	if filter.DistrictID, err = queryUint(c, "district_id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid district_id"})
+	}
+	if filter.PolyclinicID, err = queryUint(c, "polyclinic_id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid polyclinic_id"})
+	}
+
+	resp, err := h.publicUsecase.SearchHospitals(filter, page, size)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
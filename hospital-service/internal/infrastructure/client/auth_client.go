@@ -0,0 +1,73 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	dt "hospital-shared/dto"
+	"hospital-shared/middleware"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type AuthClient interface {
+	ListAuthorityRefs(afterID uint, limit int) (*dt.AuthorityRefPage, error)
+	// ListHospitalContacts hastanenin verilen roldeki kullanıcılarını kayıt sırasına göre döner
+	ListHospitalContacts(hospitalID uint, role string) ([]dt.AuthorityContact, error)
+}
+
+type authClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func NewAuthClient(baseURL, internalToken string) AuthClient {
+	return &authClient{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout:   time.Second * 10,
+			Transport: middleware.InternalTransport(internalToken),
+		},
+	}
+}
+
+func (c *authClient) ListAuthorityRefs(afterID uint, limit int) (*dt.AuthorityRefPage, error) {
+	url := fmt.Sprintf("%s/api/auth/internal/authorities?after_id=%d&limit=%d", c.baseURL, afterID, limit)
+
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("authority list request failed")
+	}
+
+	var page dt.AuthorityRefPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+func (c *authClient) ListHospitalContacts(hospitalID uint, role string) ([]dt.AuthorityContact, error) {
+	u := fmt.Sprintf("%s/api/auth/internal/hospitals/%d/contacts?role=%s", c.baseURL, hospitalID, url.QueryEscape(role))
+
+	resp, err := c.httpClient.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("hospital contacts request failed")
+	}
+
+	var contacts []dt.AuthorityContact
+	if err := json.NewDecoder(resp.Body).Decode(&contacts); err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
@@ -1,17 +1,23 @@
 package client
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	dt "hospital-shared/dto"
+	"hospital-shared/middleware"
 	"net/http"
 	"time"
 )
 
 type PersonnelClient interface {
-	GetPersonnelCount(hospitalPolyclinicID uint) (int, error)
+	// GetPersonnelCount includeOnLeave false ise bugün izinde olanları saymaz
+	GetPersonnelCount(hospitalPolyclinicID uint, includeOnLeave bool) (int, error)
 	GetPersonnelGroups(hospitalPolyclinicID uint) ([]dt.PolyclinicPersonnelGroup, error)
+	GetPersonnelStatsBatch(hospitalPolyclinicIDs []uint) (map[uint]dt.PolyclinicPersonnelStats, error)
+	ReassignStaff(req dt.ReassignStaffRequest) ([]uint, error)
+	ListStaffRefs(afterID uint, limit int) (*dt.StaffRefPage, error)
 }
 
 type personnelClient struct {
@@ -19,17 +25,18 @@ type personnelClient struct {
 	httpClient *http.Client
 }
 
-func NewPersonnelClient(baseURL string) PersonnelClient {
+func NewPersonnelClient(baseURL, internalToken string) PersonnelClient {
 	return &personnelClient{
 		baseURL: baseURL,
 		httpClient: &http.Client{
-			Timeout: time.Second * 5,
+			Timeout:   time.Second * 5,
+			Transport: middleware.InternalTransport(internalToken),
 		},
 	}
 }
 
-func (c *personnelClient) GetPersonnelCount(hpID uint) (int, error) {
-	url := fmt.Sprintf("%s/api/personnel/%d", c.baseURL, hpID)
+func (c *personnelClient) GetPersonnelCount(hpID uint, includeOnLeave bool) (int, error) {
+	url := fmt.Sprintf("%s/api/personnel/%d?include_on_leave=%t", c.baseURL, hpID, includeOnLeave)
 
 	resp, err := c.httpClient.Get(url)
 	if err != nil {
@@ -71,3 +78,79 @@ func (c *personnelClient) GetPersonnelGroups(hpID uint) ([]dt.PolyclinicPersonne
 
 	return result, nil
 }
+
+func (c *personnelClient) GetPersonnelStatsBatch(hpIDs []uint) (map[uint]dt.PolyclinicPersonnelStats, error) {
+	result := make(map[uint]dt.PolyclinicPersonnelStats, len(hpIDs))
+	if len(hpIDs) == 0 {
+		return result, nil
+	}
+
+	body, err := json.Marshal(dt.PersonnelStatsBatchRequest{HospitalPolyclinicIDs: hpIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/api/personnel/stats/batch", c.baseURL)
+	resp, err := c.httpClient.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("personnel stats request failed")
+	}
+
+	var stats []dt.PolyclinicPersonnelStats
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return nil, err
+	}
+	for _, s := range stats {
+		result[s.HospitalPolyclinicID] = s
+	}
+	return result, nil
+}
+
+func (c *personnelClient) ReassignStaff(req dt.ReassignStaffRequest) ([]uint, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/api/personnel/staff/reassign", c.baseURL)
+	resp, err := c.httpClient.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("staff reassign request failed")
+	}
+
+	var result dt.ReassignStaffResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return result.StaffIDs, nil
+}
+
+func (c *personnelClient) ListStaffRefs(afterID uint, limit int) (*dt.StaffRefPage, error) {
+	url := fmt.Sprintf("%s/api/personnel/internal/staff?after_id=%d&limit=%d", c.baseURL, afterID, limit)
+
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("staff list request failed")
+	}
+
+	var page dt.StaffRefPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
@@ -4,6 +4,11 @@ import "gorm.io/gorm"
 
 type City struct {
 	gorm.Model
-	Name      string     `gorm:"unique;not null"`
+	Name string `gorm:"unique;not null"`
+	// Resmi il plaka kodu; eski kayıtlarda ilk içe aktarıma kadar boş kalır
+	PlateCode *int `gorm:"uniqueIndex"`
+	// İl merkezinin koordinatı; ilçe ve hastane koordinatı yoksa kullanılır
+	Latitude  *float64
+	Longitude *float64
 	Districts []District `gorm:"foreignKey:CityID"`
-} 
\ No newline at end of file
+}
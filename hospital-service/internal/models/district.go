@@ -4,6 +4,9 @@ import "gorm.io/gorm"
 
 type District struct {
 	gorm.Model
-	Name   string `gorm:"not null"`
-	CityID uint
+	Name   string `gorm:"not null;uniqueIndex:idx_district_city_name"`
+	CityID uint   `gorm:"uniqueIndex:idx_district_city_name"`
+	// İlçe merkez koordinatı; koordinatı girilmemiş hastaneler için yaklaşık konum
+	Latitude  *float64
+	Longitude *float64
 }
@@ -1,17 +1,39 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Hastane yaşam döngüsü: pending -> active <-> suspended, pending/active/suspended -> closed
+const (
+	HospitalStatusPending   = "pending"
+	HospitalStatusActive    = "active"
+	HospitalStatusSuspended = "suspended"
+	HospitalStatusClosed    = "closed"
+)
 
 type Hospital struct {
 	gorm.Model
-	Name        string `gorm:"not null"`
-	TaxNumber   string `gorm:"unique;not null"`
-	Email       string `gorm:"unique;not null"`
-	Phone       string `gorm:"unique;not null"`
-	Address     string `gorm:"not null"`
-	CityID      uint   `gorm:"not null"`
-	City        City
-	DistrictID  uint `gorm:"not null"`
-	District    District
-	Polyclinics []HospitalPolyclinic `gorm:"foreignKey:HospitalID"`
+	Name            string `gorm:"not null"`
+	TaxNumber       string `gorm:"unique;not null"`
+	Email           string `gorm:"unique;not null"`
+	Phone           string `gorm:"unique;not null"`
+	Address         string `gorm:"not null"`
+	CityID          uint   `gorm:"not null"`
+	City            City
+	DistrictID      uint `gorm:"not null"`
+	District        District
+	Latitude        *float64
+	Longitude       *float64
+	Status          string `gorm:"not null;default:'active';index"` // pending, active, suspended, closed
+	StatusReason    string
+	StatusChangedAt *time.Time
+	ApprovedAt      *time.Time
+	SuspendedAt     *time.Time
+	ClosedAt        *time.Time
+	// Tutarlılık kontrolü yetkilisi olmayan hastaneleri silinmek üzere işaretler; silme kararı platform yöneticisindedir
+	DeletionFlaggedAt *time.Time           `gorm:"index"`
+	Polyclinics       []HospitalPolyclinic `gorm:"foreignKey:HospitalID"`
 }
@@ -1,9 +1,47 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type HospitalPolyclinic struct {
 	gorm.Model
-	HospitalID   uint    `gorm:"not null"`
-	PolyclinicID uint    `gorm:"not null"`
+	HospitalID   uint `gorm:"not null"`
+	PolyclinicID uint `gorm:"not null"`
+	// Günlük hasta kapasitesi; 0 tanımlanmamış demektir
+	DailyCapacity int `gorm:"not null;default:0"`
+	// Kaldırılırken personelin taşındığı poliklinik; kaldırmadan sonra atananlar da oraya taşınır
+	RemovalReassignTo *uint
+}
+
+// PolyclinicOpeningHour haftalık çalışma aralığıdır; bir gün için birden fazla aralık olabilir (öğle arası gibi)
+type PolyclinicOpeningHour struct {
+	gorm.Model
+	HospitalPolyclinicID uint         `gorm:"not null;index"`
+	Weekday              time.Weekday `gorm:"not null"` // 0 = Pazar ... 6 = Cumartesi
+	OpensAt              string       `gorm:"not null"` // HH:MM
+	ClosesAt             string       `gorm:"not null"` // HH:MM
+}
+
+// PolyclinicHoliday belirli bir gün için haftalık düzenin yerine geçer
+type PolyclinicHoliday struct {
+	gorm.Model
+	HospitalPolyclinicID uint      `gorm:"not null;uniqueIndex:idx_polyclinic_holiday_date"`
+	Date                 time.Time `gorm:"type:date;not null;uniqueIndex:idx_polyclinic_holiday_date"`
+	Closed               bool      `gorm:"not null;default:true"`
+	OpensAt              string
+	ClosesAt             string
+	Description          string
+}
+
+// ExamRoom muayene odası; kat ve numara hastane içinde tekildir
+type ExamRoom struct {
+	gorm.Model
+	HospitalID           uint   `gorm:"not null;index"`
+	HospitalPolyclinicID uint   `gorm:"not null;index"`
+	Floor                string `gorm:"not null"`
+	Number               string `gorm:"not null"`
+	Name                 string
 }
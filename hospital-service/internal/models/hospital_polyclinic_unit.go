@@ -0,0 +1,12 @@
+package models
+
+import "gorm.io/gorm"
+
+// HospitalPolyclinicUnit hastanenin bir polikliniği altında tanımladığı yerel birimdir
+// (ör. Çocuk Psikiyatrisi polikliniği altında "Ergen Psikiyatrisi Birimi")
+type HospitalPolyclinicUnit struct {
+	gorm.Model
+	HospitalPolyclinicID uint   `gorm:"not null;index"`
+	Name                 string `gorm:"not null"`
+	Code                 string
+}
@@ -4,6 +4,8 @@ import "gorm.io/gorm"
 
 type Polyclinic struct {
 	gorm.Model
-	Name                string               `gorm:"unique;not null"`
+	Name string `gorm:"unique;not null"`
+	// Resmi branş kodu; seed ile gelen kayıtlarda platform yöneticisi girene kadar boş kalır
+	Code                *string              `gorm:"uniqueIndex"`
 	HospitalPolyclinics []HospitalPolyclinic `gorm:"foreignKey:PolyclinicID"`
 }
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// PolyclinicStaffStat personnel servisindeki personel sayılarının yerel kopyasıdır.
+// PolyclinicStaffCounted olaylarındaki mutlak sayılarla güncellenir; CountedAt uygulanan son
+// sayımın zamanıdır ve daha eski sayımların üzerine yazmasını engeller.
+type PolyclinicStaffStat struct {
+	HospitalPolyclinicID uint      `gorm:"primaryKey;autoIncrement:false"`
+	Total                int       `gorm:"not null;default:0;index"`
+	CountedAt            time.Time `gorm:"not null;default:'epoch'"`
+	UpdatedAt            time.Time
+}
+
+// PolyclinicStaffGroupStat meslek grubu bazında personel sayısıdır
+type PolyclinicStaffGroupStat struct {
+	HospitalPolyclinicID uint   `gorm:"primaryKey;autoIncrement:false"`
+	JobGroupID           uint   `gorm:"primaryKey;autoIncrement:false"`
+	JobGroupName         string `gorm:"not null"`
+	Count                int    `gorm:"not null;default:0"`
+}
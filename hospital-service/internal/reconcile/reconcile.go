@@ -0,0 +1,376 @@
+// Package reconcile auth, hospital ve personnel servisleri arasındaki kopuk referansları bulur
+// ve seçilen kurallara göre onarır.
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"time"
+
+	"hospital-service/internal/infrastructure/client"
+	"hospital-service/internal/models"
+	"hospital-service/internal/repository"
+	dt "hospital-shared/dto"
+)
+
+type IssueKind string
+
+const (
+	// Personel silinmiş ya da hiç olmayan bir hastane polikliniğine bağlı
+	IssueStaffDeletedPolyclinic IssueKind = "staff_deleted_polyclinic"
+	// Personel başka bir hastanenin polikliniğine bağlı
+	IssueStaffForeignPolyclinic   IssueKind = "staff_foreign_polyclinic"
+	IssueStaffMissingHospital     IssueKind = "staff_missing_hospital"
+	IssueAuthorityMissingHospital IssueKind = "authority_missing_hospital"
+	// Kaydı yarıda kalmış, hiç yetkilisi olmayan hastane
+	IssueOrphanHospital IssueKind = "orphan_hospital"
+)
+
+type Issue struct {
+	Kind        IssueKind `json:"kind"`
+	RefID       uint      `json:"ref_id"`
+	Detail      string    `json:"detail"`
+	Repaired    bool      `json:"repaired"`
+	RepairError string    `json:"repair_error,omitempty"`
+}
+
+type HospitalReport struct {
+	HospitalID   uint    `json:"hospital_id"`
+	HospitalName string  `json:"hospital_name,omitempty"`
+	Exists       bool    `json:"exists"`
+	Issues       []Issue `json:"issues"`
+}
+
+type Report struct {
+	StartedAt  time.Time         `json:"started_at"`
+	FinishedAt time.Time         `json:"finished_at"`
+	Counts     map[IssueKind]int `json:"counts"`
+	Hospitals  []*HospitalReport `json:"hospitals"`
+}
+
+// Options hangi onarım kurallarının uygulanacağını belirler; hepsi kapalıysa sadece rapor üretilir
+type Options struct {
+	UnassignStaff bool
+	FlagOrphans   bool
+	// Yeni kayıtlar yetkili oluşturulurken yanlışlıkla yetim sayılmasın
+	OrphanGrace time.Duration
+	PageSize    int
+}
+
+type Reconciler struct {
+	hospitals   repository.HospitalRepository
+	polyclinics repository.PolyclinicRepository
+	auth        client.AuthClient
+	personnel   client.PersonnelClient
+	opts        Options
+}
+
+func New(h repository.HospitalRepository, p repository.PolyclinicRepository, ac client.AuthClient, pc client.PersonnelClient, opts Options) *Reconciler {
+	if opts.PageSize < 1 || opts.PageSize > dt.MaxRefPageSize {
+		opts.PageSize = dt.MaxRefPageSize
+	}
+	return &Reconciler{hospitals: h, polyclinics: p, auth: ac, personnel: pc, opts: opts}
+}
+
+type run struct {
+	hospitals   map[uint]models.Hospital
+	polyclinics map[uint]models.HospitalPolyclinic
+	authorities map[uint]int
+	reports     map[uint]*HospitalReport
+}
+
+func (r *Reconciler) Run(ctx context.Context) (*Report, error) {
+	started := time.Now()
+	st := &run{
+		hospitals:   make(map[uint]models.Hospital),
+		polyclinics: make(map[uint]models.HospitalPolyclinic),
+		authorities: make(map[uint]int),
+		reports:     make(map[uint]*HospitalReport),
+	}
+
+	if err := r.loadHospitals(ctx, st); err != nil {
+		return nil, fmt.Errorf("load hospitals: %w", err)
+	}
+	if err := r.loadPolyclinics(ctx, st); err != nil {
+		return nil, fmt.Errorf("load hospital polyclinics: %w", err)
+	}
+	if err := r.checkAuthorities(ctx, st); err != nil {
+		return nil, fmt.Errorf("check authorities: %w", err)
+	}
+	if err := r.checkStaff(ctx, st); err != nil {
+		return nil, fmt.Errorf("check staff: %w", err)
+	}
+	r.checkOrphans(st, started)
+
+	report := &Report{
+		StartedAt:  started,
+		FinishedAt: time.Now(),
+		Counts:     make(map[IssueKind]int),
+		Hospitals:  make([]*HospitalReport, 0, len(st.reports)),
+	}
+	for _, hr := range st.reports {
+		for _, issue := range hr.Issues {
+			report.Counts[issue.Kind]++
+		}
+		report.Hospitals = append(report.Hospitals, hr)
+	}
+	sort.Slice(report.Hospitals, func(i, j int) bool {
+		return report.Hospitals[i].HospitalID < report.Hospitals[j].HospitalID
+	})
+	return report, nil
+}
+
+func (r *Reconciler) loadHospitals(ctx context.Context, st *run) error {
+	var afterID uint
+	for ctx.Err() == nil {
+		hospitals, err := r.hospitals.ListHospitalsAfter(afterID, r.opts.PageSize)
+		if err != nil {
+			return err
+		}
+		for _, h := range hospitals {
+			st.hospitals[h.ID] = h
+		}
+		if len(hospitals) < r.opts.PageSize {
+			return nil
+		}
+		afterID = hospitals[len(hospitals)-1].ID
+	}
+	return ctx.Err()
+}
+
+func (r *Reconciler) loadPolyclinics(ctx context.Context, st *run) error {
+	var afterID uint
+	for ctx.Err() == nil {
+		hps, err := r.polyclinics.ListHospitalPolyclinicsAfter(afterID, r.opts.PageSize)
+		if err != nil {
+			return err
+		}
+		for _, hp := range hps {
+			st.polyclinics[hp.ID] = hp
+		}
+		if len(hps) < r.opts.PageSize {
+			return nil
+		}
+		afterID = hps[len(hps)-1].ID
+	}
+	return ctx.Err()
+}
+
+// polyclinic hastane polikliniğini başta yüklenen listeden döner. Listede yoksa çalışma sırasında
+// eklenmiş olabileceği için veritabanından tekrar bakılır.
+func (r *Reconciler) polyclinic(st *run, id uint) (models.HospitalPolyclinic, bool, error) {
+	if hp, ok := st.polyclinics[id]; ok {
+		return hp, true, nil
+	}
+	hps, err := r.polyclinics.ListHospitalPolyclinicsAfter(id-1, 1)
+	if err != nil {
+		return models.HospitalPolyclinic{}, false, err
+	}
+	if len(hps) == 0 || hps[0].ID != id {
+		return models.HospitalPolyclinic{}, false, nil
+	}
+	st.polyclinics[id] = hps[0]
+	return hps[0], true, nil
+}
+
+// hospital hastaneyi başta yüklenen listeden döner. Listede yoksa çalışma sırasında kaydedilmiş
+// olabileceği için veritabanından tekrar bakılır.
+func (r *Reconciler) hospital(st *run, id uint) (models.Hospital, bool, error) {
+	if h, ok := st.hospitals[id]; ok {
+		return h, true, nil
+	}
+	hospitals, err := r.hospitals.ListHospitalsAfter(id-1, 1)
+	if err != nil {
+		return models.Hospital{}, false, err
+	}
+	if len(hospitals) == 0 || hospitals[0].ID != id {
+		return models.Hospital{}, false, nil
+	}
+	st.hospitals[id] = hospitals[0]
+	return hospitals[0], true, nil
+}
+
+func (r *Reconciler) checkAuthorities(ctx context.Context, st *run) error {
+	var afterID uint
+	for ctx.Err() == nil {
+		page, err := r.auth.ListAuthorityRefs(afterID, r.opts.PageSize)
+		if err != nil {
+			return err
+		}
+		for _, a := range page.Items {
+			_, ok, err := r.hospital(st, a.HospitalID)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				st.add(a.HospitalID, Issue{
+					Kind:   IssueAuthorityMissingHospital,
+					RefID:  a.ID,
+					Detail: fmt.Sprintf("authority %d (%s) references missing hospital %d", a.ID, a.Role, a.HospitalID),
+				})
+				continue
+			}
+			st.authorities[a.HospitalID]++
+		}
+		if page.NextAfterID == 0 {
+			return nil
+		}
+		afterID = page.NextAfterID
+	}
+	return ctx.Err()
+}
+
+// unassignKey aynı hastane polikliniğindeki personel tek istekle onarılır
+type unassignKey struct {
+	hospitalID           uint
+	hospitalPolyclinicID uint
+}
+
+func (r *Reconciler) checkStaff(ctx context.Context, st *run) error {
+	pending := make(map[unassignKey][]uint)
+
+	var afterID uint
+	for ctx.Err() == nil {
+		page, err := r.personnel.ListStaffRefs(afterID, r.opts.PageSize)
+		if err != nil {
+			return err
+		}
+		for _, s := range page.Items {
+			_, ok, err := r.hospital(st, s.HospitalID)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				st.add(s.HospitalID, Issue{
+					Kind:   IssueStaffMissingHospital,
+					RefID:  s.ID,
+					Detail: fmt.Sprintf("staff %d references missing hospital %d", s.ID, s.HospitalID),
+				})
+			}
+			if s.HospitalPolyclinicID == nil {
+				continue
+			}
+
+			hpID := *s.HospitalPolyclinicID
+			hp, ok, err := r.polyclinic(st, hpID)
+			if err != nil {
+				return err
+			}
+			switch {
+			case !ok:
+				// Kaydı hiç bulunamayan poliklinik için neyin doğru olduğu bilinmez; sadece raporlanır
+				st.add(s.HospitalID, Issue{
+					Kind:   IssueStaffDeletedPolyclinic,
+					RefID:  s.ID,
+					Detail: fmt.Sprintf("staff %d references unknown hospital polyclinic %d", s.ID, hpID),
+				})
+				continue
+			case hp.DeletedAt.Valid:
+				st.add(s.HospitalID, Issue{
+					Kind:   IssueStaffDeletedPolyclinic,
+					RefID:  s.ID,
+					Detail: fmt.Sprintf("staff %d references removed hospital polyclinic %d", s.ID, hpID),
+				})
+			case hp.HospitalID != s.HospitalID:
+				st.add(s.HospitalID, Issue{
+					Kind:   IssueStaffForeignPolyclinic,
+					RefID:  s.ID,
+					Detail: fmt.Sprintf("staff %d references hospital polyclinic %d of hospital %d", s.ID, hpID, hp.HospitalID),
+				})
+			default:
+				continue
+			}
+			key := unassignKey{hospitalID: s.HospitalID, hospitalPolyclinicID: hpID}
+			pending[key] = append(pending[key], s.ID)
+		}
+		if page.NextAfterID == 0 {
+			break
+		}
+		afterID = page.NextAfterID
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if r.opts.UnassignStaff {
+		for key, staffIDs := range pending {
+			r.unassign(st, key, staffIDs)
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) unassign(st *run, key unassignKey, staffIDs []uint) {
+	hpID := key.hospitalPolyclinicID
+	moved, err := r.personnel.ReassignStaff(dt.ReassignStaffRequest{
+		HospitalID:               key.hospitalID,
+		FromHospitalPolyclinicID: &hpID,
+		StaffIDs:                 staffIDs,
+	})
+
+	done := make(map[uint]bool, len(moved))
+	for _, id := range moved {
+		done[id] = true
+	}
+	hr := st.reports[key.hospitalID]
+	for i := range hr.Issues {
+		issue := &hr.Issues[i]
+		if issue.Kind != IssueStaffDeletedPolyclinic && issue.Kind != IssueStaffForeignPolyclinic {
+			continue
+		}
+		switch {
+		case err != nil:
+			if slices.Contains(staffIDs, issue.RefID) {
+				issue.RepairError = err.Error()
+			}
+		case done[issue.RefID]:
+			issue.Repaired = true
+		}
+	}
+}
+
+func (r *Reconciler) checkOrphans(st *run, now time.Time) {
+	for _, h := range st.hospitals {
+		if st.authorities[h.ID] > 0 {
+			// Sonradan yetkili eklenmişse eski işaret kaldırılır
+			if h.DeletionFlaggedAt != nil && r.opts.FlagOrphans {
+				if err := r.hospitals.SetDeletionFlag(h.ID, nil); err != nil {
+					log.Printf("hospital %d: failed to clear deletion flag: %v", h.ID, err)
+				}
+			}
+			continue
+		}
+		if h.Status == models.HospitalStatusClosed || now.Sub(h.CreatedAt) < r.opts.OrphanGrace {
+			continue
+		}
+
+		issue := Issue{
+			Kind:   IssueOrphanHospital,
+			RefID:  h.ID,
+			Detail: fmt.Sprintf("hospital %d has no authorities", h.ID),
+		}
+		if r.opts.FlagOrphans {
+			if h.DeletionFlaggedAt != nil {
+				issue.Repaired = true
+			} else if err := r.hospitals.SetDeletionFlag(h.ID, &now); err != nil {
+				issue.RepairError = err.Error()
+			} else {
+				issue.Repaired = true
+			}
+		}
+		st.add(h.ID, issue)
+	}
+}
+
+func (st *run) add(hospitalID uint, issue Issue) {
+	hr, ok := st.reports[hospitalID]
+	if !ok {
+		h, exists := st.hospitals[hospitalID]
+		hr = &HospitalReport{HospitalID: hospitalID, HospitalName: h.Name, Exists: exists}
+		st.reports[hospitalID] = hr
+	}
+	hr.Issues = append(hr.Issues, issue)
+}
@@ -2,12 +2,19 @@ package repository
 
 import (
 	"errors"
+	"strings"
+	"time"
 
+	"hospital-service/internal/dto"
 	"hospital-service/internal/models"
+	"hospital-shared/events"
 
 	"gorm.io/gorm"
 )
 
+// EventSource outbox olaylarında servis adı olarak kullanılır
+const EventSource = "hospital"
+
 type HospitalRepository interface {
 	IsHospitalExists(taxNumber, email, phone string) (bool, error)
 	CreateHospital(h *models.Hospital) error
@@ -16,6 +23,23 @@ type HospitalRepository interface {
 	GetCityByID(id uint) (*models.City, error)
 	GetDistrictByID(id uint) (*models.District, error)
 	Update(hospital *models.Hospital) error
+
+	ListHospitals(filter dto.HospitalListFilter, page, size int) ([]models.Hospital, error)
+	CountHospitals(filter dto.HospitalListFilter) (int64, error)
+
+	FindNearby(filter dto.NearbyHospitalFilter, limit int) ([]NearbyHospital, error)
+
+	ListHospitalsAfter(afterID uint, limit int) ([]models.Hospital, error)
+	SetDeletionFlag(id uint, flaggedAt *time.Time) error
+}
+
+// NearbyHospital mesafesi hesaplanmış hastane kaydıdır
+type NearbyHospital struct {
+	Hospital    models.Hospital
+	Latitude    float64
+	Longitude   float64
+	DistanceKm  float64
+	Approximate bool
 }
 
 type hospitalRepository struct {
@@ -35,13 +59,28 @@ func (r *hospitalRepository) IsHospitalExists(taxNumber, email, phone string) (b
 }
 
 func (r *hospitalRepository) CreateHospital(h *models.Hospital) error {
-	return r.db.Create(h).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(h).Error; err != nil {
+			return err
+		}
+		return events.Enqueue(tx, EventSource, events.HospitalCreated, events.HospitalCreatedPayload{
+			HospitalID: h.ID,
+			Name:       h.Name,
+		})
+	})
 }
 
+// ErrHospitalNotFound hastane yoksa ya da silinmişse döner
+var ErrHospitalNotFound = errors.New("hospital not found")
+
 func (r *hospitalRepository) GetByID(id uint) (*models.Hospital, error) {
 	var hospital models.Hospital
-	if err := r.db.First(&hospital, id).Error; err != nil {
-		return nil, errors.New("hospital not found")
+	err := r.db.First(&hospital, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrHospitalNotFound
+	}
+	if err != nil {
+		return nil, err
 	}
 	return &hospital, nil
 }
@@ -73,3 +112,162 @@ func (r *hospitalRepository) GetDistrictByID(id uint) (*models.District, error)
 func (r *hospitalRepository) Update(h *models.Hospital) error {
 	return r.db.Save(h).Error
 }
+
+func (r *hospitalRepository) ListHospitals(filter dto.HospitalListFilter, page, size int) ([]models.Hospital, error) {
+	var hospitals []models.Hospital
+	err := r.filtered(filter).
+		Preload("City").Preload("District").
+		Order("created_at DESC").
+		Offset((page - 1) * size).Limit(size).
+		Find(&hospitals).Error
+	return hospitals, err
+}
+
+func (r *hospitalRepository) CountHospitals(filter dto.HospitalListFilter) (int64, error) {
+	var total int64
+	err := r.filtered(filter).Count(&total).Error
+	return total, err
+}
+
+// Listeleme ve sayım aynı filtreyi kullanır
+func (r *hospitalRepository) filtered(filter dto.HospitalListFilter) *gorm.DB {
+	query := r.db.Model(&models.Hospital{})
+
+	if filter.Name != "" {
+		query = query.Where("hospitals.name ILIKE ?", "%"+escapeLike(filter.Name)+"%")
+	}
+	if filter.TaxNumber != "" {
+		query = query.Where("tax_number = ?", filter.TaxNumber)
+	}
+	if filter.CityID != nil {
+		query = query.Where("city_id = ?", *filter.CityID)
+	}
+	if filter.DistrictID != nil {
+		query = query.Where("district_id = ?", *filter.DistrictID)
+	}
+	if filter.PolyclinicID != nil {
+		query = query.Where("EXISTS (SELECT 1 FROM hospital_polyclinics hp WHERE hp.hospital_id = hospitals.id AND hp.polyclinic_id = ? AND hp.deleted_at IS NULL)", *filter.PolyclinicID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.RegisteredFrom != nil {
+		query = query.Where("created_at >= ?", *filter.RegisteredFrom)
+	}
+	if filter.RegisteredTo != nil {
+		query = query.Where("created_at < ?", *filter.RegisteredTo)
+	}
+	if filter.DeletionFlagged {
+		query = query.Where("deletion_flagged_at IS NOT NULL")
+	} else if filter.ExcludeDeletionFlagged {
+		query = query.Where("deletion_flagged_at IS NULL")
+	}
+	return query
+}
+
+// escapeLike kullanıcı girdisindeki % ve _ karakterlerinin joker olarak yorumlanmasını engeller
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// Yaklaşık 1 derece enlem = 111 km; sınır kutusu haversine'den önce eleme yapar
+const kmPerDegree = 111.0
+
+const nearbyHospitalsQuery = `
+SELECT id, lat, lng, approximate, distance_km FROM (
+	SELECT geo.*, 6371 * acos(LEAST(1.0, GREATEST(-1.0,
+		cos(radians(@lat)) * cos(radians(geo.lat)) * cos(radians(geo.lng) - radians(@lng)) +
+		sin(radians(@lat)) * sin(radians(geo.lat))))) AS distance_km
+	FROM (
+		SELECT h.id,
+			COALESCE(h.latitude, d.latitude, c.latitude) AS lat,
+			COALESCE(h.longitude, d.longitude, c.longitude) AS lng,
+			h.latitude IS NULL AS approximate
+		FROM hospitals h
+		JOIN districts d ON d.id = h.district_id
+		JOIN cities c ON c.id = h.city_id
+		WHERE h.deleted_at IS NULL AND h.status = @status
+			AND (@polyclinic_id = 0 OR EXISTS (
+				SELECT 1 FROM hospital_polyclinics hp
+				WHERE hp.hospital_id = h.id AND hp.polyclinic_id = @polyclinic_id AND hp.deleted_at IS NULL))
+	) geo
+	WHERE geo.lat BETWEEN @min_lat AND @max_lat
+) ranked
+WHERE distance_km <= @radius
+ORDER BY distance_km
+LIMIT @limit`
+
+// FindNearby yalnızca aktif hastaneleri mesafeye göre sıralar
+func (r *hospitalRepository) FindNearby(filter dto.NearbyHospitalFilter, limit int) ([]NearbyHospital, error) {
+	var polyclinicID uint
+	if filter.PolyclinicID != nil {
+		polyclinicID = *filter.PolyclinicID
+	}
+	latDelta := filter.RadiusKm / kmPerDegree
+
+	var rows []struct {
+		ID          uint
+		Lat         float64
+		Lng         float64
+		Approximate bool
+		DistanceKm  float64
+	}
+	err := r.db.Raw(nearbyHospitalsQuery, map[string]interface{}{
+		"lat":           filter.Latitude,
+		"lng":           filter.Longitude,
+		"status":        models.HospitalStatusActive,
+		"polyclinic_id": polyclinicID,
+		"min_lat":       filter.Latitude - latDelta,
+		"max_lat":       filter.Latitude + latDelta,
+		"radius":        filter.RadiusKm,
+		"limit":         limit,
+	}).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return []NearbyHospital{}, nil
+	}
+
+	ids := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	var hospitals []models.Hospital
+	if err := r.db.Preload("City").Preload("District").Where("id IN ?", ids).Find(&hospitals).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.Hospital, len(hospitals))
+	for _, h := range hospitals {
+		byID[h.ID] = h
+	}
+
+	result := make([]NearbyHospital, 0, len(rows))
+	for _, row := range rows {
+		h, ok := byID[row.ID]
+		if !ok {
+			continue
+		}
+		result = append(result, NearbyHospital{
+			Hospital:    h,
+			Latitude:    row.Lat,
+			Longitude:   row.Lng,
+			DistanceKm:  row.DistanceKm,
+			Approximate: row.Approximate,
+		})
+	}
+	return result, nil
+}
+
+// ListHospitalsAfter tutarlılık kontrolü için hastaneleri ID sırasıyla sayfalar
+func (r *hospitalRepository) ListHospitalsAfter(afterID uint, limit int) ([]models.Hospital, error) {
+	var hospitals []models.Hospital
+	err := r.db.Where("id > ?", afterID).Order("id").Limit(limit).Find(&hospitals).Error
+	return hospitals, err
+}
+
+func (r *hospitalRepository) SetDeletionFlag(id uint, flaggedAt *time.Time) error {
+	return r.db.Model(&models.Hospital{}).Where("id = ?", id).Update("deletion_flagged_at", flaggedAt).Error
+}
@@ -23,7 +23,7 @@ func NewLocationRepository(db *gorm.DB) LocationRepository {
 
 func (r *locationRepository) GetAllCities() ([]models.City, error) {
 	var cities []models.City
-	if err := r.db.Find(&cities).Error; err != nil {
+	if err := r.db.Order("plate_code, name").Find(&cities).Error; err != nil {
 		return nil, err
 	}
 	return cities, nil
@@ -31,7 +31,7 @@ func (r *locationRepository) GetAllCities() ([]models.City, error) {
 
 func (r *locationRepository) GetDistrictsByCity(cityID uint) ([]models.District, error) {
 	var districts []models.District
-	if err := r.db.Where("city_id = ?", cityID).Find(&districts).Error; err != nil {
+	if err := r.db.Where("city_id = ?", cityID).Order("name").Find(&districts).Error; err != nil {
 		return nil, err
 	}
 	return districts, nil
@@ -2,9 +2,12 @@ package repository
 
 import (
 	"errors"
+	"time"
 
-	"hospital-service/internal/models"
 	"gorm.io/gorm"
+	"hospital-service/internal/dto"
+	"hospital-service/internal/models"
+	"hospital-shared/events"
 )
 
 type PolyclinicRepository interface {
@@ -12,10 +15,51 @@ type PolyclinicRepository interface {
 	IsPolyclinicAlreadyAdded(hospitalID, PolyclinicID uint) (bool, error)
 	GetPolyclinicByID(PolyclinicID uint) (*models.Polyclinic, error)
 	CreateHospitalPolyclinic(hp *models.HospitalPolyclinic) error
-	CountByHospitalID(hospitalID uint) (int64, error)
-	GetPaginatedByHospitalID(hospitalID uint, page, size int) ([]models.HospitalPolyclinic, error)
+	CountByHospitalID(hospitalID uint, filter dto.HospitalPolyclinicListFilter) (int64, error)
+	GetPaginatedByHospitalID(hospitalID uint, filter dto.HospitalPolyclinicListFilter, page, size int) ([]HospitalPolyclinicRow, error)
 	GetHospitalPolyclinicByID(id uint) (*models.HospitalPolyclinic, error)
-	Delete(hp *models.HospitalPolyclinic) error
+	Delete(hp *models.HospitalPolyclinic, reassignTo *uint) error
+	GetPolyclinicNamesByHospitalIDs(hospitalIDs []uint) (map[uint][]string, error)
+	ListHospitalPolyclinicRefs(hospitalID uint) ([]HospitalPolyclinicRef, error)
+	GetHospitalPolyclinicRefsByIDs(hospitalID uint, ids []uint) ([]HospitalPolyclinicRef, error)
+
+	CreatePolyclinic(p *models.Polyclinic) error
+	UpdatePolyclinic(p *models.Polyclinic) error
+	DeletePolyclinic(p *models.Polyclinic) error
+	IsPolyclinicNameOrCodeTaken(excludeID uint, name string, code *string) (bool, error)
+	CountHospitalPolyclinicsByPolyclinicID(polyclinicID uint) (int64, error)
+
+	CreateUnit(unit *models.HospitalPolyclinicUnit) error
+	GetUnitByID(id uint) (*models.HospitalPolyclinicUnit, error)
+	UpdateUnit(unit *models.HospitalPolyclinicUnit) error
+	DeleteUnit(unit *models.HospitalPolyclinicUnit) error
+	IsUnitCodeTaken(hospitalPolyclinicID, excludeID uint, code string) (bool, error)
+	GetUnitsByHospitalPolyclinicIDs(ids []uint) (map[uint][]models.HospitalPolyclinicUnit, error)
+
+	ListHospitalPolyclinicsAfter(afterID uint, limit int) ([]models.HospitalPolyclinic, error)
+
+	UpdateHospitalPolyclinic(hp *models.HospitalPolyclinic) error
+	ReplaceOpeningHours(hospitalPolyclinicID uint, hours []models.PolyclinicOpeningHour) error
+	GetOpeningHoursByHospitalPolyclinicIDs(ids []uint) (map[uint][]models.PolyclinicOpeningHour, error)
+	CreateHoliday(h *models.PolyclinicHoliday) error
+	GetHolidayByID(id uint) (*models.PolyclinicHoliday, error)
+	DeleteHoliday(h *models.PolyclinicHoliday) error
+	IsHolidayDateTaken(hospitalPolyclinicID uint, date time.Time) (bool, error)
+	GetHolidaysByHospitalPolyclinicIDs(ids []uint, from time.Time) (map[uint][]models.PolyclinicHoliday, error)
+	CreateExamRoom(room *models.ExamRoom) error
+	GetExamRoomByID(id uint) (*models.ExamRoom, error)
+	UpdateExamRoom(room *models.ExamRoom) error
+	DeleteExamRoom(room *models.ExamRoom) error
+	IsExamRoomTaken(hospitalID, excludeID uint, floor, number string) (bool, error)
+	GetExamRoomsByHospitalPolyclinicIDs(ids []uint) (map[uint][]models.ExamRoom, error)
+}
+
+// HospitalPolyclinicRow poliklinik adı ve koduyla birlikte okunan hastane polikliniğidir
+type HospitalPolyclinicRow struct {
+	models.HospitalPolyclinic
+	PolyclinicName string
+	PolyclinicCode *string
+	TotalPersonnel int
 }
 
 type polyclinicRepository struct {
@@ -28,7 +72,7 @@ func NewPolyclinicRepository(db *gorm.DB) PolyclinicRepository {
 
 func (r *polyclinicRepository) GetAllPolyclinic() ([]models.Polyclinic, error) {
 	var polys []models.Polyclinic
-	if err := r.db.Find(&polys).Error; err != nil {
+	if err := r.db.Order("name").Find(&polys).Error; err != nil {
 		return nil, err
 	}
 	return polys, nil
@@ -55,20 +99,56 @@ func (r *polyclinicRepository) CreateHospitalPolyclinic(hp *models.HospitalPolyc
 	return r.db.Create(hp).Error
 }
 
-func (r *polyclinicRepository) CountByHospitalID(hospitalID uint) (int64, error) {
+// Liste ve sayım aynı filtreyi kullanır; personel sayıları yerel projeksiyondan gelir
+func (r *polyclinicRepository) hospitalPolyclinicQuery(hospitalID uint, filter dto.HospitalPolyclinicListFilter) *gorm.DB {
+	query := r.db.Model(&models.HospitalPolyclinic{}).
+		Joins("JOIN polyclinics ON polyclinics.id = hospital_polyclinics.polyclinic_id").
+		Joins("LEFT JOIN polyclinic_staff_stats ON polyclinic_staff_stats.hospital_polyclinic_id = hospital_polyclinics.id").
+		Where("hospital_polyclinics.hospital_id = ?", hospitalID)
+
+	if filter.MinPersonnel != nil {
+		query = query.Where("COALESCE(polyclinic_staff_stats.total, 0) >= ?", *filter.MinPersonnel)
+	}
+	if filter.MaxPersonnel != nil {
+		query = query.Where("COALESCE(polyclinic_staff_stats.total, 0) <= ?", *filter.MaxPersonnel)
+	}
+	if filter.MissingJobGroupID != nil {
+		query = query.Where(`NOT EXISTS (
+			SELECT 1 FROM polyclinic_staff_group_stats
+			WHERE polyclinic_staff_group_stats.hospital_polyclinic_id = hospital_polyclinics.id
+			AND polyclinic_staff_group_stats.job_group_id = ?
+			AND polyclinic_staff_group_stats.count > 0)`, *filter.MissingJobGroupID)
+	}
+	return query
+}
+
+func (r *polyclinicRepository) CountByHospitalID(hospitalID uint, filter dto.HospitalPolyclinicListFilter) (int64, error) {
 	var total int64
-	err := r.db.Model(&models.HospitalPolyclinic{}).
-		Where("hospital_id = ?", hospitalID).Count(&total).Error
+	err := r.hospitalPolyclinicQuery(hospitalID, filter).Count(&total).Error
 	return total, err
 }
 
 // Belirli sayfa ve boyutta poliklinik kaydı çekiliyor
-func (r *polyclinicRepository) GetPaginatedByHospitalID(hospitalID uint, page, size int) ([]models.HospitalPolyclinic, error) {
-	var hps []models.HospitalPolyclinic
-	err := r.db.Where("hospital_id = ?", hospitalID).
+func (r *polyclinicRepository) GetPaginatedByHospitalID(hospitalID uint, filter dto.HospitalPolyclinicListFilter, page, size int) ([]HospitalPolyclinicRow, error) {
+	// Order değeri usecase'de asc/desc olarak doğrulanır
+	var order string
+	switch filter.Sort {
+	case dto.SortByTotalPersonnel:
+		order = "COALESCE(polyclinic_staff_stats.total, 0) " + filter.Order + ", hospital_polyclinics.id"
+	case dto.SortByName:
+		order = "polyclinics.name " + filter.Order + ", hospital_polyclinics.id"
+	default:
+		order = "hospital_polyclinics.id"
+	}
+
+	var rows []HospitalPolyclinicRow
+	err := r.hospitalPolyclinicQuery(hospitalID, filter).
+		Select("hospital_polyclinics.*, polyclinics.name AS polyclinic_name, polyclinics.code AS polyclinic_code, " +
+			"COALESCE(polyclinic_staff_stats.total, 0) AS total_personnel").
+		Order(order).
 		Offset((page - 1) * size).Limit(size).
-		Find(&hps).Error
-	return hps, err
+		Scan(&rows).Error
+	return rows, err
 }
 
 // // Toplam personel sayısı
@@ -109,7 +189,189 @@ func (r *polyclinicRepository) GetHospitalPolyclinicByID(id uint) (*models.Hospi
 	return &hp, nil
 }
 
-func (r *polyclinicRepository) Delete(hp *models.HospitalPolyclinic) error {
-	return r.db.Delete(hp).Error
+// Delete polikliniği birimleri, çalışma saatleri, tatilleri, odaları ve personel sayılarıyla
+// birlikte siler. Personelin reassignTo'ya taşınması ya da atamasının kaldırılması
+// HospitalPolyclinicRemoved olayıyla personnel servisinde yapılır; reassignTo poliklinikte
+// saklanır ki kaldırmayla eşzamanlı atanan personel de aynı yere taşınsın.
+func (r *polyclinicRepository) Delete(hp *models.HospitalPolyclinic, reassignTo *uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(hp).Update("removal_reassign_to", reassignTo).Error; err != nil {
+			return err
+		}
+
+		children := []interface{}{
+			&models.HospitalPolyclinicUnit{},
+			&models.PolyclinicOpeningHour{},
+			&models.PolyclinicHoliday{},
+			&models.ExamRoom{},
+			&models.PolyclinicStaffGroupStat{},
+			&models.PolyclinicStaffStat{},
+		}
+		for _, child := range children {
+			if err := tx.Where("hospital_polyclinic_id = ?", hp.ID).Delete(child).Error; err != nil {
+				return err
+			}
+		}
+		// Eşzamanlı iki kaldırma isteğinden yalnızca biri olay yayınlar
+		res := tx.Delete(hp)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errors.New("hospital polyclinic not found")
+		}
+		return events.Enqueue(tx, EventSource, events.HospitalPolyclinicRemoved, events.HospitalPolyclinicRemovedPayload{
+			HospitalID:           hp.HospitalID,
+			HospitalPolyclinicID: hp.ID,
+			PolyclinicID:         hp.PolyclinicID,
+			ReassignTo:           reassignTo,
+		})
+	})
+}
+
+// Birden fazla hastanenin poliklinik adları tek sorguda çekilir
+func (r *polyclinicRepository) GetPolyclinicNamesByHospitalIDs(hospitalIDs []uint) (map[uint][]string, error) {
+	result := make(map[uint][]string, len(hospitalIDs))
+	if len(hospitalIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		HospitalID uint
+		Name       string
+	}
+	err := r.db.Table("hospital_polyclinics").
+		Select("hospital_polyclinics.hospital_id, polyclinics.name").
+		Joins("JOIN polyclinics ON polyclinics.id = hospital_polyclinics.polyclinic_id AND polyclinics.deleted_at IS NULL").
+		Where("hospital_polyclinics.hospital_id IN ? AND hospital_polyclinics.deleted_at IS NULL", hospitalIDs).
+		Order("polyclinics.name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.HospitalID] = append(result[row.HospitalID], row.Name)
+	}
+	return result, nil
+}
+
+// HospitalPolyclinicRef hastane polikliniğinin kimliği ve katalogdaki adıdır
+type HospitalPolyclinicRef struct {
+	ID             uint
+	HospitalID     uint
+	PolyclinicID   uint
+	PolyclinicName string
+}
+
+func (r *polyclinicRepository) hospitalPolyclinicRefs() *gorm.DB {
+	return r.db.Table("hospital_polyclinics").
+		Select("hospital_polyclinics.id, hospital_polyclinics.hospital_id, hospital_polyclinics.polyclinic_id, polyclinics.name AS polyclinic_name").
+		Joins("JOIN polyclinics ON polyclinics.id = hospital_polyclinics.polyclinic_id AND polyclinics.deleted_at IS NULL").
+		Where("hospital_polyclinics.deleted_at IS NULL")
+}
+
+func (r *polyclinicRepository) ListHospitalPolyclinicRefs(hospitalID uint) ([]HospitalPolyclinicRef, error) {
+	var refs []HospitalPolyclinicRef
+	err := r.hospitalPolyclinicRefs().
+		Where("hospital_polyclinics.hospital_id = ?", hospitalID).
+		Order("polyclinics.name").
+		Scan(&refs).Error
+	return refs, err
+}
+
+// GetHospitalPolyclinicRefsByIDs bulunamayan (silinmiş) ve başka hastaneye ait kimlikleri sonuçta döndürmez
+func (r *polyclinicRepository) GetHospitalPolyclinicRefsByIDs(hospitalID uint, ids []uint) ([]HospitalPolyclinicRef, error) {
+	var refs []HospitalPolyclinicRef
+	if len(ids) == 0 {
+		return refs, nil
+	}
+	err := r.hospitalPolyclinicRefs().
+		Where("hospital_polyclinics.hospital_id = ? AND hospital_polyclinics.id IN ?", hospitalID, ids).
+		Order("hospital_polyclinics.id").
+		Scan(&refs).Error
+	return refs, err
+}
+
+func (r *polyclinicRepository) CreatePolyclinic(p *models.Polyclinic) error {
+	return r.db.Create(p).Error
+}
+
+func (r *polyclinicRepository) UpdatePolyclinic(p *models.Polyclinic) error {
+	return r.db.Save(p).Error
+}
+
+func (r *polyclinicRepository) DeletePolyclinic(p *models.Polyclinic) error {
+	return r.db.Delete(p).Error
+}
+
+func (r *polyclinicRepository) IsPolyclinicNameOrCodeTaken(excludeID uint, name string, code *string) (bool, error) {
+	var count int64
+	query := r.db.Unscoped().Model(&models.Polyclinic{}).Where("id != ?", excludeID)
+	if code != nil {
+		query = query.Where("name = ? OR code = ?", name, *code)
+	} else {
+		query = query.Where("name = ?", name)
+	}
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+func (r *polyclinicRepository) CountHospitalPolyclinicsByPolyclinicID(polyclinicID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.HospitalPolyclinic{}).
+		Where("polyclinic_id = ?", polyclinicID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *polyclinicRepository) CreateUnit(unit *models.HospitalPolyclinicUnit) error {
+	return r.db.Create(unit).Error
+}
+
+func (r *polyclinicRepository) GetUnitByID(id uint) (*models.HospitalPolyclinicUnit, error) {
+	var unit models.HospitalPolyclinicUnit
+	if err := r.db.First(&unit, id).Error; err != nil {
+		return nil, errors.New("unit not found")
+	}
+	return &unit, nil
 }
 
+func (r *polyclinicRepository) UpdateUnit(unit *models.HospitalPolyclinicUnit) error {
+	return r.db.Save(unit).Error
+}
+
+func (r *polyclinicRepository) DeleteUnit(unit *models.HospitalPolyclinicUnit) error {
+	return r.db.Delete(unit).Error
+}
+
+func (r *polyclinicRepository) IsUnitCodeTaken(hospitalPolyclinicID, excludeID uint, code string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.HospitalPolyclinicUnit{}).
+		Where("hospital_polyclinic_id = ? AND id != ? AND code = ?", hospitalPolyclinicID, excludeID, code).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *polyclinicRepository) GetUnitsByHospitalPolyclinicIDs(ids []uint) (map[uint][]models.HospitalPolyclinicUnit, error) {
+	result := make(map[uint][]models.HospitalPolyclinicUnit, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	var units []models.HospitalPolyclinicUnit
+	if err := r.db.Where("hospital_polyclinic_id IN ?", ids).Order("name").Find(&units).Error; err != nil {
+		return nil, err
+	}
+	for _, unit := range units {
+		result[unit.HospitalPolyclinicID] = append(result[unit.HospitalPolyclinicID], unit)
+	}
+	return result, nil
+}
+
+// ListHospitalPolyclinicsAfter silinmişler dahil tüm hastane polikliniklerini ID sırasıyla sayfalar
+func (r *polyclinicRepository) ListHospitalPolyclinicsAfter(afterID uint, limit int) ([]models.HospitalPolyclinic, error) {
+	var hps []models.HospitalPolyclinic
+	err := r.db.Unscoped().Where("id > ?", afterID).Order("id").Limit(limit).Find(&hps).Error
+	return hps, err
+}
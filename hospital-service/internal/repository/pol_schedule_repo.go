@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"hospital-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+func (r *polyclinicRepository) UpdateHospitalPolyclinic(hp *models.HospitalPolyclinic) error {
+	return r.db.Save(hp).Error
+}
+
+// Haftalık düzen her seferinde bütün olarak değiştirilir
+func (r *polyclinicRepository) ReplaceOpeningHours(hospitalPolyclinicID uint, hours []models.PolyclinicOpeningHour) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("hospital_polyclinic_id = ?", hospitalPolyclinicID).Delete(&models.PolyclinicOpeningHour{}).Error; err != nil {
+			return err
+		}
+		if len(hours) == 0 {
+			return nil
+		}
+		return tx.Create(&hours).Error
+	})
+}
+
+func (r *polyclinicRepository) GetOpeningHoursByHospitalPolyclinicIDs(ids []uint) (map[uint][]models.PolyclinicOpeningHour, error) {
+	result := make(map[uint][]models.PolyclinicOpeningHour, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	var hours []models.PolyclinicOpeningHour
+	if err := r.db.Where("hospital_polyclinic_id IN ?", ids).Order("weekday, opens_at").Find(&hours).Error; err != nil {
+		return nil, err
+	}
+	for _, h := range hours {
+		result[h.HospitalPolyclinicID] = append(result[h.HospitalPolyclinicID], h)
+	}
+	return result, nil
+}
+
+func (r *polyclinicRepository) CreateHoliday(h *models.PolyclinicHoliday) error {
+	return r.db.Create(h).Error
+}
+
+func (r *polyclinicRepository) GetHolidayByID(id uint) (*models.PolyclinicHoliday, error) {
+	var holiday models.PolyclinicHoliday
+	if err := r.db.First(&holiday, id).Error; err != nil {
+		return nil, errors.New("holiday not found")
+	}
+	return &holiday, nil
+}
+
+// Tarih tekil indekste olduğundan kayıt kalıcı silinir
+func (r *polyclinicRepository) DeleteHoliday(h *models.PolyclinicHoliday) error {
+	return r.db.Unscoped().Delete(h).Error
+}
+
+func (r *polyclinicRepository) IsHolidayDateTaken(hospitalPolyclinicID uint, date time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.PolyclinicHoliday{}).
+		Where("hospital_polyclinic_id = ? AND date = ?", hospitalPolyclinicID, date).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *polyclinicRepository) GetHolidaysByHospitalPolyclinicIDs(ids []uint, from time.Time) (map[uint][]models.PolyclinicHoliday, error) {
+	result := make(map[uint][]models.PolyclinicHoliday, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	var holidays []models.PolyclinicHoliday
+	err := r.db.Where("hospital_polyclinic_id IN ? AND date >= ?", ids, from).
+		Order("date").
+		Find(&holidays).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range holidays {
+		result[h.HospitalPolyclinicID] = append(result[h.HospitalPolyclinicID], h)
+	}
+	return result, nil
+}
+
+func (r *polyclinicRepository) CreateExamRoom(room *models.ExamRoom) error {
+	return r.db.Create(room).Error
+}
+
+func (r *polyclinicRepository) GetExamRoomByID(id uint) (*models.ExamRoom, error) {
+	var room models.ExamRoom
+	if err := r.db.First(&room, id).Error; err != nil {
+		return nil, errors.New("exam room not found")
+	}
+	return &room, nil
+}
+
+func (r *polyclinicRepository) UpdateExamRoom(room *models.ExamRoom) error {
+	return r.db.Save(room).Error
+}
+
+func (r *polyclinicRepository) DeleteExamRoom(room *models.ExamRoom) error {
+	return r.db.Delete(room).Error
+}
+
+func (r *polyclinicRepository) IsExamRoomTaken(hospitalID, excludeID uint, floor, number string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.ExamRoom{}).
+		Where("hospital_id = ? AND id != ? AND floor = ? AND number = ?", hospitalID, excludeID, floor, number).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *polyclinicRepository) GetExamRoomsByHospitalPolyclinicIDs(ids []uint) (map[uint][]models.ExamRoom, error) {
+	result := make(map[uint][]models.ExamRoom, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	var rooms []models.ExamRoom
+	if err := r.db.Where("hospital_polyclinic_id IN ?", ids).Order("floor, number").Find(&rooms).Error; err != nil {
+		return nil, err
+	}
+	for _, room := range rooms {
+		result[room.HospitalPolyclinicID] = append(result[room.HospitalPolyclinicID], room)
+	}
+	return result, nil
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"time"
+
+	"hospital-service/internal/models"
+	dt "hospital-shared/dto"
+
+	"gorm.io/gorm"
+)
+
+type StaffStatsRepository interface {
+	Apply(countedAt time.Time, stats []dt.PolyclinicPersonnelStats) error
+	Replace(stats []dt.PolyclinicPersonnelStats) error
+	DeleteOrphans() error
+	GetGroupsByHospitalPolyclinicIDs(ids []uint) (map[uint][]models.PolyclinicStaffGroupStat, error)
+}
+
+type staffStatsRepository struct {
+	db *gorm.DB
+}
+
+func NewStaffStatsRepository(db *gorm.DB) StaffStatsRepository {
+	return &staffStatsRepository{db: db}
+}
+
+// Apply personnel servisinin countedAt anındaki mutlak sayılarını yazar. Poliklinikte daha yeni
+// bir sayım varsa o poliklinik atlanır; tekrar gelen ya da geç gelen olaylar sayıları geri almaz.
+func (r *staffStatsRepository) Apply(countedAt time.Time, stats []dt.PolyclinicPersonnelStats) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, s := range stats {
+			res := tx.Exec(`
+				INSERT INTO polyclinic_staff_stats (hospital_polyclinic_id, total, counted_at, updated_at)
+				VALUES (?, ?, ?, ?)
+				ON CONFLICT (hospital_polyclinic_id)
+				DO UPDATE SET total = EXCLUDED.total, counted_at = EXCLUDED.counted_at, updated_at = EXCLUDED.updated_at
+				WHERE polyclinic_staff_stats.counted_at < EXCLUDED.counted_at`,
+				s.HospitalPolyclinicID, s.Total, countedAt, time.Now())
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				continue
+			}
+			if err := replaceGroups(tx, s); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func replaceGroups(tx *gorm.DB, s dt.PolyclinicPersonnelStats) error {
+	if err := tx.Where("hospital_polyclinic_id = ?", s.HospitalPolyclinicID).Delete(&models.PolyclinicStaffGroupStat{}).Error; err != nil {
+		return err
+	}
+	if len(s.Groups) == 0 {
+		return nil
+	}
+	groups := make([]models.PolyclinicStaffGroupStat, 0, len(s.Groups))
+	for _, g := range s.Groups {
+		groups = append(groups, models.PolyclinicStaffGroupStat{
+			HospitalPolyclinicID: s.HospitalPolyclinicID,
+			JobGroupID:           g.GroupID,
+			JobGroupName:         g.GroupName,
+			Count:                g.Count,
+		})
+	}
+	return tx.Create(&groups).Error
+}
+
+// Replace verilen poliklinikler için sayıları personnel servisinden gelen değerlerle değiştirir.
+// Bu sayımların zamanı bilinmediği için CountedAt değişmez; sonraki olaylar yine uygulanır.
+func (r *staffStatsRepository) Replace(stats []dt.PolyclinicPersonnelStats) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, s := range stats {
+			err := tx.Exec(`
+				INSERT INTO polyclinic_staff_stats (hospital_polyclinic_id, total, updated_at)
+				VALUES (?, ?, ?)
+				ON CONFLICT (hospital_polyclinic_id)
+				DO UPDATE SET total = EXCLUDED.total, updated_at = EXCLUDED.updated_at`,
+				s.HospitalPolyclinicID, s.Total, time.Now()).Error
+			if err != nil {
+				return err
+			}
+			if err := replaceGroups(tx, s); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteOrphans kaldırılmış hastane polikliniklerine ait satırları siler
+func (r *staffStatsRepository) DeleteOrphans() error {
+	active := r.db.Model(&models.HospitalPolyclinic{}).Select("id")
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("hospital_polyclinic_id NOT IN (?)", active).Delete(&models.PolyclinicStaffGroupStat{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("hospital_polyclinic_id NOT IN (?)", active).Delete(&models.PolyclinicStaffStat{}).Error
+	})
+}
+
+func (r *staffStatsRepository) GetGroupsByHospitalPolyclinicIDs(ids []uint) (map[uint][]models.PolyclinicStaffGroupStat, error) {
+	result := make(map[uint][]models.PolyclinicStaffGroupStat, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	var groups []models.PolyclinicStaffGroupStat
+	err := r.db.Where("hospital_polyclinic_id IN ? AND count > 0", ids).
+		Order("job_group_name").
+		Find(&groups).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range groups {
+		result[g.HospitalPolyclinicID] = append(result[g.HospitalPolyclinicID], g)
+	}
+	return result, nil
+}
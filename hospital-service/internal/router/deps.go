@@ -4,6 +4,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"hospital-service/internal/config"
 	"hospital-service/internal/database"
+	"hospital-shared/cache"
 	sharedjwt "hospital-shared/jwt"
 )
 
@@ -12,4 +13,5 @@ type RouterDeps struct {
 	DB     *database.Database
 	Config *config.Config
 	JWTSharedConfig *sharedjwt.JWTConfig
+	Cache           *cache.Cache
 }
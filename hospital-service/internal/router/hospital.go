@@ -2,15 +2,18 @@ package router
 
 import (
 	"hospital-service/internal/handler"
+	"hospital-service/internal/infrastructure/client"
 	"hospital-service/internal/repository"
 	"hospital-service/internal/usecase"
 	"hospital-shared/jwt"
 	"hospital-shared/middleware"
+	"hospital-shared/notification"
 )
 
 func HospitalRoutes(deps RouterDeps) {
 	hRepo := repository.NewHospitalRepository(deps.DB.SQL)
-	hUsecase := usecase.NewHospitalUsecase(hRepo)
+	notifier := notification.NewNotifier(notification.SMTPConfig(deps.Config.SMTP))
+	hUsecase := usecase.NewHospitalUsecase(hRepo, notifier, client.NewAuthClient(deps.Config.Auth.BaseUrl, deps.Config.Internal.Token))
 	hHandler := handler.NewHospitalHandler(hUsecase, deps.Config)
 
 	api := deps.App.Group("/api")
@@ -18,8 +21,11 @@ func HospitalRoutes(deps RouterDeps) {
 	hGroup := api.Group("/hospital")
 
 	// Mikroservis arası iletişim için - auth service'den hastane oluşturma
-	hGroup.Post("/", hHandler.CreateHospital)
+	hGroup.Post("/", middleware.InternalAuth(deps.Config.Internal.Token), hHandler.CreateHospital)
+	// Diğer servislerin JWT middleware'i hastane durumunu bu endpointten sorar
+	hGroup.Get("/:id/status", middleware.InternalAuth(deps.Config.Internal.Token), hHandler.GetHospitalStatus)
 
+	hGroup.Get("/nearby", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili", "calisan"), hHandler.NearbyHospitals)
 	hGroup.Get("/me", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), hHandler.GetHospitalMe)
 	hGroup.Put("/me", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), hHandler.UpdateHospitalMe)
 }
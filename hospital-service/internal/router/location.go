@@ -10,7 +10,7 @@ import (
 func LocationRoutes(deps RouterDeps) {
 
 	locationRepo := repository.NewLocationRepository(deps.DB.SQL)
-	locationUsecase := usecase.NewLocationUsecase(locationRepo, deps.DB.Redis)
+	locationUsecase := usecase.NewLocationUsecase(locationRepo, deps.Cache)
 	locationHandler := handler.NewLocationHandler(locationUsecase)
 
 	api := deps.App.Group("/api")
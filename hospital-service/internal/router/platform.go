@@ -0,0 +1,39 @@
+package router
+
+import (
+	"hospital-service/internal/handler"
+	"hospital-service/internal/infrastructure/client"
+	"hospital-service/internal/repository"
+	"hospital-service/internal/usecase"
+	"hospital-shared/jwt"
+	"hospital-shared/middleware"
+	"hospital-shared/notification"
+)
+
+// PlatformRoutes hastaneye bağlı olmayan platform yöneticisi endpointleri
+func PlatformRoutes(deps RouterDeps) {
+	hRepo := repository.NewHospitalRepository(deps.DB.SQL)
+	notifier := notification.NewNotifier(notification.SMTPConfig(deps.Config.SMTP))
+	hUsecase := usecase.NewHospitalUsecase(hRepo, notifier, client.NewAuthClient(deps.Config.Auth.BaseUrl, deps.Config.Internal.Token))
+	pRepo := repository.NewPolyclinicRepository(deps.DB.SQL)
+	personnelClient := client.NewPersonnelClient(deps.Config.Url.BaseUrl, deps.Config.Internal.Token)
+	pUsecase := usecase.NewPolyclinicUsecase(pRepo, repository.NewStaffStatsRepository(deps.DB.SQL), personnelClient, deps.Cache)
+	platformHandler := handler.NewPlatformHandler(hUsecase, pUsecase, deps.Config)
+
+	api := deps.App.Group("/api")
+
+	platformGroup := api.Group("/platform", middleware.AdminRateLimiter(), jwt.PlatformAuthRequired(deps.JWTSharedConfig))
+
+	platformGroup.Get("/hospitals", platformHandler.ListHospitals)
+	platformGroup.Get("/hospitals/:id", platformHandler.GetHospital)
+	platformGroup.Put("/hospitals/:id/approve", platformHandler.ApproveHospital)
+	platformGroup.Put("/hospitals/:id/reject", platformHandler.RejectHospital)
+	platformGroup.Put("/hospitals/:id/suspend", platformHandler.SuspendHospital)
+	platformGroup.Put("/hospitals/:id/reactivate", platformHandler.ReactivateHospital)
+	platformGroup.Put("/hospitals/:id/close", platformHandler.CloseHospital)
+
+	platformGroup.Get("/polyclinics", platformHandler.ListPolyclinics)
+	platformGroup.Post("/polyclinics", platformHandler.CreatePolyclinic)
+	platformGroup.Put("/polyclinics/:id", platformHandler.UpdatePolyclinic)
+	platformGroup.Delete("/polyclinics/:id", platformHandler.DeletePolyclinic)
+}
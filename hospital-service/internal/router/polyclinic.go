@@ -12,8 +12,8 @@ import (
 func PolyclinicRoutes(deps RouterDeps) {
 
 	polyclinicRepo := repository.NewPolyclinicRepository(deps.DB.SQL)
-	personnelClient := client.NewPersonnelClient(deps.Config.Url.BaseUrl)
-	polyclinicUsecase := usecase.NewPolyclinicUsecase(polyclinicRepo, personnelClient)
+	personnelClient := client.NewPersonnelClient(deps.Config.Url.BaseUrl, deps.Config.Internal.Token)
+	polyclinicUsecase := usecase.NewPolyclinicUsecase(polyclinicRepo, repository.NewStaffStatsRepository(deps.DB.SQL), personnelClient, deps.Cache)
 	polyclinicHandler := handler.NewPolyclinicHandler(polyclinicUsecase, deps.Config)
 
 	api := deps.App.Group("/api")
@@ -25,6 +25,21 @@ func PolyclinicRoutes(deps RouterDeps) {
 	polyclinicGroup.Get("/hospital-polyclinics", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili", "calisan"), polyclinicHandler.ListHospitalPolyclinic)
 	polyclinicGroup.Delete("/hospital-polyclinics/:id", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), polyclinicHandler.RemoveHospitalPolyclinic)
 
+	polyclinicGroup.Get("/hospital-polyclinics/:id/units", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili", "calisan"), polyclinicHandler.ListUnits)
+	polyclinicGroup.Post("/hospital-polyclinics/:id/units", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), polyclinicHandler.AddUnit)
+	polyclinicGroup.Put("/hospital-polyclinics/:id/units/:unitId", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), polyclinicHandler.UpdateUnit)
+	polyclinicGroup.Delete("/hospital-polyclinics/:id/units/:unitId", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), polyclinicHandler.DeleteUnit)
+
+	polyclinicGroup.Put("/hospital-polyclinics/:id/capacity", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), polyclinicHandler.UpdateCapacity)
+	polyclinicGroup.Put("/hospital-polyclinics/:id/opening-hours", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), polyclinicHandler.UpdateOpeningHours)
+	polyclinicGroup.Post("/hospital-polyclinics/:id/holidays", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), polyclinicHandler.AddHoliday)
+	polyclinicGroup.Delete("/hospital-polyclinics/:id/holidays/:holidayId", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), polyclinicHandler.DeleteHoliday)
+	polyclinicGroup.Post("/hospital-polyclinics/:id/rooms", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), polyclinicHandler.AddExamRoom)
+	polyclinicGroup.Put("/hospital-polyclinics/:id/rooms/:roomId", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), polyclinicHandler.UpdateExamRoom)
+	polyclinicGroup.Delete("/hospital-polyclinics/:id/rooms/:roomId", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), polyclinicHandler.DeleteExamRoom)
+
 	// Mikroservis arası iletişim için JWT gerektirmeyen endpoint - Personnel servisi bu endpointe http isteği atıyor
 	polyclinicGroup.Get("/hospital-polyclinics/:id", polyclinicHandler.GetHospitalPolyclinic)
+	polyclinicGroup.Get("/internal/hospitals/:hospitalId/hospital-polyclinics", middleware.InternalAuth(deps.Config.Internal.Token), polyclinicHandler.ListHospitalPolyclinicRefs)
+	polyclinicGroup.Post("/internal/hospital-polyclinics/batch", middleware.InternalAuth(deps.Config.Internal.Token), polyclinicHandler.GetHospitalPolyclinicsBatch)
 }
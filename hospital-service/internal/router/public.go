@@ -0,0 +1,22 @@
+package router
+
+import (
+	"hospital-service/internal/handler"
+	"hospital-service/internal/repository"
+	"hospital-service/internal/usecase"
+	"hospital-shared/middleware"
+)
+
+// PublicRoutes hastalar ve iş ortakları için kimlik doğrulamasız endpointler
+func PublicRoutes(deps RouterDeps) {
+	hRepo := repository.NewHospitalRepository(deps.DB.SQL)
+	pRepo := repository.NewPolyclinicRepository(deps.DB.SQL)
+	publicUsecase := usecase.NewPublicUsecase(hRepo, pRepo)
+	publicHandler := handler.NewPublicHandler(publicUsecase)
+
+	api := deps.App.Group("/api")
+
+	publicGroup := api.Group("/public", middleware.PublicRateLimiter())
+
+	publicGroup.Get("/hospitals", publicHandler.SearchHospitals)
+}
@@ -2,31 +2,72 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
 
 	"hospital-service/internal/dto"
+	"hospital-service/internal/infrastructure/client"
 	"hospital-service/internal/models"
 	"hospital-service/internal/repository"
 	dt "hospital-shared/dto"
+	"hospital-shared/notification"
 )
 
 type HospitalUsecase interface {
 	GetHospitalByID(hospitalID uint) (*dto.HospitalResponse, error)
 	UpdateHospital(hospitalID uint, req *dto.UpdateHospitalRequest) (*dto.HospitalResponse, error)
 	CreateHospital(req *dt.CreateHospitalRequest) (*dto.HospitalResponse, error)
+
+	GetHospitalStatus(hospitalID uint) (string, error)
+	FindNearbyHospitals(filter dto.NearbyHospitalFilter) (*dto.NearbyHospitalListResponse, error)
+
+	ListHospitals(filter dto.HospitalListFilter, page, size int) (*dto.HospitalListResponse, error)
+	ApproveHospital(hospitalID uint) (*dto.HospitalResponse, error)
+	RejectHospital(hospitalID uint, reason string) (*dto.HospitalResponse, error)
+	SuspendHospital(hospitalID uint, reason string) (*dto.HospitalResponse, error)
+	ReactivateHospital(hospitalID uint) (*dto.HospitalResponse, error)
+	CloseHospital(hospitalID uint, reason string) (*dto.HospitalResponse, error)
 }
 
+const (
+	defaultNearbyRadiusKm = 10.0
+	maxNearbyRadiusKm     = 200.0
+	maxNearbyResults      = 50
+)
+
+// Hangi durumdan hangi duruma geçilebileceği
+var hospitalTransitions = map[string][]string{
+	models.HospitalStatusPending:   {models.HospitalStatusActive, models.HospitalStatusClosed},
+	models.HospitalStatusActive:    {models.HospitalStatusSuspended, models.HospitalStatusClosed},
+	models.HospitalStatusSuspended: {models.HospitalStatusActive, models.HospitalStatusClosed},
+}
+
+// Kaydı yapan kullanıcı hastanenin ilk yetkilisidir
+const registrantRole = "yetkili"
+
 type hospitalUsecase struct {
-	repo repository.HospitalRepository
+	repo       repository.HospitalRepository
+	notifier   notification.Notifier
+	authClient client.AuthClient
 }
 
-func NewHospitalUsecase(repo repository.HospitalRepository) HospitalUsecase {
-	return &hospitalUsecase{repo: repo}
+func NewHospitalUsecase(repo repository.HospitalRepository, notifier notification.Notifier, authClient client.AuthClient) HospitalUsecase {
+	return &hospitalUsecase{
+		repo:       repo,
+		notifier:   notifier,
+		authClient: authClient,
+	}
 }
 
 func (u *hospitalUsecase) CreateHospital(req *dt.CreateHospitalRequest) (*dto.HospitalResponse, error) {
 	if exists, _ := u.repo.IsHospitalExists(req.TaxNumber, req.Email, req.Phone); exists {
 		return nil, errors.New("hospital already exists")
 	}
+	if err := validateCoordinates(req.Latitude, req.Longitude); err != nil {
+		return nil, err
+	}
 
 	hospital := &models.Hospital{
 		Name:       req.Name,
@@ -36,6 +77,9 @@ func (u *hospitalUsecase) CreateHospital(req *dt.CreateHospitalRequest) (*dto.Ho
 		Address:    req.Address,
 		CityID:     req.CityID,
 		DistrictID: req.DistrictID,
+		Latitude:   req.Latitude,
+		Longitude:  req.Longitude,
+		Status:     models.HospitalStatusPending,
 	}
 	if err := u.repo.CreateHospital(hospital); err != nil {
 		return nil, err
@@ -44,18 +88,7 @@ func (u *hospitalUsecase) CreateHospital(req *dt.CreateHospitalRequest) (*dto.Ho
 	city, _ := u.repo.GetCityByID(hospital.CityID)
 	district, _ := u.repo.GetDistrictByID(hospital.DistrictID)
 
-	return &dto.HospitalResponse{
-		ID:           hospital.ID,
-		TaxNumber:    hospital.TaxNumber,
-		Name:         hospital.Name,
-		Email:        hospital.Email,
-		Phone:        hospital.Phone,
-		Address:      hospital.Address,
-		CityID:       hospital.CityID,
-		CityName:     city.Name,
-		DistrictID:   hospital.DistrictID,
-		DistrictName: district.Name,
-	}, nil
+	return toHospitalResponse(hospital, city, district), nil
 }
 
 func (u *hospitalUsecase) GetHospitalByID(hospitalID uint) (*dto.HospitalResponse, error) {
@@ -66,18 +99,18 @@ func (u *hospitalUsecase) GetHospitalByID(hospitalID uint) (*dto.HospitalRespons
 	city, _ := u.repo.GetCityByID(hospital.CityID)
 	district, _ := u.repo.GetDistrictByID(hospital.DistrictID)
 
-	return &dto.HospitalResponse{
-		ID:           hospital.ID,
-		Name:         hospital.Name,
-		TaxNumber:    hospital.TaxNumber,
-		Email:        hospital.Email,
-		Phone:        hospital.Phone,
-		Address:      hospital.Address,
-		CityID:       hospital.CityID,
-		CityName:     city.Name,
-		DistrictID:   hospital.DistrictID,
-		DistrictName: district.Name,
-	}, nil
+	return toHospitalResponse(hospital, city, district), nil
+}
+
+// ErrHospitalNotFound durum sorgusunda hastanenin olmadığını diğer hatalardan ayırmak için
+var ErrHospitalNotFound = repository.ErrHospitalNotFound
+
+func (u *hospitalUsecase) GetHospitalStatus(hospitalID uint) (string, error) {
+	hospital, err := u.repo.GetByID(hospitalID)
+	if err != nil {
+		return "", err
+	}
+	return hospital.Status, nil
 }
 
 func (u *hospitalUsecase) UpdateHospital(hospitalID uint, req *dto.UpdateHospitalRequest) (*dto.HospitalResponse, error) {
@@ -90,6 +123,13 @@ func (u *hospitalUsecase) UpdateHospital(hospitalID uint, req *dto.UpdateHospita
 		return nil, errors.New("another hospital with given tax number, email, or phone already exists")
 	}
 
+	if req.ClearCoordinates && (req.Latitude != nil || req.Longitude != nil) {
+		return nil, errors.New("clear_coordinates cannot be combined with latitude and longitude")
+	}
+	if err := validateCoordinates(req.Latitude, req.Longitude); err != nil {
+		return nil, err
+	}
+
 	city, err := u.repo.GetCityByID(req.CityID)
 	if err != nil {
 		return nil, err
@@ -106,21 +146,261 @@ func (u *hospitalUsecase) UpdateHospital(hospitalID uint, req *dto.UpdateHospita
 	hospital.Address = req.Address
 	hospital.CityID = req.CityID
 	hospital.DistrictID = req.DistrictID
+	switch {
+	case req.ClearCoordinates:
+		hospital.Latitude, hospital.Longitude = nil, nil
+	case req.Latitude != nil:
+		hospital.Latitude, hospital.Longitude = req.Latitude, req.Longitude
+	}
 
 	if err := u.repo.Update(hospital); err != nil {
 		return nil, err
 	}
 
-	return &dto.HospitalResponse{
-		ID:           hospital.ID,
-		Name:         hospital.Name,
-		TaxNumber:    hospital.TaxNumber,
-		Email:        hospital.Email,
-		Phone:        hospital.Phone,
-		Address:      hospital.Address,
-		CityID:       hospital.CityID,
-		CityName:     city.Name,
-		DistrictID:   hospital.DistrictID,
-		DistrictName: district.Name,
+	return toHospitalResponse(hospital, city, district), nil
+}
+
+func (u *hospitalUsecase) FindNearbyHospitals(filter dto.NearbyHospitalFilter) (*dto.NearbyHospitalListResponse, error) {
+	lat, lng := filter.Latitude, filter.Longitude
+	if err := validateCoordinates(&lat, &lng); err != nil {
+		return nil, err
+	}
+	if filter.RadiusKm <= 0 {
+		filter.RadiusKm = defaultNearbyRadiusKm
+	}
+	if filter.RadiusKm > maxNearbyRadiusKm {
+		return nil, fmt.Errorf("radius cannot exceed %.0f km", maxNearbyRadiusKm)
+	}
+
+	nearby, err := u.repo.FindNearby(filter, maxNearbyResults)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.NearbyHospitalResponse, 0, len(nearby))
+	for _, n := range nearby {
+		resp = append(resp, dto.NearbyHospitalResponse{
+			ID:           n.Hospital.ID,
+			Name:         n.Hospital.Name,
+			Phone:        n.Hospital.Phone,
+			Address:      n.Hospital.Address,
+			CityName:     n.Hospital.City.Name,
+			DistrictName: n.Hospital.District.Name,
+			Latitude:     n.Latitude,
+			Longitude:    n.Longitude,
+			DistanceKm:   math.Round(n.DistanceKm*100) / 100,
+			Approximate:  n.Approximate,
+		})
+	}
+
+	return &dto.NearbyHospitalListResponse{
+		Hospitals: resp,
+		RadiusKm:  filter.RadiusKm,
+	}, nil
+}
+
+// Platform listelerinde tek sayfada dönebilecek en fazla kayıt
+const maxPlatformPageSize = 100
+
+func (u *hospitalUsecase) ListHospitals(filter dto.HospitalListFilter, page, size int) (*dto.HospitalListResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = 10
+	}
+	if size > maxPlatformPageSize {
+		size = maxPlatformPageSize
+	}
+
+	total, err := u.repo.CountHospitals(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	hospitals, err := u.repo.ListHospitals(filter, page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.HospitalResponse, 0, len(hospitals))
+	for i := range hospitals {
+		h := &hospitals[i]
+		resp = append(resp, *toHospitalResponse(h, &h.City, &h.District))
+	}
+
+	return &dto.HospitalListResponse{
+		Hospitals: resp,
+		Total:     int(total),
+		Page:      page,
+		Size:      size,
 	}, nil
 }
+
+func (u *hospitalUsecase) ApproveHospital(hospitalID uint) (*dto.HospitalResponse, error) {
+	return u.changeStatus(hospitalID, models.HospitalStatusPending, models.HospitalStatusActive, "")
+}
+
+func (u *hospitalUsecase) RejectHospital(hospitalID uint, reason string) (*dto.HospitalResponse, error) {
+	if reason == "" {
+		return nil, errors.New("reason is required")
+	}
+	return u.changeStatus(hospitalID, models.HospitalStatusPending, models.HospitalStatusClosed, reason)
+}
+
+func (u *hospitalUsecase) SuspendHospital(hospitalID uint, reason string) (*dto.HospitalResponse, error) {
+	if reason == "" {
+		return nil, errors.New("reason is required")
+	}
+	return u.changeStatus(hospitalID, models.HospitalStatusActive, models.HospitalStatusSuspended, reason)
+}
+
+func (u *hospitalUsecase) ReactivateHospital(hospitalID uint) (*dto.HospitalResponse, error) {
+	return u.changeStatus(hospitalID, models.HospitalStatusSuspended, models.HospitalStatusActive, "")
+}
+
+func (u *hospitalUsecase) CloseHospital(hospitalID uint, reason string) (*dto.HospitalResponse, error) {
+	if reason == "" {
+		return nil, errors.New("reason is required")
+	}
+	return u.changeStatus(hospitalID, "", models.HospitalStatusClosed, reason)
+}
+
+// changeStatus from boş değilse hastanenin o durumda olmasını bekler
+func (u *hospitalUsecase) changeStatus(hospitalID uint, from, to, reason string) (*dto.HospitalResponse, error) {
+	hospital, err := u.repo.GetByID(hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	if from != "" && hospital.Status != from {
+		return nil, fmt.Errorf("hospital is %s, expected %s", hospital.Status, from)
+	}
+	if !canTransition(hospital.Status, to) {
+		return nil, fmt.Errorf("hospital cannot move from %s to %s", hospital.Status, to)
+	}
+
+	now := time.Now()
+	previous := hospital.Status
+	hospital.Status = to
+	hospital.StatusReason = reason
+	hospital.StatusChangedAt = &now
+	switch to {
+	case models.HospitalStatusActive:
+		if previous == models.HospitalStatusPending {
+			hospital.ApprovedAt = &now
+		}
+		hospital.SuspendedAt = nil
+	case models.HospitalStatusSuspended:
+		hospital.SuspendedAt = &now
+	case models.HospitalStatusClosed:
+		hospital.ClosedAt = &now
+	}
+
+	if err := u.repo.Update(hospital); err != nil {
+		return nil, err
+	}
+
+	u.notifyStatusChange(hospital, previous)
+
+	city, _ := u.repo.GetCityByID(hospital.CityID)
+	district, _ := u.repo.GetDistrictByID(hospital.DistrictID)
+
+	return toHospitalResponse(hospital, city, district), nil
+}
+
+// Durum değişikliğini hastaneyi kaydeden yetkiliye bildirir, hata akışı bozmaz
+func (u *hospitalUsecase) notifyStatusChange(h *models.Hospital, previous string) {
+	var subject, body string
+	switch {
+	case previous == models.HospitalStatusPending && h.Status == models.HospitalStatusActive:
+		subject = "Hastane kaydınız onaylandı"
+		body = fmt.Sprintf("%s kaydı onaylandı. Sisteme giriş yapabilirsiniz.", h.Name)
+	case previous == models.HospitalStatusPending && h.Status == models.HospitalStatusClosed:
+		subject = "Hastane kaydınız reddedildi"
+		body = fmt.Sprintf("%s kaydı reddedildi. Sebep: %s", h.Name, h.StatusReason)
+	case h.Status == models.HospitalStatusSuspended:
+		subject = "Hastane hesabınız askıya alındı"
+		body = fmt.Sprintf("%s hesabı askıya alındı. Sebep: %s", h.Name, h.StatusReason)
+	case h.Status == models.HospitalStatusActive:
+		subject = "Hastane hesabınız tekrar aktif"
+		body = fmt.Sprintf("%s hesabı tekrar aktif edildi.", h.Name)
+	case h.Status == models.HospitalStatusClosed:
+		subject = "Hastane hesabınız kapatıldı"
+		body = fmt.Sprintf("%s hesabı kapatıldı. Sebep: %s", h.Name, h.StatusReason)
+	default:
+		return
+	}
+
+	if err := u.notifier.Send(u.registrantEmail(h), subject, body); err != nil {
+		log.Printf("hospital %d status notification failed: %v", h.ID, err)
+	}
+}
+
+// registrantEmail auth servisinden hastanenin ilk yetkilisini bulur. Yetkiliye ulaşılamazsa
+// bildirim kaybolmasın diye kayıtta verilen kurum e-postasına düşer.
+func (u *hospitalUsecase) registrantEmail(h *models.Hospital) string {
+	contacts, err := u.authClient.ListHospitalContacts(h.ID, registrantRole)
+	if err != nil {
+		log.Printf("hospital %d registrant lookup failed, using hospital email: %v", h.ID, err)
+		return h.Email
+	}
+	if len(contacts) == 0 || contacts[0].Email == "" {
+		log.Printf("hospital %d has no yetkili, using hospital email", h.ID)
+		return h.Email
+	}
+	return contacts[0].Email
+}
+
+func canTransition(from, to string) bool {
+	for _, s := range hospitalTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Koordinatlar ya birlikte verilir ya hiç verilmez
+func validateCoordinates(lat, lng *float64) error {
+	if lat == nil && lng == nil {
+		return nil
+	}
+	if lat == nil || lng == nil {
+		return errors.New("latitude and longitude must be provided together")
+	}
+	if *lat < -90 || *lat > 90 {
+		return errors.New("latitude must be between -90 and 90")
+	}
+	if *lng < -180 || *lng > 180 {
+		return errors.New("longitude must be between -180 and 180")
+	}
+	return nil
+}
+
+func toHospitalResponse(h *models.Hospital, city *models.City, district *models.District) *dto.HospitalResponse {
+	resp := &dto.HospitalResponse{
+		ID:           h.ID,
+		Name:         h.Name,
+		TaxNumber:    h.TaxNumber,
+		Email:        h.Email,
+		Phone:        h.Phone,
+		Address:      h.Address,
+		CityID:       h.CityID,
+		DistrictID:   h.DistrictID,
+		Latitude:     h.Latitude,
+		Longitude:    h.Longitude,
+		Status:       h.Status,
+		StatusReason: h.StatusReason,
+		ApprovedAt:   h.ApprovedAt,
+		CreatedAt:    h.CreatedAt,
+
+		DeletionFlaggedAt: h.DeletionFlaggedAt,
+	}
+	if city != nil {
+		resp.CityName = city.Name
+	}
+	if district != nil {
+		resp.DistrictName = district.Name
+	}
+	return resp
+}
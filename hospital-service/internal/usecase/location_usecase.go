@@ -2,12 +2,11 @@ package usecase
 
 import (
 	"context"
-	"encoding/json"
+	"time"
 
 	"hospital-service/internal/dto"
 	"hospital-service/internal/repository"
-
-	"github.com/go-redis/redis/v8"
+	"hospital-shared/cache"
 )
 
 type LocationUsecase interface {
@@ -15,79 +14,61 @@ type LocationUsecase interface {
 	ListDistrictsByCity(cityID uint) ([]dto.DistrictLookup, error)
 }
 
+// Lokasyon verisi yalnızca içe aktarım komutuyla değişir
+var (
+	CityCacheEntity     = cache.Entity{Name: "cities", Version: 1, TTL: 24 * time.Hour}
+	DistrictCacheEntity = cache.Entity{Name: "districts_by_city", Version: 1, TTL: 24 * time.Hour}
+)
+
 type locationUsecase struct {
 	repo  repository.LocationRepository
-	redis *redis.Client
+	cache *cache.Cache
 }
 
-func NewLocationUsecase(repo repository.LocationRepository, redis *redis.Client) LocationUsecase {
+func NewLocationUsecase(repo repository.LocationRepository, c *cache.Cache) LocationUsecase {
 	return &locationUsecase{
 		repo:  repo,
-		redis: redis,
+		cache: c,
 	}
 }
 
 func (u *locationUsecase) ListAllCities() ([]dto.CityLookup, error) {
-	ctx := context.Background()
-	cacheKey := "cities"
-
-	cached, err := u.redis.Get(ctx, cacheKey).Result()
-	if err == nil && cached != "" {
-		var resp []dto.CityLookup
-		if err := json.Unmarshal([]byte(cached), &resp); err == nil {
-			return resp, nil
+	return cache.GetOrLoad(context.Background(), u.cache, CityCacheEntity, nil, func() ([]dto.CityLookup, error) {
+		cities, err := u.repo.GetAllCities()
+		if err != nil {
+			return nil, err
 		}
-	}
-
-	cities, err := u.repo.GetAllCities()
-	if err != nil {
-		return nil, err
-	}
-
-	resp := make([]dto.CityLookup, 0, len(cities))
-	for _, c := range cities {
-		resp = append(resp, dto.CityLookup{
-			ID:   c.ID,
-			Name: c.Name,
-		})
-	}
-
-	if data, err := json.Marshal(resp); err == nil {
-		_ = u.redis.Set(ctx, cacheKey, data, 0).Err()
-	}
 
-	return resp, nil
+		resp := make([]dto.CityLookup, 0, len(cities))
+		for _, c := range cities {
+			item := dto.CityLookup{
+				ID:   c.ID,
+				Name: c.Name,
+			}
+			if c.PlateCode != nil {
+				item.PlateCode = *c.PlateCode
+			}
+			resp = append(resp, item)
+		}
+		return resp, nil
+	})
 }
 
 func (u *locationUsecase) ListDistrictsByCity(cityID uint) ([]dto.DistrictLookup, error) {
-	ctx := context.Background()
-	cacheKey := "districts_by_city_" + string(rune(cityID))
-
-	cached, err := u.redis.Get(ctx, cacheKey).Result()
-	if err == nil && cached != "" {
-		var resp []dto.DistrictLookup
-		if err := json.Unmarshal([]byte(cached), &resp); err == nil {
-			return resp, nil
+	return cache.GetOrLoad(context.Background(), u.cache, DistrictCacheEntity, []interface{}{cityID}, func() ([]dto.DistrictLookup, error) {
+		districts, err := u.repo.GetDistrictsByCity(cityID)
+		if err != nil {
+			return nil, err
 		}
-	}
-
-	districts, err := u.repo.GetDistrictsByCity(cityID)
-	if err != nil {
-		return nil, err
-	}
 
-	resp := make([]dto.DistrictLookup, 0, len(districts))
-	for _, d := range districts {
-		resp = append(resp, dto.DistrictLookup{
-			ID:     d.ID,
-			Name:   d.Name,
-			CityID: d.CityID,
-		})
-	}
-
-	if data, err := json.Marshal(resp); err == nil {
-		_ = u.redis.Set(ctx, cacheKey, data, 0).Err()
-	}
-
-	return resp, nil
+		resp := make([]dto.DistrictLookup, 0, len(districts))
+		for _, d := range districts {
+			resp = append(resp, dto.DistrictLookup{
+				ID:     d.ID,
+				Name:   d.Name,
+				CityID: d.CityID,
+			})
+		}
+		return resp, nil
+	})
 }
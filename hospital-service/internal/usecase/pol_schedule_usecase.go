@@ -0,0 +1,284 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"hospital-service/internal/dto"
+	"hospital-service/internal/models"
+)
+
+const (
+	clockLayout = "15:04"
+	dateLayout  = "2006-01-02"
+)
+
+func (u *polyclinicUsecase) UpdateCapacity(hospitalPolyclinicID, hospitalID uint, req *dto.UpdateCapacityRequest) error {
+	hp, err := u.ownedHospitalPolyclinic(hospitalPolyclinicID, hospitalID)
+	if err != nil {
+		return err
+	}
+	if req.DailyCapacity < 0 {
+		return errors.New("daily capacity cannot be negative")
+	}
+
+	hp.DailyCapacity = req.DailyCapacity
+	return u.repo.UpdateHospitalPolyclinic(hp)
+}
+
+func (u *polyclinicUsecase) UpdateOpeningHours(hospitalPolyclinicID, hospitalID uint, req *dto.UpdateOpeningHoursRequest) ([]dto.OpeningHour, error) {
+	if _, err := u.ownedHospitalPolyclinic(hospitalPolyclinicID, hospitalID); err != nil {
+		return nil, err
+	}
+	if err := validateOpeningHours(req.Hours); err != nil {
+		return nil, err
+	}
+
+	hours := make([]models.PolyclinicOpeningHour, 0, len(req.Hours))
+	for _, h := range req.Hours {
+		hours = append(hours, models.PolyclinicOpeningHour{
+			HospitalPolyclinicID: hospitalPolyclinicID,
+			Weekday:              time.Weekday(h.Weekday),
+			OpensAt:              h.OpensAt,
+			ClosesAt:             h.ClosesAt,
+		})
+	}
+	sort.Slice(hours, func(i, j int) bool {
+		if hours[i].Weekday != hours[j].Weekday {
+			return hours[i].Weekday < hours[j].Weekday
+		}
+		return hours[i].OpensAt < hours[j].OpensAt
+	})
+
+	if err := u.repo.ReplaceOpeningHours(hospitalPolyclinicID, hours); err != nil {
+		return nil, err
+	}
+	return toOpeningHours(hours), nil
+}
+
+func (u *polyclinicUsecase) AddHoliday(hospitalPolyclinicID, hospitalID uint, req *dto.HolidayRequest) (*dto.HolidayResponse, error) {
+	if _, err := u.ownedHospitalPolyclinic(hospitalPolyclinicID, hospitalID); err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse(dateLayout, req.Date)
+	if err != nil {
+		return nil, errors.New("date must be in YYYY-MM-DD format")
+	}
+	if !req.Closed {
+		if err := validateInterval(req.OpensAt, req.ClosesAt); err != nil {
+			return nil, err
+		}
+	}
+	taken, err := u.repo.IsHolidayDateTaken(hospitalPolyclinicID, date)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, errors.New("an exception already exists for this date")
+	}
+
+	holiday := &models.PolyclinicHoliday{
+		HospitalPolyclinicID: hospitalPolyclinicID,
+		Date:                 date,
+		Closed:               req.Closed,
+		Description:          strings.TrimSpace(req.Description),
+	}
+	if !req.Closed {
+		holiday.OpensAt = req.OpensAt
+		holiday.ClosesAt = req.ClosesAt
+	}
+	if err := u.repo.CreateHoliday(holiday); err != nil {
+		return nil, err
+	}
+	return toHolidayResponse(holiday), nil
+}
+
+func (u *polyclinicUsecase) DeleteHoliday(hospitalPolyclinicID, holidayID, hospitalID uint) error {
+	if _, err := u.ownedHospitalPolyclinic(hospitalPolyclinicID, hospitalID); err != nil {
+		return err
+	}
+	holiday, err := u.repo.GetHolidayByID(holidayID)
+	if err != nil {
+		return err
+	}
+	if holiday.HospitalPolyclinicID != hospitalPolyclinicID {
+		return errors.New("holiday not found")
+	}
+	return u.repo.DeleteHoliday(holiday)
+}
+
+func (u *polyclinicUsecase) AddExamRoom(hospitalPolyclinicID, hospitalID uint, req *dto.ExamRoomRequest) (*dto.ExamRoomResponse, error) {
+	if _, err := u.ownedHospitalPolyclinic(hospitalPolyclinicID, hospitalID); err != nil {
+		return nil, err
+	}
+	floor, number, err := u.validateExamRoom(hospitalID, 0, req)
+	if err != nil {
+		return nil, err
+	}
+
+	room := &models.ExamRoom{
+		HospitalID:           hospitalID,
+		HospitalPolyclinicID: hospitalPolyclinicID,
+		Floor:                floor,
+		Number:               number,
+		Name:                 strings.TrimSpace(req.Name),
+	}
+	if err := u.repo.CreateExamRoom(room); err != nil {
+		return nil, err
+	}
+	return toExamRoomResponse(room), nil
+}
+
+func (u *polyclinicUsecase) UpdateExamRoom(hospitalPolyclinicID, roomID, hospitalID uint, req *dto.ExamRoomRequest) (*dto.ExamRoomResponse, error) {
+	room, err := u.ownedExamRoom(hospitalPolyclinicID, roomID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	floor, number, err := u.validateExamRoom(hospitalID, roomID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	room.Floor = floor
+	room.Number = number
+	room.Name = strings.TrimSpace(req.Name)
+	if err := u.repo.UpdateExamRoom(room); err != nil {
+		return nil, err
+	}
+	return toExamRoomResponse(room), nil
+}
+
+func (u *polyclinicUsecase) DeleteExamRoom(hospitalPolyclinicID, roomID, hospitalID uint) error {
+	room, err := u.ownedExamRoom(hospitalPolyclinicID, roomID, hospitalID)
+	if err != nil {
+		return err
+	}
+	return u.repo.DeleteExamRoom(room)
+}
+
+func (u *polyclinicUsecase) ownedExamRoom(hospitalPolyclinicID, roomID, hospitalID uint) (*models.ExamRoom, error) {
+	if _, err := u.ownedHospitalPolyclinic(hospitalPolyclinicID, hospitalID); err != nil {
+		return nil, err
+	}
+	room, err := u.repo.GetExamRoomByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.HospitalPolyclinicID != hospitalPolyclinicID {
+		return nil, errors.New("exam room not found")
+	}
+	return room, nil
+}
+
+// Aynı kat ve numaradaki oda hastane içinde iki polikliniğe verilemez
+func (u *polyclinicUsecase) validateExamRoom(hospitalID, excludeID uint, req *dto.ExamRoomRequest) (string, string, error) {
+	floor := strings.TrimSpace(req.Floor)
+	number := strings.TrimSpace(req.Number)
+	if floor == "" || number == "" {
+		return "", "", errors.New("floor and number are required")
+	}
+	taken, err := u.repo.IsExamRoomTaken(hospitalID, excludeID, floor, number)
+	if err != nil {
+		return "", "", err
+	}
+	if taken {
+		return "", "", errors.New("exam room with given floor and number already exists in this hospital")
+	}
+	return floor, number, nil
+}
+
+// Aynı gün için aralıklar çakışmamalı
+func validateOpeningHours(hours []dto.OpeningHour) error {
+	byDay := make(map[int][]dto.OpeningHour)
+	for _, h := range hours {
+		if h.Weekday < 0 || h.Weekday > 6 {
+			return errors.New("weekday must be between 0 (Sunday) and 6 (Saturday)")
+		}
+		if err := validateInterval(h.OpensAt, h.ClosesAt); err != nil {
+			return err
+		}
+		byDay[h.Weekday] = append(byDay[h.Weekday], h)
+	}
+
+	for day, intervals := range byDay {
+		sort.Slice(intervals, func(i, j int) bool { return intervals[i].OpensAt < intervals[j].OpensAt })
+		for i := 1; i < len(intervals); i++ {
+			if intervals[i].OpensAt < intervals[i-1].ClosesAt {
+				return fmt.Errorf("opening hours overlap on weekday %d", day)
+			}
+		}
+	}
+	return nil
+}
+
+func validateInterval(opensAt, closesAt string) error {
+	opens, err := time.Parse(clockLayout, opensAt)
+	if err != nil {
+		return errors.New("opens_at must be in HH:MM format")
+	}
+	closes, err := time.Parse(clockLayout, closesAt)
+	if err != nil {
+		return errors.New("closes_at must be in HH:MM format")
+	}
+	if !opens.Before(closes) {
+		return errors.New("opens_at must be before closes_at")
+	}
+	return nil
+}
+
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func toOpeningHours(hours []models.PolyclinicOpeningHour) []dto.OpeningHour {
+	resp := make([]dto.OpeningHour, 0, len(hours))
+	for _, h := range hours {
+		resp = append(resp, dto.OpeningHour{
+			Weekday:  int(h.Weekday),
+			OpensAt:  h.OpensAt,
+			ClosesAt: h.ClosesAt,
+		})
+	}
+	return resp
+}
+
+func toHolidayResponse(h *models.PolyclinicHoliday) *dto.HolidayResponse {
+	return &dto.HolidayResponse{
+		ID:          h.ID,
+		Date:        h.Date.Format(dateLayout),
+		Closed:      h.Closed,
+		OpensAt:     h.OpensAt,
+		ClosesAt:    h.ClosesAt,
+		Description: h.Description,
+	}
+}
+
+func toHolidayResponses(holidays []models.PolyclinicHoliday) []dto.HolidayResponse {
+	resp := make([]dto.HolidayResponse, 0, len(holidays))
+	for i := range holidays {
+		resp = append(resp, *toHolidayResponse(&holidays[i]))
+	}
+	return resp
+}
+
+func toExamRoomResponse(room *models.ExamRoom) *dto.ExamRoomResponse {
+	return &dto.ExamRoomResponse{
+		ID:     room.ID,
+		Floor:  room.Floor,
+		Number: room.Number,
+		Name:   room.Name,
+	}
+}
+
+func toExamRoomResponses(rooms []models.ExamRoom) []dto.ExamRoomResponse {
+	resp := make([]dto.ExamRoomResponse, 0, len(rooms))
+	for i := range rooms {
+		resp = append(resp, *toExamRoomResponse(&rooms[i]))
+	}
+	return resp
+}
@@ -1,50 +1,163 @@
 package usecase
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"hospital-service/internal/dto"
 	"hospital-service/internal/infrastructure/client"
 	"hospital-service/internal/models"
 	"hospital-service/internal/repository"
+	"hospital-shared/cache"
 	dt "hospital-shared/dto"
 )
 
+// Toplu poliklinik sorgusunda kabul edilen en fazla kimlik sayısı
+const maxHospitalPolyclinicBatchSize = 200
+
+// ErrHospitalPolyclinicHasStaff personel atanmış poliklinik reassign_to veya force olmadan kaldırılamaz
+var ErrHospitalPolyclinicHasStaff = errors.New("hospital polyclinic has assigned staff; use reassign_to or force")
+
+// ErrStaffCountUnavailable personnel servisi cevap vermediğinde kaldırma güvenle yapılamaz
+var ErrStaffCountUnavailable = errors.New("assigned staff could not be counted, try again later")
+
+// ErrInvalidHospitalPolyclinicFilter liste filtresi geçersiz olduğunda döner; ayrıntı mesajdadır
+var ErrInvalidHospitalPolyclinicFilter = errors.New("invalid hospital polyclinic filter")
+
 type PolyclinicUsecase interface {
 	ListAllPolyclinics() ([]dto.PolyclinicLookup, error)
 	AddPolyclinicToHospital(req *dto.AddHospitalPolyclinicRequest, hospitalID uint) (*dto.HospitalPolyclinicResponse, error)
-	ListHospitalPolyclinic(hospitalID uint, page, size int) (*dto.HospitalPolyclinicListResponse, error)
-	RemoveHospitalPolyclinic(id uint, hospitalID uint) error
+	ListHospitalPolyclinic(hospitalID uint, filter dto.HospitalPolyclinicListFilter, page, size int) (*dto.HospitalPolyclinicListResponse, error)
+	RemoveHospitalPolyclinic(id uint, hospitalID uint, opts dto.RemoveHospitalPolyclinicOptions) error
 
 	GetHospitalPolyclinic(id uint) (*dt.HospitalPolyclinicResponseDTO, error)
+	ListHospitalPolyclinicRefs(hospitalID uint) ([]dt.HospitalPolyclinicResponseDTO, error)
+	GetHospitalPolyclinicsBatch(hospitalID uint, ids []uint) ([]dt.HospitalPolyclinicResponseDTO, error)
+
+	CreatePolyclinic(req *dto.PolyclinicRequest) (*dto.PolyclinicLookup, error)
+	UpdatePolyclinic(id uint, req *dto.PolyclinicRequest) (*dto.PolyclinicLookup, error)
+	DeletePolyclinic(id uint) error
+
+	ListUnits(hospitalPolyclinicID, hospitalID uint) ([]dto.HospitalPolyclinicUnitResponse, error)
+	AddUnit(hospitalPolyclinicID, hospitalID uint, req *dto.HospitalPolyclinicUnitRequest) (*dto.HospitalPolyclinicUnitResponse, error)
+	UpdateUnit(hospitalPolyclinicID, unitID, hospitalID uint, req *dto.HospitalPolyclinicUnitRequest) (*dto.HospitalPolyclinicUnitResponse, error)
+	DeleteUnit(hospitalPolyclinicID, unitID, hospitalID uint) error
+
+	UpdateCapacity(hospitalPolyclinicID, hospitalID uint, req *dto.UpdateCapacityRequest) error
+	UpdateOpeningHours(hospitalPolyclinicID, hospitalID uint, req *dto.UpdateOpeningHoursRequest) ([]dto.OpeningHour, error)
+	AddHoliday(hospitalPolyclinicID, hospitalID uint, req *dto.HolidayRequest) (*dto.HolidayResponse, error)
+	DeleteHoliday(hospitalPolyclinicID, holidayID, hospitalID uint) error
+	AddExamRoom(hospitalPolyclinicID, hospitalID uint, req *dto.ExamRoomRequest) (*dto.ExamRoomResponse, error)
+	UpdateExamRoom(hospitalPolyclinicID, roomID, hospitalID uint, req *dto.ExamRoomRequest) (*dto.ExamRoomResponse, error)
+	DeleteExamRoom(hospitalPolyclinicID, roomID, hospitalID uint) error
 }
 
+// Katalog yalnızca platform yöneticisi tarafından değiştirilir, her yazmada silinir
+var PolyclinicCacheEntity = cache.Entity{Name: "polyclinics", Version: 1, TTL: 6 * time.Hour}
+
 type polyclinicUsecase struct {
 	repo            repository.PolyclinicRepository
+	staffStats      repository.StaffStatsRepository
 	personnelClient client.PersonnelClient
+	cache           *cache.Cache
 }
 
-func NewPolyclinicUsecase(r repository.PolyclinicRepository, pc client.PersonnelClient) PolyclinicUsecase {
+func NewPolyclinicUsecase(r repository.PolyclinicRepository, s repository.StaffStatsRepository, pc client.PersonnelClient, c *cache.Cache) PolyclinicUsecase {
 	return &polyclinicUsecase{
 		repo:            r,
+		staffStats:      s,
 		personnelClient: pc,
+		cache:           c,
 	}
 }
 
 func (u *polyclinicUsecase) ListAllPolyclinics() ([]dto.PolyclinicLookup, error) {
-	polys, err := u.repo.GetAllPolyclinic()
+	return cache.GetOrLoad(context.Background(), u.cache, PolyclinicCacheEntity, nil, func() ([]dto.PolyclinicLookup, error) {
+		polys, err := u.repo.GetAllPolyclinic()
+		if err != nil {
+			return nil, err
+		}
+
+		resp := make([]dto.PolyclinicLookup, 0, len(polys))
+		for i := range polys {
+			resp = append(resp, *toPolyclinicLookup(&polys[i]))
+		}
+		return resp, nil
+	})
+}
+
+func (u *polyclinicUsecase) CreatePolyclinic(req *dto.PolyclinicRequest) (*dto.PolyclinicLookup, error) {
+	name, code, err := normalizePolyclinicRequest(req)
 	if err != nil {
 		return nil, err
 	}
+	if taken, err := u.repo.IsPolyclinicNameOrCodeTaken(0, name, code); err != nil {
+		return nil, err
+	} else if taken {
+		return nil, errors.New("polyclinic with given name or code already exists")
+	}
 
-	resp := make([]dto.PolyclinicLookup, 0, len(polys))
-	for _, p := range polys {
-		resp = append(resp, dto.PolyclinicLookup{
-			ID:   p.ID,
-			Name: p.Name,
-		})
+	poly := &models.Polyclinic{Name: name, Code: code}
+	if err := u.repo.CreatePolyclinic(poly); err != nil {
+		return nil, err
 	}
-	return resp, nil
+
+	u.invalidateCatalog()
+	return toPolyclinicLookup(poly), nil
+}
+
+func (u *polyclinicUsecase) UpdatePolyclinic(id uint, req *dto.PolyclinicRequest) (*dto.PolyclinicLookup, error) {
+	poly, err := u.repo.GetPolyclinicByID(id)
+	if err != nil {
+		return nil, err
+	}
+	name, code, err := normalizePolyclinicRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if taken, err := u.repo.IsPolyclinicNameOrCodeTaken(id, name, code); err != nil {
+		return nil, err
+	} else if taken {
+		return nil, errors.New("polyclinic with given name or code already exists")
+	}
+
+	poly.Name = name
+	poly.Code = code
+	if err := u.repo.UpdatePolyclinic(poly); err != nil {
+		return nil, err
+	}
+
+	u.invalidateCatalog()
+	return toPolyclinicLookup(poly), nil
+}
+
+// Bir hastanede kullanılan poliklinik katalogdan silinemez
+func (u *polyclinicUsecase) DeletePolyclinic(id uint) error {
+	poly, err := u.repo.GetPolyclinicByID(id)
+	if err != nil {
+		return err
+	}
+	used, err := u.repo.CountHospitalPolyclinicsByPolyclinicID(id)
+	if err != nil {
+		return err
+	}
+	if used > 0 {
+		return fmt.Errorf("polyclinic is used by %d hospitals", used)
+	}
+
+	if err := u.repo.DeletePolyclinic(poly); err != nil {
+		return err
+	}
+
+	u.invalidateCatalog()
+	return nil
+}
+
+func (u *polyclinicUsecase) invalidateCatalog() {
+	_ = u.cache.Invalidate(context.Background(), PolyclinicCacheEntity)
 }
 
 func (u *polyclinicUsecase) AddPolyclinicToHospital(req *dto.AddHospitalPolyclinicRequest, hospitalID uint) (*dto.HospitalPolyclinicResponse, error) {
@@ -79,57 +192,79 @@ func (u *polyclinicUsecase) AddPolyclinicToHospital(req *dto.AddHospitalPolyclin
 
 }
 
-func (u *polyclinicUsecase) ListHospitalPolyclinic(hospitalID uint, page, size int) (*dto.HospitalPolyclinicListResponse, error) {
+func (u *polyclinicUsecase) ListHospitalPolyclinic(hospitalID uint, filter dto.HospitalPolyclinicListFilter, page, size int) (*dto.HospitalPolyclinicListResponse, error) {
 	if page < 1 {
 		page = 1
 	}
 	if size < 1 {
 		size = 10
 	}
+	if err := validateHospitalPolyclinicListFilter(&filter); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidHospitalPolyclinicFilter, err)
+	}
 
-	total, err := u.repo.CountByHospitalID(hospitalID)
+	total, err := u.repo.CountByHospitalID(hospitalID, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	hps, err := u.repo.GetPaginatedByHospitalID(hospitalID, page, size)
+	hps, err := u.repo.GetPaginatedByHospitalID(hospitalID, filter, page, size)
 	if err != nil {
 		return nil, err
 	}
 
-	result := make([]dto.HospitalPolyclinicDetail, 0, len(hps))
+	hpIDs := make([]uint, 0, len(hps))
 	for _, hp := range hps {
-		poly, err := u.repo.GetPolyclinicByID(hp.PolyclinicID)
-		if err != nil {
-			return nil, err
-		}
-
-		totalPersonnel, err := u.personnelClient.GetPersonnelCount(hp.ID)
-		if err != nil {
-			// Hata yönetimi → istersen logla ve sıfır dön
-			totalPersonnel = 0
-		}
-
-		groupCounts, err := u.personnelClient.GetPersonnelGroups(hp.ID)
-		if err != nil {
-			groupCounts = []dt.PolyclinicPersonnelGroup{}
-		}
+		hpIDs = append(hpIDs, hp.ID)
+	}
+	units, err := u.repo.GetUnitsByHospitalPolyclinicIDs(hpIDs)
+	if err != nil {
+		return nil, err
+	}
+	hours, err := u.repo.GetOpeningHoursByHospitalPolyclinicIDs(hpIDs)
+	if err != nil {
+		return nil, err
+	}
+	// Geçmiş tatiller listelenmez
+	holidays, err := u.repo.GetHolidaysByHospitalPolyclinicIDs(hpIDs, today())
+	if err != nil {
+		return nil, err
+	}
+	rooms, err := u.repo.GetExamRoomsByHospitalPolyclinicIDs(hpIDs)
+	if err != nil {
+		return nil, err
+	}
+	// Personel sayıları personnel olaylarıyla güncellenen yerel tablodan okunur
+	groups, err := u.staffStats.GetGroupsByHospitalPolyclinicIDs(hpIDs)
+	if err != nil {
+		return nil, err
+	}
 
-		personnelGroups := make([]dto.PolyclinicPersonnelGroup, 0, len(groupCounts))
-		for _, g := range groupCounts {
+	result := make([]dto.HospitalPolyclinicDetail, 0, len(hps))
+	for _, hp := range hps {
+		personnelGroups := make([]dto.PolyclinicPersonnelGroup, 0, len(groups[hp.ID]))
+		for _, g := range groups[hp.ID] {
 			personnelGroups = append(personnelGroups, dto.PolyclinicPersonnelGroup{
-				GroupName: g.GroupName,
+				GroupName: g.JobGroupName,
 				Count:     g.Count,
 			})
 		}
 
-		result = append(result, dto.HospitalPolyclinicDetail{
+		detail := dto.HospitalPolyclinicDetail{
 			ID:              hp.ID,
-			PolyclinicName:  poly.Name,
-			TotalPersonnel:  int(totalPersonnel),
+			PolyclinicName:  hp.PolyclinicName,
+			Units:           toUnitResponses(units[hp.ID]),
+			DailyCapacity:   hp.DailyCapacity,
+			OpeningHours:    toOpeningHours(hours[hp.ID]),
+			Holidays:        toHolidayResponses(holidays[hp.ID]),
+			Rooms:           toExamRoomResponses(rooms[hp.ID]),
+			TotalPersonnel:  hp.TotalPersonnel,
 			PersonnelGroups: personnelGroups,
-		})
-
+		}
+		if hp.PolyclinicCode != nil {
+			detail.PolyclinicCode = *hp.PolyclinicCode
+		}
+		result = append(result, detail)
 	}
 
 	return &dto.HospitalPolyclinicListResponse{
@@ -140,7 +275,29 @@ func (u *polyclinicUsecase) ListHospitalPolyclinic(hospitalID uint, page, size i
 	}, nil
 }
 
-func (u *polyclinicUsecase) RemoveHospitalPolyclinic(id uint, hospitalID uint) error {
+func validateHospitalPolyclinicListFilter(f *dto.HospitalPolyclinicListFilter) error {
+	switch f.Sort {
+	case "", dto.SortByName, dto.SortByTotalPersonnel:
+	default:
+		return errors.New("sort must be one of: name, total_personnel")
+	}
+	switch f.Order {
+	case "":
+		f.Order = "asc"
+		if f.Sort == dto.SortByTotalPersonnel {
+			f.Order = "desc"
+		}
+	case "asc", "desc":
+	default:
+		return errors.New("order must be asc or desc")
+	}
+	if f.MinPersonnel != nil && f.MaxPersonnel != nil && *f.MinPersonnel > *f.MaxPersonnel {
+		return errors.New("min_personnel cannot be greater than max_personnel")
+	}
+	return nil
+}
+
+func (u *polyclinicUsecase) RemoveHospitalPolyclinic(id uint, hospitalID uint, opts dto.RemoveHospitalPolyclinicOptions) error {
 	hp, err := u.repo.GetHospitalPolyclinicByID(id)
 	if err != nil {
 		return errors.New("hospital polyclinic not found")
@@ -149,8 +306,31 @@ func (u *polyclinicUsecase) RemoveHospitalPolyclinic(id uint, hospitalID uint) e
 	if hp.HospitalID != hospitalID {
 		return errors.New("forbidden: cannot remove polyclinic from another hospital")
 	}
-	return u.repo.Delete(hp)
+	if opts.ReassignTo != nil && opts.Force {
+		return errors.New("reassign_to and force cannot be used together")
+	}
+	if opts.ReassignTo != nil {
+		if *opts.ReassignTo == id {
+			return errors.New("cannot reassign staff to the polyclinic being removed")
+		}
+		if _, err := u.ownedHospitalPolyclinic(*opts.ReassignTo, hospitalID); err != nil {
+			return fmt.Errorf("reassign target: %w", err)
+		}
+	}
 
+	// Yerel projeksiyon geride kalmış olabileceğinden atanmış personel personnel servisine sorulur;
+	// sayı alınamazsa personel olup olmadığı bilinmediği için kaldırma reddedilir. Taşıma ya da
+	// atamanın kaldırılması olay üzerinden personnel servisinde yapılır.
+	if opts.ReassignTo == nil && !opts.Force {
+		assigned, err := u.personnelClient.GetPersonnelCount(id, true)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrStaffCountUnavailable, err)
+		}
+		if assigned > 0 {
+			return fmt.Errorf("%w (%d assigned)", ErrHospitalPolyclinicHasStaff, assigned)
+		}
+	}
+	return u.repo.Delete(hp, opts.ReassignTo)
 }
 
 func (u *polyclinicUsecase) GetHospitalPolyclinic(id uint) (*dt.HospitalPolyclinicResponseDTO, error) {
@@ -171,3 +351,180 @@ func (u *polyclinicUsecase) GetHospitalPolyclinic(id uint) (*dt.HospitalPolyclin
 		PolyclinicName: poly.Name,
 	}, nil
 }
+
+// ListHospitalPolyclinicRefs personel servisinin poliklinikleri adla eşleştirmesi için kullanılır
+func (u *polyclinicUsecase) ListHospitalPolyclinicRefs(hospitalID uint) ([]dt.HospitalPolyclinicResponseDTO, error) {
+	refs, err := u.repo.ListHospitalPolyclinicRefs(hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	return toHospitalPolyclinicRefs(refs), nil
+}
+
+// GetHospitalPolyclinicsBatch personel listesindeki poliklinik adlarını tek istekte çözer
+func (u *polyclinicUsecase) GetHospitalPolyclinicsBatch(hospitalID uint, ids []uint) ([]dt.HospitalPolyclinicResponseDTO, error) {
+	if hospitalID == 0 {
+		return nil, errors.New("hospital_id is required")
+	}
+	if len(ids) > maxHospitalPolyclinicBatchSize {
+		return nil, fmt.Errorf("at most %d hospital polyclinics can be requested at once", maxHospitalPolyclinicBatchSize)
+	}
+	refs, err := u.repo.GetHospitalPolyclinicRefsByIDs(hospitalID, ids)
+	if err != nil {
+		return nil, err
+	}
+	return toHospitalPolyclinicRefs(refs), nil
+}
+
+func toHospitalPolyclinicRefs(refs []repository.HospitalPolyclinicRef) []dt.HospitalPolyclinicResponseDTO {
+	resp := make([]dt.HospitalPolyclinicResponseDTO, 0, len(refs))
+	for _, r := range refs {
+		resp = append(resp, dt.HospitalPolyclinicResponseDTO{
+			ID:             r.ID,
+			HospitalID:     r.HospitalID,
+			PolyclinicID:   r.PolyclinicID,
+			PolyclinicName: r.PolyclinicName,
+		})
+	}
+	return resp
+}
+
+func (u *polyclinicUsecase) ListUnits(hospitalPolyclinicID, hospitalID uint) ([]dto.HospitalPolyclinicUnitResponse, error) {
+	if _, err := u.ownedHospitalPolyclinic(hospitalPolyclinicID, hospitalID); err != nil {
+		return nil, err
+	}
+	units, err := u.repo.GetUnitsByHospitalPolyclinicIDs([]uint{hospitalPolyclinicID})
+	if err != nil {
+		return nil, err
+	}
+	return toUnitResponses(units[hospitalPolyclinicID]), nil
+}
+
+func (u *polyclinicUsecase) AddUnit(hospitalPolyclinicID, hospitalID uint, req *dto.HospitalPolyclinicUnitRequest) (*dto.HospitalPolyclinicUnitResponse, error) {
+	if _, err := u.ownedHospitalPolyclinic(hospitalPolyclinicID, hospitalID); err != nil {
+		return nil, err
+	}
+	name, code, err := u.validateUnit(hospitalPolyclinicID, 0, req)
+	if err != nil {
+		return nil, err
+	}
+
+	unit := &models.HospitalPolyclinicUnit{
+		HospitalPolyclinicID: hospitalPolyclinicID,
+		Name:                 name,
+		Code:                 code,
+	}
+	if err := u.repo.CreateUnit(unit); err != nil {
+		return nil, err
+	}
+	return toUnitResponse(unit), nil
+}
+
+func (u *polyclinicUsecase) UpdateUnit(hospitalPolyclinicID, unitID, hospitalID uint, req *dto.HospitalPolyclinicUnitRequest) (*dto.HospitalPolyclinicUnitResponse, error) {
+	unit, err := u.ownedUnit(hospitalPolyclinicID, unitID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	name, code, err := u.validateUnit(hospitalPolyclinicID, unitID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	unit.Name = name
+	unit.Code = code
+	if err := u.repo.UpdateUnit(unit); err != nil {
+		return nil, err
+	}
+	return toUnitResponse(unit), nil
+}
+
+func (u *polyclinicUsecase) DeleteUnit(hospitalPolyclinicID, unitID, hospitalID uint) error {
+	unit, err := u.ownedUnit(hospitalPolyclinicID, unitID, hospitalID)
+	if err != nil {
+		return err
+	}
+	return u.repo.DeleteUnit(unit)
+}
+
+func (u *polyclinicUsecase) ownedHospitalPolyclinic(id, hospitalID uint) (*models.HospitalPolyclinic, error) {
+	hp, err := u.repo.GetHospitalPolyclinicByID(id)
+	if err != nil {
+		return nil, errors.New("hospital polyclinic not found")
+	}
+	if hp.HospitalID != hospitalID {
+		return nil, errors.New("forbidden: hospital polyclinic belongs to another hospital")
+	}
+	return hp, nil
+}
+
+func (u *polyclinicUsecase) ownedUnit(hospitalPolyclinicID, unitID, hospitalID uint) (*models.HospitalPolyclinicUnit, error) {
+	if _, err := u.ownedHospitalPolyclinic(hospitalPolyclinicID, hospitalID); err != nil {
+		return nil, err
+	}
+	unit, err := u.repo.GetUnitByID(unitID)
+	if err != nil {
+		return nil, err
+	}
+	if unit.HospitalPolyclinicID != hospitalPolyclinicID {
+		return nil, errors.New("unit not found")
+	}
+	return unit, nil
+}
+
+func (u *polyclinicUsecase) validateUnit(hospitalPolyclinicID, excludeID uint, req *dto.HospitalPolyclinicUnitRequest) (string, string, error) {
+	name := strings.TrimSpace(req.Name)
+	code := strings.TrimSpace(req.Code)
+	if name == "" {
+		return "", "", errors.New("unit name is required")
+	}
+	if code != "" {
+		taken, err := u.repo.IsUnitCodeTaken(hospitalPolyclinicID, excludeID, code)
+		if err != nil {
+			return "", "", err
+		}
+		if taken {
+			return "", "", errors.New("unit code already exists in this polyclinic")
+		}
+	}
+	return name, code, nil
+}
+
+func normalizePolyclinicRequest(req *dto.PolyclinicRequest) (string, *string, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return "", nil, errors.New("polyclinic name is required")
+	}
+	var code *string
+	if c := strings.TrimSpace(req.Code); c != "" {
+		code = &c
+	}
+	return name, code, nil
+}
+
+func toPolyclinicLookup(p *models.Polyclinic) *dto.PolyclinicLookup {
+	resp := &dto.PolyclinicLookup{
+		ID:   p.ID,
+		Name: p.Name,
+	}
+	if p.Code != nil {
+		resp.Code = *p.Code
+	}
+	return resp
+}
+
+func toUnitResponse(unit *models.HospitalPolyclinicUnit) *dto.HospitalPolyclinicUnitResponse {
+	return &dto.HospitalPolyclinicUnitResponse{
+		ID:                   unit.ID,
+		HospitalPolyclinicID: unit.HospitalPolyclinicID,
+		Name:                 unit.Name,
+		Code:                 unit.Code,
+	}
+}
+
+func toUnitResponses(units []models.HospitalPolyclinicUnit) []dto.HospitalPolyclinicUnitResponse {
+	resp := make([]dto.HospitalPolyclinicUnitResponse, 0, len(units))
+	for i := range units {
+		resp = append(resp, *toUnitResponse(&units[i]))
+	}
+	return resp
+}
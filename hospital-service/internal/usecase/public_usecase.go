@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"hospital-service/internal/dto"
+	"hospital-service/internal/models"
+	"hospital-service/internal/repository"
+)
+
+// Herkese açık listelemede tek sayfada dönebilecek en fazla kayıt
+const maxPublicPageSize = 50
+
+type PublicUsecase interface {
+	SearchHospitals(filter dto.HospitalListFilter, page, size int) (*dto.PublicHospitalListResponse, error)
+}
+
+type publicUsecase struct {
+	hospitalRepo   repository.HospitalRepository
+	polyclinicRepo repository.PolyclinicRepository
+}
+
+func NewPublicUsecase(hospitalRepo repository.HospitalRepository, polyclinicRepo repository.PolyclinicRepository) PublicUsecase {
+	return &publicUsecase{
+		hospitalRepo:   hospitalRepo,
+		polyclinicRepo: polyclinicRepo,
+	}
+}
+
+// SearchHospitals yalnızca aktif hastaneleri döner
+func (u *publicUsecase) SearchHospitals(filter dto.HospitalListFilter, page, size int) (*dto.PublicHospitalListResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = 10
+	}
+	if size > maxPublicPageSize {
+		size = maxPublicPageSize
+	}
+
+	filter.Status = models.HospitalStatusActive
+	filter.TaxNumber = ""
+	filter.RegisteredFrom = nil
+	filter.RegisteredTo = nil
+	filter.DeletionFlagged = false
+	filter.ExcludeDeletionFlagged = true
+
+	total, err := u.hospitalRepo.CountHospitals(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	hospitals, err := u.hospitalRepo.ListHospitals(filter, page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(hospitals))
+	for _, h := range hospitals {
+		ids = append(ids, h.ID)
+	}
+	polyclinics, err := u.polyclinicRepo.GetPolyclinicNamesByHospitalIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.PublicHospitalResponse, 0, len(hospitals))
+	for _, h := range hospitals {
+		names := polyclinics[h.ID]
+		if names == nil {
+			names = []string{}
+		}
+		resp = append(resp, dto.PublicHospitalResponse{
+			ID:           h.ID,
+			Name:         h.Name,
+			Phone:        h.Phone,
+			Address:      h.Address,
+			CityID:       h.CityID,
+			CityName:     h.City.Name,
+			DistrictID:   h.DistrictID,
+			DistrictName: h.District.Name,
+			Latitude:     h.Latitude,
+			Longitude:    h.Longitude,
+			Polyclinics:  names,
+		})
+	}
+
+	return &dto.PublicHospitalListResponse{
+		Hospitals: resp,
+		Total:     int(total),
+		Page:      page,
+		Size:      size,
+	}, nil
+}
@@ -0,0 +1,209 @@
+// Package cache servisler arasında ortak cache-aside yardımcılarını içerir.
+//
+// Anahtarlar "<servis>:<varlık>:v<sürüm>:<parçalar>" biçimindedir. Bir varlığın
+// JSON yapısı değiştiğinde Version artırılır; eski anahtarlar TTL dolunca düşer.
+// Redis erişilemezken değerler süreç içi LRU önbellekte tutulur.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"hospital-shared/metrics"
+
+	"github.com/go-redis/redis/v8"
+	"golang.org/x/sync/singleflight"
+)
+
+// Entity önbelleğe alınan bir veri türünü tanımlar
+type Entity struct {
+	Name    string
+	Version int
+	TTL     time.Duration
+}
+
+type Cache struct {
+	monitor *Monitor
+	local   *localLRU
+	service string
+	group   singleflight.Group
+
+	// Redis kapalıyken yapılan silmeler bağlantı gelince tekrarlanır
+	mu      sync.Mutex
+	pending map[string]Entity
+}
+
+func New(monitor *Monitor, service string) *Cache {
+	c := &Cache{
+		monitor: monitor,
+		local:   newLocalLRU(defaultLocalCapacity),
+		service: service,
+		pending: make(map[string]Entity),
+	}
+	monitor.OnReconnect(c.replayInvalidations)
+	return c
+}
+
+// Key varlık ve parçalardan sürümlü anahtar üretir
+func (c *Cache) Key(e Entity, parts ...interface{}) string {
+	key := c.entityPrefix(e)
+	for _, p := range parts {
+		key += ":" + fmt.Sprint(p)
+	}
+	return key
+}
+
+func (c *Cache) entityPrefix(e Entity) string {
+	return fmt.Sprintf("%s:%s:v%d", c.service, e.Name, e.Version)
+}
+
+// GetOrLoad değeri önbellekten okur, yoksa load ile yükleyip TTL ile yazar.
+// Aynı anahtar için eşzamanlı yüklemeler tek bir load çağrısında birleşir.
+// Redis hataları akışı bozmaz; değer süreç içi önbellekten ya da kaynaktan gelir.
+func GetOrLoad[T any](ctx context.Context, c *Cache, e Entity, parts []interface{}, load func() (T, error)) (T, error) {
+	key := c.Key(e, parts...)
+
+	if value, ok := get[T](ctx, c, e, key); ok {
+		return value, nil
+	}
+
+	v, err, _ := c.group.Do(key, func() (interface{}, error) {
+		// Bekleyen başka bir çağrı değeri yazmış olabilir
+		if value, ok := get[T](ctx, c, e, key); ok {
+			return value, nil
+		}
+
+		value, err := load()
+		if err != nil {
+			return value, err
+		}
+		if data, err := json.Marshal(value); err == nil {
+			c.set(ctx, e, key, data)
+		}
+		return value, nil
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return v.(T), nil
+}
+
+func get[T any](ctx context.Context, c *Cache, e Entity, key string) (T, bool) {
+	var value T
+
+	cached, ok := c.read(ctx, e, key)
+	if !ok {
+		metrics.CacheMissCounter.WithLabelValues(c.service, e.Name).Inc()
+		return value, false
+	}
+	if err := json.Unmarshal(cached, &value); err != nil {
+		metrics.CacheMissCounter.WithLabelValues(c.service, e.Name).Inc()
+		return value, false
+	}
+
+	metrics.CacheHitCounter.WithLabelValues(c.service, e.Name).Inc()
+	return value, true
+}
+
+func (c *Cache) read(ctx context.Context, e Entity, key string) ([]byte, bool) {
+	if !c.monitor.Available() {
+		return c.local.get(key)
+	}
+
+	cached, err := c.monitor.Client().Get(ctx, key).Bytes()
+	if err == nil {
+		return cached, true
+	}
+	if !errors.Is(err, redis.Nil) {
+		metrics.CacheErrorCounter.WithLabelValues(c.service, e.Name).Inc()
+		c.monitor.MarkFailed(err)
+		return c.local.get(key)
+	}
+	return nil, false
+}
+
+func (c *Cache) set(ctx context.Context, e Entity, key string, data []byte) {
+	if c.monitor.Available() {
+		err := c.monitor.Client().Set(ctx, key, data, e.TTL).Err()
+		if err == nil {
+			return
+		}
+		metrics.CacheErrorCounter.WithLabelValues(c.service, e.Name).Inc()
+		c.monitor.MarkFailed(err)
+	}
+	c.local.set(key, data, e.TTL)
+}
+
+// Invalidate varlığın verilen parçalara ait anahtarını siler
+func (c *Cache) Invalidate(ctx context.Context, e Entity, parts ...interface{}) error {
+	key := c.Key(e, parts...)
+	c.local.delete(key)
+	return c.del(ctx, e, func() ([]string, error) { return []string{key}, nil })
+}
+
+// InvalidateEntity varlığa ait güncel sürümdeki tüm anahtarları siler
+func (c *Cache) InvalidateEntity(ctx context.Context, e Entity) error {
+	prefix := c.entityPrefix(e)
+	c.local.deletePrefix(prefix)
+	return c.del(ctx, e, func() ([]string, error) {
+		keys := []string{prefix}
+		iter := c.monitor.Client().Scan(ctx, 0, prefix+":*", 100).Iterator()
+		for iter.Next(ctx) {
+			keys = append(keys, iter.Val())
+		}
+		return keys, iter.Err()
+	})
+}
+
+func (c *Cache) del(ctx context.Context, e Entity, keys func() ([]string, error)) error {
+	if !c.monitor.Available() {
+		c.deferInvalidation(e)
+		return nil
+	}
+
+	list, err := keys()
+	if err == nil {
+		err = c.monitor.Client().Del(ctx, list...).Err()
+	}
+	if err != nil {
+		log.Printf("cache invalidation failed for %s: %v", e.Name, err)
+		c.monitor.MarkFailed(err)
+		c.deferInvalidation(e)
+		return err
+	}
+	return nil
+}
+
+// PendingInvalidations Redis'e ulaşılamadığı için bekleyen silme sayısını döner.
+// Kısa ömürlü komutlar çıkmadan önce bunu kontrol etmelidir; bekleyenler süreçle birlikte kaybolur.
+func (c *Cache) PendingInvalidations() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.pending)
+}
+
+// Redis'te kalan eski değerler bağlantı geri geldiğinde varlık bazında silinir
+func (c *Cache) deferInvalidation(e Entity) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pending[c.entityPrefix(e)] = e
+}
+
+func (c *Cache) replayInvalidations() {
+	c.mu.Lock()
+	pending := c.pending
+	c.pending = make(map[string]Entity)
+	c.mu.Unlock()
+
+	for _, e := range pending {
+		if err := c.InvalidateEntity(context.Background(), e); err != nil {
+			log.Printf("deferred cache invalidation failed for %s: %v", e.Name, err)
+		}
+	}
+}
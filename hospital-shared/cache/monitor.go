@@ -0,0 +1,131 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"hospital-shared/metrics"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ErrRedisUnavailable Redis'e ulaşılamadığında döner
+var ErrRedisUnavailable = errors.New("redis is unavailable")
+
+// Monitor Redis bağlantısının durumunu arka planda izler.
+// go-redis her komutta yeniden bağlanmayı dener; Monitor yalnızca bağlantı
+// kopukken istekleri beklemeden yedeğe yönlendirmek için durumu tutar.
+type Monitor struct {
+	rdb       *redis.Client
+	service   string
+	interval  time.Duration
+	available atomic.Bool
+
+	mu          sync.Mutex
+	onReconnect []func()
+}
+
+// NewMonitor ilk bağlantıyı dener; başarısız olsa da Monitor döner
+func NewMonitor(rdb *redis.Client, service string, interval time.Duration) *Monitor {
+	m := &Monitor{
+		rdb:      rdb,
+		service:  service,
+		interval: interval,
+	}
+	if err := m.ping(context.Background()); err != nil {
+		log.Printf("redis unavailable at startup, running in degraded mode: %v", err)
+		m.setAvailable(false)
+	} else {
+		m.setAvailable(true)
+	}
+	return m
+}
+
+func (m *Monitor) Client() *redis.Client {
+	return m.rdb
+}
+
+func (m *Monitor) Available() bool {
+	return m.available.Load()
+}
+
+// Check sağlık kontrolü için Redis durumunu hata olarak döner
+func (m *Monitor) Check() error {
+	if !m.Available() {
+		return ErrRedisUnavailable
+	}
+	return nil
+}
+
+// MarkFailed bir komut hatasından sonra bir sonraki kontrole kadar Redis'i devre dışı bırakır
+func (m *Monitor) MarkFailed(err error) {
+	if m.available.Load() {
+		log.Printf("redis command failed, switching to degraded mode: %v", err)
+	}
+	m.setAvailable(false)
+}
+
+// OnReconnect bağlantı geri geldiğinde çalışacak fonksiyonu ekler
+func (m *Monitor) OnReconnect(fn func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onReconnect = append(m.onReconnect, fn)
+}
+
+// Start ctx kapanana kadar bağlantıyı periyodik olarak kontrol eder
+func (m *Monitor) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.check(ctx)
+			}
+		}
+	}()
+}
+
+func (m *Monitor) check(ctx context.Context) {
+	wasAvailable := m.Available()
+	if err := m.ping(ctx); err != nil {
+		if wasAvailable {
+			log.Printf("redis connection lost: %v", err)
+		}
+		m.setAvailable(false)
+		return
+	}
+
+	m.setAvailable(true)
+	if !wasAvailable {
+		log.Printf("redis connection restored")
+		m.mu.Lock()
+		hooks := append([]func(){}, m.onReconnect...)
+		m.mu.Unlock()
+		for _, fn := range hooks {
+			fn()
+		}
+	}
+}
+
+func (m *Monitor) ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	return m.rdb.Ping(ctx).Err()
+}
+
+func (m *Monitor) setAvailable(v bool) {
+	m.available.Store(v)
+	value := 0.0
+	if v {
+		value = 1
+	}
+	metrics.RedisAvailableGauge.WithLabelValues(m.service).Set(value)
+}
@@ -0,0 +1,10 @@
+package dto
+
+// AuthorityContact bir hastanedeki kullanıcıya bildirim göndermek için gereken bilgilerdir
+type AuthorityContact struct {
+	ID        uint   `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+}
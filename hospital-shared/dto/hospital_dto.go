@@ -8,6 +8,9 @@ type CreateHospitalRequest struct {
 	Address    string `json:"address"`
 	CityID     uint   `json:"city_id"`
 	DistrictID uint   `json:"district_id"`
+	// Koordinatlar isteğe bağlıdır, verilirse ikisi birlikte verilir
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
 }
 
 type HospitalResponse struct {
@@ -23,11 +26,48 @@ type HospitalResponse struct {
 	DistrictName string `json:"district_name"`
 }
 
+type HospitalStatusResponse struct {
+	ID     uint   `json:"id"`
+	Status string `json:"status"`
+}
+
 type PolyclinicPersonnelGroup struct {
+	GroupID   uint   `json:"groupId,omitempty"`
 	GroupName string `json:"groupName"`
 	Count     int    `json:"count"`
 }
 
+// Hospital servisi sayfadaki tüm poliklinikler için tek istekte istatistik alır
+type PersonnelStatsBatchRequest struct {
+	HospitalPolyclinicIDs []uint `json:"hospital_polyclinic_ids"`
+}
+
+type PolyclinicPersonnelStats struct {
+	HospitalPolyclinicID uint                       `json:"hospital_polyclinic_id"`
+	Total                int                        `json:"total"`
+	Groups               []PolyclinicPersonnelGroup `json:"groups"`
+}
+
+// Poliklinik hastaneden kaldırılırken personelin taşınması için kullanılır.
+// FromHospitalPolyclinicID boşsa polikliniği olmayan personel, ToHospitalPolyclinicID boşsa atama kaldırılır.
+// StaffIDs verilirse sadece bu personel taşınır (geri alma için).
+type ReassignStaffRequest struct {
+	HospitalID               uint   `json:"hospital_id"`
+	FromHospitalPolyclinicID *uint  `json:"from_hospital_polyclinic_id"`
+	ToHospitalPolyclinicID   *uint  `json:"to_hospital_polyclinic_id"`
+	StaffIDs                 []uint `json:"staff_ids,omitempty"`
+}
+
+type ReassignStaffResponse struct {
+	StaffIDs []uint `json:"staff_ids"`
+}
+
+// Personel servisi listedeki poliklinik adlarını tek istekte çözer; yalnızca verilen hastanenin poliklinikleri döner
+type HospitalPolyclinicBatchRequest struct {
+	HospitalID            uint   `json:"hospital_id"`
+	HospitalPolyclinicIDs []uint `json:"hospital_polyclinic_ids"`
+}
+
 type HospitalPolyclinicResponseDTO struct {
 	ID           uint   `json:"id"`
 	HospitalID   uint   `json:"hospital_id"`
@@ -0,0 +1,29 @@
+package dto
+
+// Tutarlılık kontrolü için servisler kayıtlarını ID sırasıyla sayfalı verir.
+// NextAfterID 0 ise son sayfadır.
+
+type AuthorityRef struct {
+	ID         uint   `json:"id"`
+	HospitalID uint   `json:"hospital_id"`
+	Role       string `json:"role"`
+}
+
+type AuthorityRefPage struct {
+	Items       []AuthorityRef `json:"items"`
+	NextAfterID uint           `json:"next_after_id"`
+}
+
+type StaffRef struct {
+	ID                   uint  `json:"id"`
+	HospitalID           uint  `json:"hospital_id"`
+	HospitalPolyclinicID *uint `json:"hospital_polyclinic_id"`
+}
+
+type StaffRefPage struct {
+	Items       []StaffRef `json:"items"`
+	NextAfterID uint       `json:"next_after_id"`
+}
+
+// MaxRefPageSize sayfalı iç endpointlerin kabul ettiği en büyük sayfa boyutudur
+const MaxRefPageSize = 500
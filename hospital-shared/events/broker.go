@@ -0,0 +1,13 @@
+package events
+
+import "context"
+
+// Handler bir olayı işler; hata dönerse olay daha sonra tekrar teslim edilir
+type Handler func(ctx context.Context, e Event) error
+
+// Broker olayların yayınlandığı ve tüketildiği altyapıyı soyutlar
+type Broker interface {
+	Publish(ctx context.Context, e Event) error
+	// Subscribe ctx kapanana kadar bloklar; aynı gruptaki tüketiciler olayları paylaşır
+	Subscribe(ctx context.Context, group, consumer string, handler Handler) error
+}
@@ -0,0 +1,122 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"time"
+
+	"hospital-shared/metrics"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// İşlenmiş olay kayıtları bu süreden sonra silinir. Broker bir olayı bundan daha geç
+// tekrar teslim etmez; stream uzunluğu ve bekleyen mesajların devralınması bunu sınırlar.
+const processedRetention = 30 * 24 * time.Hour
+
+// TxHandler olayı, işlendi kaydıyla aynı transaction içinde işler
+type TxHandler func(ctx context.Context, tx *gorm.DB, e Event) error
+
+// Consumer bir servisin olay tüketicisidir. Her olay tipi için tek handler kaydedilir;
+// aynı olay tekrar teslim edilse de handler yalnızca bir kez başarıyla çalışır.
+type Consumer struct {
+	db       *gorm.DB
+	broker   Broker
+	service  string
+	name     string
+	handlers map[string]TxHandler
+}
+
+// NewConsumer servis adını consumer group olarak kullanır; instance'lar hostname ile ayrılır
+func NewConsumer(db *gorm.DB, broker Broker, service string) *Consumer {
+	name, err := os.Hostname()
+	if err != nil || name == "" {
+		name = service
+	}
+	return &Consumer{
+		db:       db,
+		broker:   broker,
+		service:  service,
+		name:     name,
+		handlers: make(map[string]TxHandler),
+	}
+}
+
+func (c *Consumer) On(eventType string, h TxHandler) {
+	c.handlers[eventType] = h
+}
+
+// Start ctx kapanana kadar olayları arka planda tüketir ve eski işlenmiş olay kayıtlarını temizler
+func (c *Consumer) Start(ctx context.Context) {
+	go c.pruneLoop(ctx)
+	go func() {
+		for {
+			err := c.broker.Subscribe(ctx, c.service, c.name, c.handle)
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("events: %s subscription ended, restarting: %v", c.service, err)
+			sleep(ctx, retryInterval)
+		}
+	}()
+}
+
+func (c *Consumer) handle(ctx context.Context, e Event) error {
+	h, ok := c.handlers[e.Type]
+	if !ok {
+		// Bu servisi ilgilendirmeyen olay
+		return nil
+	}
+
+	err := c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&ProcessedEvent{
+			EventID:     e.ID,
+			Consumer:    c.service,
+			ProcessedAt: time.Now(),
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errDuplicate
+		}
+		return h(ctx, tx, e)
+	})
+
+	switch {
+	case errors.Is(err, errDuplicate):
+		metrics.EventsConsumedCounter.WithLabelValues(c.service, e.Type, "duplicate").Inc()
+		return nil
+	case err != nil:
+		metrics.EventsConsumedCounter.WithLabelValues(c.service, e.Type, "error").Inc()
+		return err
+	}
+	metrics.EventsConsumedCounter.WithLabelValues(c.service, e.Type, "ok").Inc()
+	return nil
+}
+
+var errDuplicate = errors.New("event already processed")
+
+func (c *Consumer) pruneLoop(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		c.prune()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Consumer) prune() {
+	cutoff := time.Now().Add(-processedRetention)
+	err := c.db.Where("consumer = ? AND processed_at < ?", c.service, cutoff).Delete(&ProcessedEvent{}).Error
+	if err != nil {
+		log.Printf("events: failed to prune processed events: %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+// Package events servisler arası domain olaylarını taşır.
+//
+// Olaylar değişiklikle aynı veritabanı transaction'ında outbox tablosuna yazılır
+// (Enqueue), Relay bunları Broker'a yayınlar, tüketiciler ise Idempotent ile
+// sarılarak her olayı bir kez işler.
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	dt "hospital-shared/dto"
+
+	"github.com/google/uuid"
+)
+
+// Olay tipleri
+const (
+	HospitalCreated           = "HospitalCreated"
+	HospitalPolyclinicRemoved = "HospitalPolyclinicRemoved"
+	StaffAssigned             = "StaffAssigned"
+	PolyclinicStaffCounted    = "PolyclinicStaffCounted"
+	AuthorityDeleted          = "AuthorityDeleted"
+)
+
+// Event broker üzerinden taşınan zarftır
+type Event struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Source     string          `json:"source"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// Decode olay içeriğini verilen yapıya çözer
+func (e Event) Decode(v interface{}) error {
+	return json.Unmarshal(e.Payload, v)
+}
+
+func newEvent(source, eventType string, payload interface{}) (Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, err
+	}
+	return Event{
+		ID:         uuid.NewString(),
+		Type:       eventType,
+		Source:     source,
+		OccurredAt: time.Now().UTC(),
+		Payload:    data,
+	}, nil
+}
+
+type HospitalCreatedPayload struct {
+	HospitalID uint   `json:"hospital_id"`
+	Name       string `json:"name"`
+}
+
+// HospitalPolyclinicRemovedPayload kaldırılan polikliniği taşır. ReassignTo verilmişse
+// personel o polikliniğe taşınır, verilmemişse personelin polikliniği boşaltılır.
+type HospitalPolyclinicRemovedPayload struct {
+	HospitalID           uint  `json:"hospital_id"`
+	HospitalPolyclinicID uint  `json:"hospital_polyclinic_id"`
+	PolyclinicID         uint  `json:"polyclinic_id"`
+	ReassignTo           *uint `json:"reassign_to,omitempty"`
+}
+
+// StaffAssignedPayload personelin poliklinik ataması veya meslek grubu değiştiğinde yayınlanır.
+// HospitalPolyclinicID boşsa personelin polikliniği kaldırılmıştır.
+// Previous alanları değişiklikten önceki durumu taşır; yeni personelde boştur.
+type StaffAssignedPayload struct {
+	StaffID                      uint   `json:"staff_id"`
+	HospitalID                   uint   `json:"hospital_id"`
+	HospitalPolyclinicID         *uint  `json:"hospital_polyclinic_id"`
+	JobGroupID                   uint   `json:"job_group_id"`
+	JobGroupName                 string `json:"job_group_name"`
+	PreviousHospitalPolyclinicID *uint  `json:"previous_hospital_polyclinic_id"`
+	PreviousJobGroupID           uint   `json:"previous_job_group_id,omitempty"`
+}
+
+// PolyclinicStaffCountedPayload polikliniklerin değişiklikten sonraki mutlak personel sayılarını taşır.
+// CountedAt aynı poliklinik için sayımların sırasını belirler; tüketici kendi kopyasından eski
+// bir sayımı uygulamaz, böylece tekrar gelen ya da sırası karışan olaylar sayıları bozmaz.
+type PolyclinicStaffCountedPayload struct {
+	HospitalID uint                          `json:"hospital_id"`
+	CountedAt  time.Time                     `json:"counted_at"`
+	Stats      []dt.PolyclinicPersonnelStats `json:"stats"`
+}
+
+type AuthorityDeletedPayload struct {
+	AuthorityID uint   `json:"authority_id"`
+	HospitalID  uint   `json:"hospital_id"`
+	Role        string `json:"role"`
+}
@@ -0,0 +1,61 @@
+package events
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OutboxEvent henüz yayınlanmamış ya da yayınlanmış olayları tutar
+type OutboxEvent struct {
+	ID          uint       `gorm:"primaryKey"`
+	EventID     string     `gorm:"uniqueIndex;not null"`
+	Type        string     `gorm:"not null"`
+	Source      string     `gorm:"not null"`
+	Payload     []byte     `gorm:"type:jsonb;not null"`
+	OccurredAt  time.Time  `gorm:"not null"`
+	PublishedAt *time.Time `gorm:"index"`
+	Attempts    int        `gorm:"not null;default:0"`
+	LastError   string
+	// Başarısız denemeden sonra olay bu zamana kadar bekler
+	NextAttemptAt *time.Time
+	// Deneme sınırı aşılınca doldurulur; bu olaylar tekrar denenmez, elle incelenir
+	DeadLetteredAt *time.Time `gorm:"index"`
+}
+
+// ProcessedEvent bir tüketicinin işlediği olayları kaydeder
+type ProcessedEvent struct {
+	EventID     string    `gorm:"primaryKey"`
+	Consumer    string    `gorm:"primaryKey"`
+	ProcessedAt time.Time `gorm:"not null;index"`
+}
+
+// Migrate outbox ve işlenmiş olay tablolarını oluşturur
+func Migrate(db *gorm.DB) error {
+	return db.AutoMigrate(&OutboxEvent{}, &ProcessedEvent{})
+}
+
+// Enqueue olayı verilen transaction içinde outbox'a yazar; olay ancak transaction commit edilirse yayınlanır
+func Enqueue(tx *gorm.DB, source, eventType string, payload interface{}) error {
+	e, err := newEvent(source, eventType, payload)
+	if err != nil {
+		return err
+	}
+	return tx.Create(&OutboxEvent{
+		EventID:    e.ID,
+		Type:       e.Type,
+		Source:     e.Source,
+		Payload:    e.Payload,
+		OccurredAt: e.OccurredAt,
+	}).Error
+}
+
+func (o *OutboxEvent) event() Event {
+	return Event{
+		ID:         o.EventID,
+		Type:       o.Type,
+		Source:     o.Source,
+		OccurredAt: o.OccurredAt,
+		Payload:    o.Payload,
+	}
+}
@@ -0,0 +1,252 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"hospital-shared/cache"
+	"hospital-shared/metrics"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// DefaultStream tüm servislerin olaylarını taşıyan Redis stream'idir
+const DefaultStream = "hospital:events"
+
+const (
+	streamMaxLen   = 100000
+	readBatchSize  = 50
+	readBlock      = 5 * time.Second
+	claimMinIdle   = time.Minute
+	retryInterval  = 5 * time.Second
+	payloadField   = "event"
+	groupField     = "group"
+	busyGroupError = "BUSYGROUP"
+	// Bu kadar teslimattan sonra hâlâ işlenemeyen mesaj dead-letter stream'ine ayrılır
+	maxDeliveries    = 10
+	deadLetterSuffix = ":dead"
+	deadLetterMaxLen = 10000
+)
+
+// RedisStreamsBroker olayları Redis Streams üzerinden consumer group'larla dağıtır.
+// İşlenemeyen mesajlar onaylanmaz; claimMinIdle sonra aynı gruptaki bir tüketici tarafından tekrar alınır.
+// maxDeliveries kez teslim edilip işlenemeyen mesaj "<stream>:dead" stream'ine taşınıp onaylanır.
+// Tekrar denemeler mesajları sırasız işletebildiğinden sıraya bağlı tüketiciler olaydaki zamanla
+// (PolyclinicStaffCounted.CountedAt gibi) eski olayları ayıklar.
+type RedisStreamsBroker struct {
+	monitor *cache.Monitor
+	stream  string
+}
+
+func NewRedisStreamsBroker(monitor *cache.Monitor, stream string) *RedisStreamsBroker {
+	return &RedisStreamsBroker{monitor: monitor, stream: stream}
+}
+
+func (b *RedisStreamsBroker) Publish(ctx context.Context, e Event) error {
+	if !b.monitor.Available() {
+		return cache.ErrRedisUnavailable
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	err = b.monitor.Client().XAdd(ctx, &redis.XAddArgs{
+		Stream: b.stream,
+		MaxLen: streamMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{payloadField: data},
+	}).Err()
+	if err != nil {
+		b.monitor.MarkFailed(err)
+	}
+	return err
+}
+
+func (b *RedisStreamsBroker) Subscribe(ctx context.Context, group, consumer string, handler Handler) error {
+	groupReady := false
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if !b.monitor.Available() {
+			sleep(ctx, retryInterval)
+			continue
+		}
+		if !groupReady {
+			if err := b.ensureGroup(ctx, group); err != nil {
+				b.fail(ctx, "create consumer group", err)
+				continue
+			}
+			groupReady = true
+		}
+
+		// Önce başka tüketicilerde takılı kalmış mesajlar devralınır
+		if err := b.claimStale(ctx, group, consumer, handler); err != nil {
+			b.fail(ctx, "claim pending events", err)
+			continue
+		}
+
+		streams, err := b.monitor.Client().XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: consumer,
+			Streams:  []string{b.stream, ">"},
+			Count:    readBatchSize,
+			Block:    readBlock,
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// Stream silinmiş olabilir; grup bir sonraki turda yeniden oluşturulur
+			groupReady = false
+			b.fail(ctx, "read events", err)
+			continue
+		}
+		for _, s := range streams {
+			b.dispatch(ctx, group, s.Messages, handler)
+		}
+	}
+}
+
+func (b *RedisStreamsBroker) ensureGroup(ctx context.Context, group string) error {
+	// "0" ile grup stream'deki mevcut olayları da alır; tüketiciler idempotent olduğundan güvenlidir
+	err := b.monitor.Client().XGroupCreateMkStream(ctx, b.stream, group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), busyGroupError) {
+		return err
+	}
+	return nil
+}
+
+func (b *RedisStreamsBroker) claimStale(ctx context.Context, group, consumer string, handler Handler) error {
+	start := "0-0"
+	for {
+		msgs, next, err := b.monitor.Client().XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   b.stream,
+			Group:    group,
+			Consumer: consumer,
+			MinIdle:  claimMinIdle,
+			Start:    start,
+			Count:    readBatchSize,
+		}).Result()
+		if err != nil {
+			return err
+		}
+		b.dispatch(ctx, group, b.parkExhausted(ctx, group, msgs), handler)
+		if next == "0-0" || len(msgs) == 0 {
+			return nil
+		}
+		start = next
+	}
+}
+
+// parkExhausted teslimat sınırını aşan mesajları dead-letter stream'ine taşır ve kalanları döner.
+// Teslimat sayısı okunamazsa mesajlar olduğu gibi tekrar denenir.
+func (b *RedisStreamsBroker) parkExhausted(ctx context.Context, group string, msgs []redis.XMessage) []redis.XMessage {
+	if len(msgs) == 0 {
+		return msgs
+	}
+	client := b.monitor.Client()
+	cmds := make([]*redis.XPendingExtCmd, len(msgs))
+	_, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, msg := range msgs {
+			cmds[i] = pipe.XPendingExt(ctx, &redis.XPendingExtArgs{
+				Stream: b.stream,
+				Group:  group,
+				Start:  msg.ID,
+				End:    msg.ID,
+				Count:  1,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("events: failed to read delivery counts: %v", err)
+		return msgs
+	}
+
+	remaining := msgs[:0:0]
+	for i, msg := range msgs {
+		pending, _ := cmds[i].Result()
+		if len(pending) == 0 || pending[0].RetryCount <= maxDeliveries {
+			remaining = append(remaining, msg)
+			continue
+		}
+		if err := b.park(ctx, group, msg, pending[0].RetryCount); err != nil {
+			log.Printf("events: failed to dead-letter %s: %v", msg.ID, err)
+			remaining = append(remaining, msg)
+		}
+	}
+	return remaining
+}
+
+func (b *RedisStreamsBroker) park(ctx context.Context, group string, msg redis.XMessage, deliveries int64) error {
+	raw, _ := msg.Values[payloadField].(string)
+	err := b.monitor.Client().XAdd(ctx, &redis.XAddArgs{
+		Stream: b.stream + deadLetterSuffix,
+		MaxLen: deadLetterMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{payloadField: raw, groupField: group},
+	}).Err()
+	if err != nil {
+		return err
+	}
+	b.ack(ctx, group, msg.ID)
+
+	var e Event
+	_ = json.Unmarshal([]byte(raw), &e)
+	log.Printf("events: %s dead-lettered %s event %s after %d deliveries", group, e.Type, e.ID, deliveries)
+	metrics.EventsParkedCounter.WithLabelValues(group, e.Type).Inc()
+	return nil
+}
+
+func (b *RedisStreamsBroker) dispatch(ctx context.Context, group string, msgs []redis.XMessage, handler Handler) {
+	for _, msg := range msgs {
+		var e Event
+		raw, _ := msg.Values[payloadField].(string)
+		if err := json.Unmarshal([]byte(raw), &e); err != nil {
+			// Bozuk mesaj tekrar denense de çözülemez; onaylanıp atlanır
+			log.Printf("events: dropping malformed message %s: %v", msg.ID, err)
+			b.ack(ctx, group, msg.ID)
+			continue
+		}
+		if err := handler(ctx, e); err != nil {
+			log.Printf("events: %s handler failed for %s (%s): %v", group, e.Type, e.ID, err)
+			continue
+		}
+		b.ack(ctx, group, msg.ID)
+	}
+}
+
+func (b *RedisStreamsBroker) ack(ctx context.Context, group, id string) {
+	if err := b.monitor.Client().XAck(ctx, b.stream, group, id).Err(); err != nil {
+		log.Printf("events: failed to ack %s: %v", id, err)
+	}
+}
+
+func (b *RedisStreamsBroker) fail(ctx context.Context, op string, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	log.Printf("events: failed to %s: %v", op, err)
+	b.monitor.MarkFailed(err)
+	sleep(ctx, retryInterval)
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+	case <-t.C:
+	}
+}
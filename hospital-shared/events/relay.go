@@ -0,0 +1,171 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"hospital-shared/cache"
+	"hospital-shared/metrics"
+
+	"gorm.io/gorm"
+)
+
+const (
+	relayBatchSize = 100
+	relayInterval  = time.Second
+	// Relay advisory lock sınıfı; kilit servis adına göre alınır
+	relayLockClass = 7300
+	// Yayınlanmış olaylar bu süreden sonra outbox'tan silinir
+	outboxRetention = 7 * 24 * time.Hour
+	// Bu kadar başarısız denemeden sonra olay dead-letter olarak işaretlenir
+	maxPublishAttempts = 10
+	maxPublishBackoff  = 10 * time.Minute
+)
+
+// Relay outbox'taki yayınlanmamış olayları sırayla broker'a aktarır. Birden fazla instance
+// çalışabilir ama aynı anda yalnızca advisory lock'u alan instance yayınlar; böylece olaylar
+// outbox sırasıyla yayınlanır. Tüketici tarafında tekrar denemeler sırayı bozabilir, sıraya bağlı
+// tüketiciler olaydaki zamanı (CountedAt gibi) kullanır.
+type Relay struct {
+	db      *gorm.DB
+	broker  Broker
+	service string
+}
+
+func NewRelay(db *gorm.DB, broker Broker, service string) *Relay {
+	return &Relay{db: db, broker: broker, service: service}
+}
+
+// Start ctx kapanana kadar outbox'ı arka planda boşaltır
+func (r *Relay) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(relayInterval)
+		defer ticker.Stop()
+		lastPrune := time.Time{}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.drain(ctx)
+				if time.Since(lastPrune) > time.Hour {
+					r.prune()
+					lastPrune = time.Now()
+				}
+			}
+		}
+	}()
+}
+
+func (r *Relay) drain(ctx context.Context) {
+	for {
+		published, err := r.publishBatch(ctx)
+		if err != nil {
+			log.Printf("events: outbox relay stopped this round: %v", err)
+			break
+		}
+		if published < relayBatchSize {
+			break
+		}
+	}
+
+	var pending int64
+	if err := r.db.Model(&OutboxEvent{}).Where("published_at IS NULL AND dead_lettered_at IS NULL").Count(&pending).Error; err == nil {
+		metrics.OutboxPendingGauge.WithLabelValues(r.service).Set(float64(pending))
+	}
+}
+
+// publishBatch yayınlanan olay sayısını döner; ilk hatada durur ki olay sırası korunsun.
+// Deneme sınırını aşan olay dead-letter olarak ayrılır ve sıradaki olaylara geçilir.
+// Kilidi başka bir instance tutuyorsa hiçbir şey yapmaz.
+func (r *Relay) publishBatch(ctx context.Context) (int, error) {
+	published := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?, hashtext(?))", relayLockClass, r.service).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+
+		var batch []OutboxEvent
+		err := tx.Where("published_at IS NULL AND dead_lettered_at IS NULL").
+			Order("id").
+			Limit(relayBatchSize).
+			Find(&batch).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range batch {
+			o := &batch[i]
+			now := time.Now()
+			if o.NextAttemptAt != nil && now.Before(*o.NextAttemptAt) {
+				// Sıradaki olay bekleme süresinde; sonrakiler de onu bekler
+				return nil
+			}
+			if err := r.broker.Publish(ctx, o.event()); err != nil {
+				if errors.Is(err, cache.ErrRedisUnavailable) {
+					return nil
+				}
+				deadLettered, uerr := r.recordFailure(tx, o, err, now)
+				if uerr != nil {
+					return uerr
+				}
+				if deadLettered {
+					continue
+				}
+				// Deneme sayısı kaydedilir, transaction commit edilir; olay bekleme süresinden sonra tekrar denenir
+				return nil
+			}
+			if err := tx.Model(o).Updates(map[string]interface{}{"published_at": &now, "last_error": "", "next_attempt_at": nil}).Error; err != nil {
+				return err
+			}
+			metrics.EventsPublishedCounter.WithLabelValues(r.service, o.Type).Inc()
+			published++
+		}
+		return nil
+	})
+	return published, err
+}
+
+// recordFailure başarısız denemeyi kaydeder; sınır aşıldıysa olayı dead-letter yapar
+func (r *Relay) recordFailure(tx *gorm.DB, o *OutboxEvent, cause error, now time.Time) (bool, error) {
+	attempts := o.Attempts + 1
+	updates := map[string]interface{}{"attempts": attempts, "last_error": cause.Error()}
+
+	deadLettered := attempts >= maxPublishAttempts
+	if deadLettered {
+		updates["dead_lettered_at"] = now
+	} else {
+		updates["next_attempt_at"] = now.Add(publishBackoff(attempts))
+	}
+	if err := tx.Model(o).Updates(updates).Error; err != nil {
+		return false, err
+	}
+
+	if deadLettered {
+		log.Printf("events: %s event %s dead-lettered after %d attempts: %v", o.Type, o.EventID, attempts, cause)
+		metrics.EventsDeadLetteredCounter.WithLabelValues(r.service, o.Type).Inc()
+	}
+	return deadLettered, nil
+}
+
+// publishBackoff her denemede iki katına çıkar, maxPublishBackoff ile sınırlıdır
+func publishBackoff(attempts int) time.Duration {
+	backoff := time.Second << uint(attempts)
+	if backoff <= 0 || backoff > maxPublishBackoff {
+		return maxPublishBackoff
+	}
+	return backoff
+}
+
+func (r *Relay) prune() {
+	cutoff := time.Now().Add(-outboxRetention)
+	if err := r.db.Where("published_at < ?", cutoff).Delete(&OutboxEvent{}).Error; err != nil {
+		log.Printf("events: failed to prune outbox: %v", err)
+	}
+}
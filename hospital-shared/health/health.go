@@ -0,0 +1,53 @@
+// Package health servislerin /health endpointini oluşturur
+package health
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	StatusUp       = "up"
+	StatusDown     = "down"
+	StatusOK       = "ok"
+	StatusDegraded = "degraded"
+)
+
+// Check bir bağımlılığın durumunu kontrol eder.
+// Critical olmayan bağımlılıklar düştüğünde servis "degraded" raporlanır ama 200 döner.
+type Check struct {
+	Name     string
+	Critical bool
+	Func     func() error
+}
+
+type Response struct {
+	Status     string            `json:"status"`
+	Components map[string]string `json:"components"`
+}
+
+// Handler tüm kontrolleri çalıştırır; kritik bir bağımlılık düştüyse 503 döner
+func Handler(checks ...Check) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		resp := Response{
+			Status:     StatusOK,
+			Components: make(map[string]string, len(checks)),
+		}
+		code := fiber.StatusOK
+
+		for _, check := range checks {
+			if err := check.Func(); err != nil {
+				resp.Components[check.Name] = StatusDown
+				if check.Critical {
+					resp.Status = StatusDown
+					code = fiber.StatusServiceUnavailable
+				} else if resp.Status == StatusOK {
+					resp.Status = StatusDegraded
+				}
+				continue
+			}
+			resp.Components[check.Name] = StatusUp
+		}
+
+		return c.Status(code).JSON(resp)
+	}
+}
@@ -5,4 +5,7 @@ type JWTConfig struct {
 	PublicKeyPEM       string
 	AccessTokenExpiry  string
 	RefreshTokenExpiry string
+
+	// HospitalStatus set edilirse AuthRequired aktif olmayan hastanelerin tokenlarını reddeder
+	HospitalStatus HospitalStatusFunc
 }
@@ -4,6 +4,7 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -23,6 +24,9 @@ type UserInfo struct {
 // UserContextKey is the key for user info in Fiber context
 const UserContextKey = "userInfo"
 
+// PlatformAdminRole is the role of platform-wide administrators that are not bound to a hospital
+const PlatformAdminRole = "platform_admin"
+
 // TokenPair represents both access and refresh tokens
 type TokenPair struct {
 	AccessToken  string `json:"access_token"`
@@ -221,29 +225,82 @@ func RefreshAccessToken(refreshToken string, cfg *JWTConfig) (*TokenPair, error)
 	return GenerateTokenPair(userInfo.AuthorityID, userInfo.HospitalID, userInfo.Role, cfg)
 }
 
-// AuthRequired returns a Fiber middleware that checks JWT and sets user info in context
+// AuthRequired returns a Fiber middleware that checks JWT and sets user info in context.
+// Tokens that are not bound to a hospital are rejected; use PlatformAuthRequired for platform routes.
 func AuthRequired(cfg *JWTConfig) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		header := c.Get("Authorization")
-		if header == "" || !strings.HasPrefix(header, "Bearer ") {
+		user, err := authenticate(c, cfg)
+		if err != nil {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Missing or invalid Authorization header",
+				"error": err.Error(),
+			})
+		}
+
+		if user.HospitalID == 0 {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "Forbidden: token is not bound to a hospital",
 			})
 		}
 
-		tokenStr := strings.TrimPrefix(header, "Bearer ")
-		user, err := ParseAccessToken(tokenStr, cfg)
+		if cfg.HospitalStatus != nil {
+			status, err := cfg.HospitalStatus(user.HospitalID)
+			if errors.Is(err, ErrHospitalNotFound) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error": "Forbidden: hospital not found",
+				})
+			}
+			if err != nil {
+				return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+					"error": "Cannot verify hospital status",
+				})
+			}
+			if status != HospitalStatusActive {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error": "Forbidden: hospital is " + status,
+				})
+			}
+		}
+
+		c.Locals(UserContextKey, user)
+		return c.Next()
+	}
+}
+
+// PlatformAuthRequired returns a Fiber middleware for platform routes. Only platform admin tokens,
+// which carry no hospital, are accepted.
+func PlatformAuthRequired(cfg *JWTConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user, err := authenticate(c, cfg)
 		if err != nil {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Invalid or expired access token",
+				"error": err.Error(),
 			})
 		}
 
+		if user.Role != PlatformAdminRole {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Forbidden: insufficient role"})
+		}
+
 		c.Locals(UserContextKey, user)
 		return c.Next()
 	}
 }
 
+// authenticate reads the bearer token from the request and parses it
+func authenticate(c *fiber.Ctx, cfg *JWTConfig) (*UserInfo, error) {
+	header := c.Get("Authorization")
+	if header == "" || !strings.HasPrefix(header, "Bearer ") {
+		return nil, fmt.Errorf("Missing or invalid Authorization header")
+	}
+
+	tokenStr := strings.TrimPrefix(header, "Bearer ")
+	user, err := ParseAccessToken(tokenStr, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid or expired access token")
+	}
+	return user, nil
+}
+
 // GetUserInfo extracts user info from Fiber context
 func GetUserInfo(c *fiber.Ctx) *UserInfo {
 	val := c.Locals(UserContextKey)
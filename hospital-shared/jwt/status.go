@@ -0,0 +1,99 @@
+package jwt
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// HospitalStatusActive is the only hospital status whose users are allowed through AuthRequired
+	HospitalStatusActive = "active"
+	// HospitalStatusPending is the status of hospitals waiting for platform admin approval
+	HospitalStatusPending = "pending"
+)
+
+// ErrHospitalNotFound is returned by a HospitalStatusFunc when the hospital does not exist or was deleted
+var ErrHospitalNotFound = errors.New("hospital not found")
+
+// HospitalStatusFunc returns the lifecycle status of a hospital (pending, active, suspended, closed).
+// It returns ErrHospitalNotFound for unknown hospitals; any other error means the status could not be read.
+type HospitalStatusFunc func(hospitalID uint) (string, error)
+
+type cachedStatus struct {
+	status    string
+	notFound  bool
+	expiresAt time.Time
+	staleAt   time.Time
+}
+
+// CachedHospitalStatus wraps fetch with a small in-process cache so that every request
+// does not hit the hospital service. Unknown hospitals are cached like statuses. When fetch fails
+// the last known status is served until staleTTL has passed, and concurrent lookups of the same
+// hospital share a single fetch. Expired entries are swept at most once per ttl so the map does
+// not grow with every hospital ever seen.
+func CachedHospitalStatus(fetch HospitalStatusFunc, ttl, staleTTL time.Duration) HospitalStatusFunc {
+	var mu sync.Mutex
+	var group singleflight.Group
+	entries := make(map[uint]cachedStatus)
+	nextSweep := time.Now().Add(ttl)
+
+	return func(hospitalID uint) (string, error) {
+		mu.Lock()
+		entry, ok := entries[hospitalID]
+		mu.Unlock()
+		if ok && time.Now().Before(entry.expiresAt) {
+			return entry.result()
+		}
+
+		v, err, _ := group.Do(strconv.FormatUint(uint64(hospitalID), 10), func() (interface{}, error) {
+			status, err := fetch(hospitalID)
+			if err != nil && !errors.Is(err, ErrHospitalNotFound) {
+				return nil, err
+			}
+
+			now := time.Now()
+			fresh := cachedStatus{
+				status:    status,
+				notFound:  err != nil,
+				expiresAt: now.Add(ttl),
+				staleAt:   now.Add(staleTTL),
+			}
+			mu.Lock()
+			if now.After(nextSweep) {
+				evictExpiredStatuses(entries, now)
+				nextSweep = now.Add(ttl)
+			}
+			entries[hospitalID] = fresh
+			mu.Unlock()
+			return fresh, nil
+		})
+		if err != nil {
+			// The hospital service is unreachable; keep serving the last known status for a while
+			if ok && time.Now().Before(entry.staleAt) {
+				return entry.result()
+			}
+			return "", err
+		}
+		return v.(cachedStatus).result()
+	}
+}
+
+func (s cachedStatus) result() (string, error) {
+	if s.notFound {
+		return "", ErrHospitalNotFound
+	}
+	return s.status, nil
+}
+
+// evictExpiredStatuses removes entries that cannot be served even as stale; the caller holds the lock
+func evictExpiredStatuses(entries map[uint]cachedStatus, now time.Time) {
+	for id, entry := range entries {
+		if now.After(entry.staleAt) && now.After(entry.expiresAt) {
+			delete(entries, id)
+		}
+	}
+}
@@ -102,6 +102,73 @@ var (
 		},
 		[]string{"endpoint", "ip"},
 	)
+
+	// Önbellek metrikleri
+	CacheHitCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_hits_total",
+			Help: "Total number of cache hits",
+		},
+		[]string{"service", "entity"},
+	)
+	CacheMissCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_misses_total",
+			Help: "Total number of cache misses",
+		},
+		[]string{"service", "entity"},
+	)
+	CacheErrorCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_errors_total",
+			Help: "Total number of cache backend errors",
+		},
+		[]string{"service", "entity"},
+	)
+	RedisAvailableGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "redis_available",
+			Help: "Whether Redis is reachable (1) or the service runs degraded (0)",
+		},
+		[]string{"service"},
+	)
+
+	// Olay (outbox) metrikleri
+	EventsPublishedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "events_published_total",
+			Help: "Total number of outbox events published to the broker",
+		},
+		[]string{"service", "type"},
+	)
+	EventsConsumedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "events_consumed_total",
+			Help: "Total number of consumed events by result (ok, duplicate, error)",
+		},
+		[]string{"service", "type", "result"},
+	)
+	EventsDeadLetteredCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "events_dead_lettered_total",
+			Help: "Total number of outbox events given up after too many failed publish attempts",
+		},
+		[]string{"service", "type"},
+	)
+	EventsParkedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "events_parked_total",
+			Help: "Total number of consumed events moved to the dead-letter stream after too many failed deliveries",
+		},
+		[]string{"service", "type"},
+	)
+	OutboxPendingGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "outbox_pending_events",
+			Help: "Number of outbox events waiting to be published",
+		},
+		[]string{"service"},
+	)
 )
 
 func init() {
@@ -118,6 +185,15 @@ func init() {
 	prometheus.MustRegister(RefreshTokenSuccessCounter)
 	prometheus.MustRegister(RefreshTokenFailCounter)
 	prometheus.MustRegister(RateLimitExceededCounter)
+	prometheus.MustRegister(CacheHitCounter)
+	prometheus.MustRegister(CacheMissCounter)
+	prometheus.MustRegister(CacheErrorCounter)
+	prometheus.MustRegister(RedisAvailableGauge)
+	prometheus.MustRegister(EventsPublishedCounter)
+	prometheus.MustRegister(EventsConsumedCounter)
+	prometheus.MustRegister(EventsDeadLetteredCounter)
+	prometheus.MustRegister(EventsParkedCounter)
+	prometheus.MustRegister(OutboxPendingGauge)
 }
 
 // PrometheusHandler Fiber ile uyumlu /metrics endpointi için handler döndürür
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// InternalTokenHeader servisler arası isteklerde paylaşılan anahtarın taşındığı header
+const InternalTokenHeader = "X-Internal-Token"
+
+// InternalAuth sadece paylaşılan anahtarı bilen servislerin çağırabileceği endpointler için.
+// Anahtar ayarlanmamışsa tüm istekler reddedilir.
+func InternalAuth(token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		given := c.Get(InternalTokenHeader)
+		if token == "" || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Unauthorized",
+			})
+		}
+		return c.Next()
+	}
+}
+
+// InternalTransport servis istemcilerinin her isteğine paylaşılan anahtarı ekler
+func InternalTransport(token string) http.RoundTripper {
+	return &internalTransport{token: token, base: http.DefaultTransport}
+}
+
+type internalTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *internalTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrip isteği değiştirmemeli, header kopya üzerinde eklenir
+	clone := req.Clone(req.Context())
+	clone.Header.Set(InternalTokenHeader, t.token)
+	return t.base.RoundTrip(clone)
+}
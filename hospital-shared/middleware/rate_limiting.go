@@ -80,3 +80,21 @@ func AdminRateLimiter() fiber.Handler {
 		},
 	})
 }
+
+// PublicRateLimiter Kimlik doğrulaması olmayan herkese açık endpointler için limit
+func PublicRateLimiter() fiber.Handler {
+	return limiter.New(limiter.Config{
+		Max:        30,              // 30 istek
+		Expiration: 1 * time.Minute, // 1 dakika
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return c.IP() + ":public"
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			// Rate limit metriklerini artır
+			metrics.RateLimitExceededCounter.WithLabelValues("public", c.IP()).Inc()
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": "Rate limit exceeded. Please try again later.",
+			})
+		},
+	})
+}
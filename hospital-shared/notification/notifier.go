@@ -0,0 +1,63 @@
+// Package notification servislerin e-posta bildirimlerini gönderir.
+package notification
+
+import (
+	"fmt"
+	"log"
+	"mime"
+	"net/smtp"
+	"strings"
+)
+
+// SMTPConfig servis ayarlarındaki SMTP bölümüdür; host boşsa bildirimler sadece loglanır
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+type Notifier interface {
+	Send(to, subject, body string) error
+}
+
+// NewNotifier SMTP ayarlıysa e-posta gönderen, değilse sadece loglayan notifier döner
+func NewNotifier(cfg SMTPConfig) Notifier {
+	if cfg.Host == "" {
+		return &logNotifier{}
+	}
+	return &smtpNotifier{cfg: cfg}
+}
+
+type smtpNotifier struct {
+	cfg SMTPConfig
+}
+
+func (n *smtpNotifier) Send(to, subject, body string) error {
+	var auth smtp.Auth
+	if n.cfg.Username != "" {
+		auth = smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.Host)
+	}
+
+	// Başlıklar ASCII olmalı; Türkçe karakterli konular RFC 2047'ye göre kodlanır
+	msg := strings.Join([]string{
+		"From: " + n.cfg.From,
+		"To: " + to,
+		"Subject: " + mime.QEncoding.Encode("utf-8", subject),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	addr := fmt.Sprintf("%s:%s", n.cfg.Host, n.cfg.Port)
+	return smtp.SendMail(addr, auth, n.cfg.From, []string{to}, []byte(msg))
+}
+
+type logNotifier struct{}
+
+func (n *logNotifier) Send(to, subject, body string) error {
+	log.Printf("notification to=%s subject=%q body=%q", to, subject, body)
+	return nil
+}
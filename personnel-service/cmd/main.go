@@ -1,11 +1,21 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	_ "time/tzdata" // container imajında zoneinfo olmayabilir
 
 	"personnel-service/internal/config"
+	"personnel-service/internal/consumer"
 	"personnel-service/internal/database"
+	"personnel-service/internal/infrastructure/client"
+	"personnel-service/internal/jobs"
+	"personnel-service/internal/repository"
 	"personnel-service/internal/router"
 	"personnel-service/pkg/utils"
 
@@ -15,6 +25,10 @@ import (
 
 	fiberSwagger "github.com/swaggo/fiber-swagger"
 
+	"hospital-shared/cache"
+	"hospital-shared/events"
+	"hospital-shared/health"
+	"hospital-shared/jwt"
 	"hospital-shared/metrics"
 )
 
@@ -25,6 +39,10 @@ func main() {
 		log.Fatalf("cannot load config: %v", err)
 	}
 
+	// Sayımlardaki gün sınırı migration'daki ilk yayından önce ayarlanmalı
+	location := cfg.Location()
+	repository.SetCountLocation(location)
+
 	// Connect to database
 	dbInstance, err := database.NewDatabase(&cfg)
 	if err != nil {
@@ -41,20 +59,56 @@ func main() {
 	app.Use(metrics.PrometheusMiddleware())
 	app.Get("/metrics", metrics.PrometheusHandler())
 
+	// Redis düşerse servis çalışmaya devam eder, durum /health üzerinden raporlanır
+	dbInstance.RedisMonitor.Start(context.Background())
+	app.Get("/health", health.Handler(
+		health.Check{Name: "postgres", Critical: true, Func: dbInstance.PingSQL},
+		health.Check{Name: "redis", Func: dbInstance.RedisMonitor.Check},
+	))
+
 	app.Get("/swagger/*", fiberSwagger.WrapHandler)
 
+	// Outbox olayları Redis Streams'e aktarılır, diğer servislerin olayları tüketilir
+	broker := events.NewRedisStreamsBroker(dbInstance.RedisMonitor, events.DefaultStream)
+	events.NewRelay(dbInstance.SQL, broker, repository.EventSource).Start(context.Background())
+	eventConsumer := events.NewConsumer(dbInstance.SQL, broker, repository.EventSource)
+	consumer.Register(eventConsumer)
+	eventConsumer.Start(context.Background())
+
+	// Aktif olmayan hastanelerin tokenları reddedilir
+	jwtCfg := utils.MapToSharedJWTConfig(&cfg)
+	hospitalClient := client.NewHospitalClient(cfg.Url.BaseUrl, cfg.Internal.Token)
+	jwtCfg.HospitalStatus = jwt.CachedHospitalStatus(hospitalClient.GetHospitalStatus, 30*time.Second, 5*time.Minute)
+
 	deps := router.RouterDeps{
 		App:             app,
 		DB:              dbInstance,
 		Config:          &cfg,
-		JWTSharedConfig: utils.MapToSharedJWTConfig(&cfg),
+		JWTSharedConfig: jwtCfg,
+		Cache:           cache.New(dbInstance.RedisMonitor, "personnel"),
+		Location:        location,
 	}
 
-	router.PersonnelRoutes(deps)
+	dailyJobs := router.PersonnelRoutes(deps)
+
+	// Günlük işler servis kapanırken durdurulur
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	for _, job := range dailyJobs {
+		jobs.Daily(ctx, dbInstance.SQL, deps.Location, job)
+	}
+	go func() {
+		<-ctx.Done()
+		if err := app.Shutdown(); err != nil {
+			log.Printf("shutdown failed: %v", err)
+		}
+	}()
 
 	for _, r := range app.GetRoutes() {
 		fmt.Println(r.Method, r.Path)
 	}
 
-	log.Fatal(app.Listen(":" + cfg.Server.Port))
+	if err := app.Listen(":" + cfg.Server.Port); err != nil {
+		log.Fatal(err)
+	}
 }
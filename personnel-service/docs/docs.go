@@ -15,68 +15,28 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/personnel/job-groups": {
-            "get": {
-                "description": "Returns all job groups",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Personnel"
-                ],
-                "summary": "Tüm meslek gruplarını listeler",
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.JobGroupLookup"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/api/personnel/staff": {
+        "/api/personnel/assignments": {
             "get": {
-                "description": "Lists staff with filters and pagination",
+                "description": "Lists staff with the polyclinic, job group and title they had on the given date, e.g. staff of polyclinic X as of 2026-01-01. Staff deleted later are included with active=false.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Personnel"
+                    "Assignment"
                 ],
-                "summary": "Personelleri listeler (filtreli ve sayfalı)",
+                "summary": "Belirli bir tarihteki personeli listeler",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page size",
-                        "name": "size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "First name",
-                        "name": "first_name",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Last name",
-                        "name": "last_name",
-                        "in": "query"
+                        "description": "Date (YYYY-MM-DD)",
+                        "name": "as_of",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "TC Kimlik No",
-                        "name": "tc",
+                        "description": "Hospital polyclinic ID or 'unassigned'",
+                        "name": "hospital_polyclinic_id",
                         "in": "query"
                     },
                     {
@@ -93,10 +53,10 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.StaffListResponse"
+                            "$ref": "#/definitions/dto.StaffAsOfResponse"
                         }
                     },
                     "400": {
@@ -109,9 +69,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/personnel/attendance/clock": {
             "post": {
-                "description": "Adds a new staff member",
+                "description": "Records a clock-in or clock-out using the scanned kiosk QR token and the staff's TC and phone",
                 "consumes": [
                     "application/json"
                 ],
@@ -119,17 +81,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Personnel"
+                    "Attendance"
                 ],
-                "summary": "Personel ekler",
+                "summary": "Kiosk QR'ı ile giriş/çıkış kaydeder",
                 "parameters": [
                     {
-                        "description": "Staff info",
-                        "name": "staff",
+                        "description": "Clock request",
+                        "name": "clock",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.AddStaffRequest"
+                            "$ref": "#/definitions/dto.KioskClockRequest"
                         }
                     }
                 ],
@@ -137,7 +99,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.StaffResponse"
+                            "$ref": "#/definitions/dto.AttendanceEventResponse"
                         }
                     },
                     "400": {
@@ -148,38 +110,99 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/api/personnel/staff/{id}": {
-            "put": {
-                "description": "Updates a staff member",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/personnel/attendance/events": {
+            "get": {
+                "description": "Lists attendance events of the hospital in a date range (default last 7 days)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Personnel"
+                    "Attendance"
                 ],
-                "summary": "Personel günceller",
+                "summary": "Giriş/çıkış kayıtlarını listeler",
                 "parameters": [
                     {
                         "type": "integer",
                         "description": "Staff ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "name": "staff_id",
+                        "in": "query"
                     },
                     {
-                        "description": "Staff info",
-                        "name": "staff",
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AttendanceEventResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Records an attendance event on behalf of a staff member; a reason is required",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Elle giriş/çıkış kaydı ekler",
+                "parameters": [
+                    {
+                        "description": "Attendance event",
+                        "name": "event",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateStaffRequest"
+                            "$ref": "#/definitions/dto.ManualAttendanceRequest"
                         }
                     }
                 ],
@@ -187,7 +210,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.StaffResponse"
+                            "$ref": "#/definitions/dto.AttendanceEventResponse"
                         }
                     },
                     "400": {
@@ -200,34 +223,77 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes a staff member",
+            }
+        },
+        "/api/personnel/attendance/kiosk/qr": {
+            "get": {
+                "description": "Returns the rotating QR token for the kiosk identified by the X-Kiosk-Key header",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Personnel"
+                    "Attendance"
                 ],
-                "summary": "Personel siler",
+                "summary": "Kiosk ekranı için güncel QR içeriğini döner",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Staff ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Kiosk API key",
+                        "name": "X-Kiosk-Key",
+                        "in": "header",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.KioskQRResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            }
+        },
+        "/api/personnel/attendance/timesheets": {
+            "get": {
+                "description": "Returns scheduled, worked and overtime minutes, absences and leave days per staff for a month",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Aylık puantajı getirir",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Month (YYYY-MM)",
+                        "name": "month",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Staff ID",
+                        "name": "staff_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TimesheetResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -241,33 +307,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/personnel/titles": {
+        "/api/personnel/attendance/timesheets/export": {
             "get": {
-                "description": "Returns all titles for a given job group",
+                "description": "Exports the monthly timesheet summary as CSV for payroll",
                 "produces": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "tags": [
-                    "Personnel"
+                    "Attendance"
                 ],
-                "summary": "Seçili meslek grubuna ait unvanları listeler",
+                "summary": "Aylık puantajı CSV olarak indirir",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Job Group ID",
-                        "name": "job_group_id",
+                        "type": "string",
+                        "description": "Month (YYYY-MM)",
+                        "name": "month",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Staff ID",
+                        "name": "staff_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.TitleLookup"
-                            }
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -281,52 +350,3030 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.AddStaffRequest": {
-            "type": "object",
-            "properties": {
-                "first_name": {
-                    "type": "string"
-                },
-                "hospital_polyclinic_id": {
-                    "type": "integer"
-                },
-                "job_group_id": {
-                    "type": "integer"
-                },
-                "last_name": {
-                    "type": "string"
-                },
-                "phone": {
-                    "type": "string"
-                },
-                "tc": {
-                    "type": "string"
-                },
-                "title_id": {
-                    "type": "integer"
-                },
-                "working_days": {
-                    "type": "string"
+        },
+        "/api/personnel/certifications/expiring": {
+            "get": {
+                "description": "Lists certifications of the hospital that expire within the given number of days, including already expired ones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Certification"
+                ],
+                "summary": "Süresi yaklaşan sertifikaları listeler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Days ahead (default 30, max 365)",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ExpiringCertificationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
                 }
             }
         },
-        "dto.JobGroupLookup": {
+        "/api/personnel/certifications/{id}": {
+            "put": {
+                "description": "Updates a certification; changing the expiry date re-enables the expiry notification",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Certification"
+                ],
+                "summary": "Sertifikayı günceller",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Certification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Certification",
+                        "name": "certification",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CertificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CertificationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a certification together with its document",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Certification"
+                ],
+                "summary": "Sertifikayı siler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Certification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/certifications/{id}/document": {
+            "get": {
+                "description": "Downloads the uploaded document of a certification",
+                "produces": [
+                    "application/pdf",
+                    "image/jpeg",
+                    "image/png"
+                ],
+                "tags": [
+                    "Certification"
+                ],
+                "summary": "Sertifika belgesini indirir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Certification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Uploads the scanned document of a certification (PDF, JPEG or PNG, max 3 MB), replacing any previous one",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Certification"
+                ],
+                "summary": "Sertifika belgesini yükler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Certification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Document",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CertificationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/job-groups": {
+            "get": {
+                "description": "Returns all job groups",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Personnel"
+                ],
+                "summary": "Tüm meslek gruplarını listeler",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.JobGroupLookup"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/kiosks": {
+            "get": {
+                "description": "Lists the kiosks of the hospital",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Kioskları listeler",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.KioskResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers a clock-in kiosk for the hospital; the API key is returned only once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Kiosk tanımlar",
+                "parameters": [
+                    {
+                        "description": "Kiosk info",
+                        "name": "kiosk",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateKioskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.KioskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/kiosks/{id}": {
+            "delete": {
+                "description": "Deactivates a kiosk; its key and QR codes stop working",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Kioskı devre dışı bırakır",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Kiosk ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/leave-requests": {
+            "get": {
+                "description": "Lists leave requests of the hospital",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "İzin taleplerini listeler (filtreli ve sayfalı)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pending | approved | rejected | cancelled",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Staff ID",
+                        "name": "staff_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LeaveRequestListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a pending leave request; overlapping published on-call duties are listed in conflicts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "İzin talebi oluşturur",
+                "parameters": [
+                    {
+                        "description": "Leave request",
+                        "name": "leave",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateLeaveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LeaveRequestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/leave-requests/{id}/approve": {
+            "post": {
+                "description": "Approves a pending leave request; fails with 409 if it overlaps published duties unless force=true",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "İzin talebini onaylar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Leave request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Approve even if it overlaps published on-call duties",
+                        "name": "force",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Review note",
+                        "name": "review",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReviewLeaveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LeaveRequestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/leave-requests/{id}/cancel": {
+            "post": {
+                "description": "Cancels a pending or approved leave request; approved days return to the balance",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "İzin talebini iptal eder",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Leave request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LeaveRequestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/leave-requests/{id}/reject": {
+            "post": {
+                "description": "Rejects a pending leave request",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "İzin talebini reddeder",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Leave request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review note",
+                        "name": "review",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReviewLeaveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LeaveRequestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/leave-types": {
+            "get": {
+                "description": "Returns all leave types with their default yearly entitlement",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "İzin türlerini listeler",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.LeaveTypeResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/on-duty": {
+            "get": {
+                "description": "Lists staff whose shifts overlap the given time window in a hospital polyclinic",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Poliklinikte belirli gün ve saatlerde çalışan personeli listeler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hospital Polyclinic ID",
+                        "name": "hospital_polyclinic_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HH:MM (default 00:00)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "HH:MM (default end of day)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.OnDutyStaff"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/rosters": {
+            "get": {
+                "description": "Lists rosters of the hospital; employees only see published rosters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roster"
+                ],
+                "summary": "Nöbet listelerini listeler",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "draft | published",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RosterListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/rosters/generate": {
+            "post": {
+                "description": "Generates a draft on-call roster for the hospital or a polyclinic over a date range",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roster"
+                ],
+                "summary": "Nöbet listesi taslağı üretir",
+                "parameters": [
+                    {
+                        "description": "Roster rules",
+                        "name": "roster",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GenerateRosterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RosterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/rosters/{id}": {
+            "get": {
+                "description": "Returns a roster with its days, per-staff load and rule violations",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roster"
+                ],
+                "summary": "Nöbet listesini kural ihlalleri ve yük dağılımıyla getirir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Roster ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RosterResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a draft roster",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roster"
+                ],
+                "summary": "Taslak nöbet listesini siler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Roster ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/rosters/{id}/assignments": {
+            "post": {
+                "description": "Adds a duty to a draft roster; rule violations are reported in issues",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roster"
+                ],
+                "summary": "Taslak nöbet listesine nöbet ekler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Roster ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Assignment",
+                        "name": "assignment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RosterAssignmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RosterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/rosters/{id}/assignments/{assignmentId}": {
+            "delete": {
+                "description": "Removes a duty from a draft roster",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roster"
+                ],
+                "summary": "Taslak nöbet listesinden nöbet çıkarır",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Roster ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Assignment ID",
+                        "name": "assignmentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RosterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/rosters/{id}/publish": {
+            "post": {
+                "description": "Publishes a draft roster; fails with 409 and the issues unless force=true",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roster"
+                ],
+                "summary": "Nöbet listesini yayınlar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Roster ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Publish even if there are issues",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RosterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/staff": {
+            "get": {
+                "description": "Lists staff with filters, stable sorting and page or cursor pagination. When cursor is given page is ignored; next_cursor is empty on the last page. TC numbers are masked and the tc filter is rejected unless the caller is yetkili.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Personnel"
+                ],
+                "summary": "Personelleri listeler (filtreli, sıralı ve sayfalı)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (max 100)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "name",
+                        "description": "name | title | created_at | polyclinic",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "asc | desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First name",
+                        "name": "first_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last name",
+                        "name": "last_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "TC Kimlik No",
+                        "name": "tc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Job Group ID",
+                        "name": "job_group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Title ID",
+                        "name": "title_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hospital polyclinic ID or 'unassigned'",
+                        "name": "hospital_polyclinic_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Weekday with a weekly shift (0 = Sunday)",
+                        "name": "working_day",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StaffListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a new staff member",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Personnel"
+                ],
+                "summary": "Personel ekler",
+                "parameters": [
+                    {
+                        "description": "Staff info",
+                        "name": "staff",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AddStaffRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StaffResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/staff/export": {
+            "get": {
+                "description": "Exports every staff member matching the list filters as CSV, XLSX or PDF. The file is streamed, not paginated. TC numbers are masked and the tc filter is rejected unless the caller is yetkili. CSV cells that could be read as formulas are prefixed with an apostrophe.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Personnel"
+                ],
+                "summary": "Personel listesini dosya olarak dışa aktarır",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export format (csv, xlsx, pdf)",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First name",
+                        "name": "first_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last name",
+                        "name": "last_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "TC Kimlik No",
+                        "name": "tc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Job Group ID",
+                        "name": "job_group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Title ID",
+                        "name": "title_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hospital polyclinic ID or 'unassigned'",
+                        "name": "hospital_polyclinic_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Weekday with a weekly shift (0 = Sunday)",
+                        "name": "working_day",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/staff/import": {
+            "post": {
+                "description": "Starts a bulk staff import from a CSV or XLSX file. Columns are matched by header name (Ad, Soyad, TC, Telefon, Meslek Grubu, Unvan, Poliklinik) unless a columns mapping is given. The file is validated and imported in the background; poll the job for the result. In dry-run mode nothing is saved.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Personnel"
+                ],
+                "summary": "Dosyadan toplu personel aktarır",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV or XLSX file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON mapping of fields to header names, e.g. {\\",
+                        "name": "columns",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate, do not import",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StaffImportJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/staff/import/{id}": {
+            "get": {
+                "description": "Returns the status of a staff import job with per-row validation errors",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Personnel"
+                ],
+                "summary": "Toplu aktarım işinin durumunu getirir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Import job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StaffImportJobResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/staff/search": {
+            "get": {
+                "description": "Ranked search over name, title, TC prefix and phone. Turkish characters are folded, so \"sukru\" matches \"Şükrü\". Every word of q must match. TC numbers are masked and not searched unless the caller is yetkili.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Personnel"
+                ],
+                "summary": "Personel arar (Türkçe karakter duyarsız)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text (min 2 characters)",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max results (default 20, max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StaffSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/staff/{id}": {
+            "put": {
+                "description": "Updates personal details and the weekly schedule of a staff member. hospital_polyclinic_id, job_group_id and title_id must match the current assignment; change them with POST /api/personnel/staff/{id}/transfers.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Personnel"
+                ],
+                "summary": "Personel günceller",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Staff ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Staff info",
+                        "name": "staff",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateStaffRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StaffResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a staff member",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Personnel"
+                ],
+                "summary": "Personel siler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Staff ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/staff/{id}/assignments": {
+            "get": {
+                "description": "Lists the effective-dated polyclinic, job group and title history of a staff member, newest first. Works for deleted staff too.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Assignment"
+                ],
+                "summary": "Personelin atama geçmişini listeler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Staff ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.StaffAssignmentResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/staff/{id}/certifications": {
+            "get": {
+                "description": "Lists certifications of a staff member, soonest expiry first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Certification"
+                ],
+                "summary": "Personelin sertifikalarını listeler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Staff ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CertificationResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a diploma, specialty certificate, BLS/ACLS or other certification to a staff member. BLS and ACLS require an expiry date.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Certification"
+                ],
+                "summary": "Personele sertifika ekler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Staff ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Certification",
+                        "name": "certification",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CertificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CertificationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/staff/{id}/leave-balance": {
+            "get": {
+                "description": "Returns entitlement, used, pending and remaining days per leave type",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "Personelin izin bakiyesini getirir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Staff ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Year (default current year)",
+                        "name": "year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LeaveBalanceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/staff/{id}/leave-entitlements": {
+            "put": {
+                "description": "Sets the yearly entitlement of a staff member for a leave type",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "Personelin yıllık izin hakkını belirler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Staff ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Entitlement",
+                        "name": "entitlement",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetLeaveEntitlementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/staff/{id}/schedule": {
+            "get": {
+                "description": "Returns the weekly shifts and the date overrides of a staff member",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Personelin haftalık vardiyalarını ve gün istisnalarını getirir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Staff ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD (default today)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD (default from + 30 days)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StaffScheduleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the weekly shift template of a staff member",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Personelin haftalık vardiyalarını günceller",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Staff ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Weekly shifts",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.WeeklyShift"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/staff/{id}/schedule/overrides/{date}": {
+            "put": {
+                "description": "Replaces the shifts of a staff member for a single date (or marks the day off)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Personelin bir günlük vardiyasını değiştirir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Staff ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Override",
+                        "name": "override",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ShiftOverrideRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ShiftOverrideResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes the override of a date so the weekly template applies again",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Günlük vardiya istisnasını kaldırır",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Staff ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/staff/{id}/transfers": {
+            "post": {
+                "description": "Transfers a staff member to another polyclinic and/or changes job group and title from the effective date. The change is recorded in the assignment history with the reason and the approving authority.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Assignment"
+                ],
+                "summary": "Personeli başka polikliniğe taşır ya da unvanını değiştirir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Staff ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transfer",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferStaffRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StaffAssignmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/personnel/titles": {
+            "get": {
+                "description": "Returns all titles for a given job group",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Personnel"
+                ],
+                "summary": "Seçili meslek grubuna ait unvanları listeler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job Group ID",
+                        "name": "job_group_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TitleLookup"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AddStaffRequest": {
+            "type": "object",
+            "properties": {
+                "first_name": {
+                    "type": "string"
+                },
+                "hospital_polyclinic_id": {
+                    "type": "integer"
+                },
+                "job_group_id": {
+                    "type": "integer"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "schedule": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WeeklyShift"
+                    }
+                },
+                "tc": {
+                    "type": "string"
+                },
+                "title_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AttendanceEventResponse": {
+            "type": "object",
+            "properties": {
+                "created_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kiosk_id": {
+                    "type": "integer"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "staff_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CertificationDocumentInfo": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "uploaded_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CertificationRequest": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "YYYY-MM-DD, süresiz belgelerde boş",
+                    "type": "string"
+                },
+                "issued_at": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "issuer": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "diploma | specialty | bls | acls | other",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CertificationResponse": {
+            "type": "object",
+            "properties": {
+                "days_left": {
+                    "description": "DaysLeft bugünden bitişe kalan gündür; süresi geçmişse negatif, süresizse boştur",
+                    "type": "integer"
+                },
+                "document": {
+                    "$ref": "#/definitions/dto.CertificationDocumentInfo"
+                },
+                "expired": {
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "issued_at": {
+                    "type": "string"
+                },
+                "issuer": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "staff_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateKioskRequest": {
+            "type": "object",
+            "properties": {
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateLeaveRequest": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "leave_type_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "staff_id": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ExpiringCertification": {
+            "type": "object",
+            "properties": {
+                "days_left": {
+                    "description": "DaysLeft bugünden bitişe kalan gündür; süresi geçmişse negatif, süresizse boştur",
+                    "type": "integer"
+                },
+                "document": {
+                    "$ref": "#/definitions/dto.CertificationDocumentInfo"
+                },
+                "expired": {
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "issued_at": {
+                    "type": "string"
+                },
+                "issuer": {
+                    "type": "string"
+                },
+                "job_group_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "staff_id": {
+                    "type": "integer"
+                },
+                "title_name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ExpiringCertificationsResponse": {
+            "type": "object",
+            "properties": {
+                "certifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ExpiringCertification"
+                    }
+                },
+                "days": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GenerateRosterRequest": {
+            "type": "object",
+            "properties": {
+                "duty_end": {
+                    "description": "HH:MM, varsayılan 08:00 (24 saat)",
+                    "type": "string"
+                },
+                "duty_start": {
+                    "description": "HH:MM, varsayılan 08:00",
+                    "type": "string"
+                },
+                "end_date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "holidays": {
+                    "description": "YYYY-MM-DD",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "hospital_polyclinic_id": {
+                    "description": "boşsa hastanenin tamamı",
+                    "type": "integer"
+                },
+                "max_shifts_per_staff": {
+                    "description": "varsayılan 8",
+                    "type": "integer"
+                },
+                "min_rest_hours": {
+                    "description": "nöbet bitişinden sonraki en az dinlenme, varsayılan 24",
+                    "type": "integer"
+                },
+                "requirements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RosterRequirement"
+                    }
+                },
+                "start_date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.JobGroupLookup": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.KioskClockRequest": {
+            "type": "object",
+            "properties": {
+                "phone": {
+                    "type": "string"
+                },
+                "tc": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "in | out, boşsa son kayda göre belirlenir",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.KioskQRResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "RFC3339",
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.KioskResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "api_key": {
+                    "description": "yalnızca oluşturulurken bir kez döner",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LeaveBalance": {
+            "type": "object",
+            "properties": {
+                "entitlement": {
+                    "type": "integer"
+                },
+                "leave_type_id": {
+                    "type": "integer"
+                },
+                "leave_type_name": {
+                    "type": "string"
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "remaining": {
+                    "type": "integer"
+                },
+                "tracked": {
+                    "type": "boolean"
+                },
+                "used": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.LeaveBalanceResponse": {
+            "type": "object",
+            "properties": {
+                "balances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LeaveBalance"
+                    }
+                },
+                "staff_id": {
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.LeaveConflict": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "roster_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.LeaveRequestListResponse": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer"
+                },
+                "requests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LeaveRequestResponse"
+                    }
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.LeaveRequestResponse": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LeaveConflict"
+                    }
+                },
+                "days": {
+                    "type": "integer"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "leave_type_id": {
+                    "type": "integer"
+                },
+                "leave_type_name": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "review_note": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "integer"
+                },
+                "staff_id": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LeaveTypeResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "yearly_days": {
+                    "description": "0 = bakiye takibi yok",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ManualAttendanceRequest": {
+            "type": "object",
+            "properties": {
+                "occurred_at": {
+                    "description": "RFC3339",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "staff_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "in | out",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.OnDutyShift": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "hospital_polyclinic_id": {
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.OnDutyStaff": {
+            "type": "object",
+            "properties": {
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "job_group_id": {
+                    "type": "integer"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "shifts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OnDutyShift"
+                    }
+                },
+                "title_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ReviewLeaveRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RosterAssignmentRequest": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "staff_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RosterAssignmentResponse": {
+            "type": "object",
+            "properties": {
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "job_group_id": {
+                    "type": "integer"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "staff_id": {
+                    "type": "integer"
+                },
+                "title_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RosterDay": {
+            "type": "object",
+            "properties": {
+                "assignments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RosterAssignmentResponse"
+                    }
+                },
+                "date": {
+                    "type": "string"
+                },
+                "holiday": {
+                    "type": "boolean"
+                },
+                "weekend": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.RosterIssue": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "job_group_id": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "missing": {
+                    "type": "integer"
+                },
+                "staff_id": {
+                    "type": "integer"
+                },
+                "title_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RosterListResponse": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer"
+                },
+                "rosters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RosterSummary"
+                    }
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RosterRequirement": {
+            "type": "object",
+            "properties": {
+                "job_group_id": {
+                    "type": "integer"
+                },
+                "min_staff": {
+                    "type": "integer"
+                },
+                "title_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RosterResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RosterDay"
+                    }
+                },
+                "duty_end": {
+                    "type": "string"
+                },
+                "duty_start": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "holidays": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "hospital_polyclinic_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RosterIssue"
+                    }
+                },
+                "max_shifts_per_staff": {
+                    "type": "integer"
+                },
+                "min_rest_hours": {
+                    "type": "integer"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "requirements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RosterRequirement"
+                    }
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "summary": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RosterStaffLoad"
+                    }
+                }
+            }
+        },
+        "dto.RosterStaffLoad": {
+            "type": "object",
+            "properties": {
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "staff_id": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "weekend_holiday": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RosterSummary": {
+            "type": "object",
+            "properties": {
+                "assignment_count": {
+                    "type": "integer"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "hospital_polyclinic_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SetLeaveEntitlementRequest": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "integer"
+                },
+                "leave_type_id": {
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ShiftInterval": {
+            "type": "object",
+            "properties": {
+                "end_time": {
+                    "type": "string"
+                },
+                "hospital_polyclinic_id": {
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ShiftOverrideRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "off": {
+                    "type": "boolean"
+                },
+                "shifts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ShiftInterval"
+                    }
+                }
+            }
+        },
+        "dto.ShiftOverrideResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "off": {
+                    "type": "boolean"
+                },
+                "shifts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ShiftInterval"
+                    }
+                }
+            }
+        },
+        "dto.StaffAsOf": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "false ise personel sonradan silinmiştir",
+                    "type": "boolean"
+                },
+                "approved_by": {
+                    "type": "integer"
+                },
+                "change_type": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "hospital_polyclinic_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "job_group_id": {
+                    "type": "integer"
+                },
+                "job_group_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "polyclinic_name": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "staff_id": {
+                    "type": "integer"
+                },
+                "title_id": {
+                    "type": "integer"
+                },
+                "title_name": {
+                    "type": "string"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "description": "hariç; boşsa güncel atamadır",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.StaffAsOfResponse": {
+            "type": "object",
+            "properties": {
+                "as_of": {
+                    "type": "string"
+                },
+                "staff": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StaffAsOf"
+                    }
+                }
+            }
+        },
+        "dto.StaffAssignmentResponse": {
             "type": "object",
             "properties": {
+                "approved_by": {
+                    "type": "integer"
+                },
+                "change_type": {
+                    "type": "string"
+                },
+                "hospital_polyclinic_id": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "job_group_id": {
+                    "type": "integer"
+                },
+                "job_group_name": {
+                    "type": "string"
+                },
+                "polyclinic_name": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "staff_id": {
+                    "type": "integer"
+                },
+                "title_id": {
+                    "type": "integer"
+                },
+                "title_name": {
+                    "type": "string"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "description": "hariç; boşsa güncel atamadır",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.StaffImportJobResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StaffImportRowError"
+                    }
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "imported_rows": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "pending | running | validated | completed | failed",
+                    "type": "string"
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "valid_rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.StaffImportRowError": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "string"
+                },
+                "message": {
                     "type": "string"
+                },
+                "row": {
+                    "type": "integer"
                 }
             }
         },
         "dto.StaffListResponse": {
             "type": "object",
             "properties": {
+                "next_cursor": {
+                    "description": "sonraki sayfa yoksa boştur",
+                    "type": "string"
+                },
                 "page": {
                     "type": "integer"
                 },
@@ -371,6 +3418,74 @@ const docTemplate = `{
                 "polyclinic_name": {
                     "type": "string"
                 },
+                "schedule": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WeeklyShift"
+                    }
+                },
+                "tc": {
+                    "type": "string"
+                },
+                "title_id": {
+                    "type": "integer"
+                },
+                "title_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.StaffScheduleResponse": {
+            "type": "object",
+            "properties": {
+                "overrides": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ShiftOverrideResponse"
+                    }
+                },
+                "staff_id": {
+                    "type": "integer"
+                },
+                "weekly": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WeeklyShift"
+                    }
+                }
+            }
+        },
+        "dto.StaffSearchHit": {
+            "type": "object",
+            "properties": {
+                "first_name": {
+                    "type": "string"
+                },
+                "hospital_polyclinic_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "job_group_id": {
+                    "type": "integer"
+                },
+                "job_group_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "polyclinic_name": {
+                    "type": "string"
+                },
+                "score": {
+                    "description": "yalnızca sıralama içindir, sorgular arasında karşılaştırılamaz",
+                    "type": "integer"
+                },
                 "tc": {
                     "type": "string"
                 },
@@ -379,9 +3494,102 @@ const docTemplate = `{
                 },
                 "title_name": {
                     "type": "string"
+                }
+            }
+        },
+        "dto.StaffSearchResponse": {
+            "type": "object",
+            "properties": {
+                "query": {
+                    "description": "katlanmış hali; \"Şükrü\" -\u003e \"sukru\"",
+                    "type": "string"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StaffSearchHit"
+                    }
+                }
+            }
+        },
+        "dto.StaffTimesheet": {
+            "type": "object",
+            "properties": {
+                "absent_days": {
+                    "type": "integer"
+                },
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TimesheetDay"
+                    }
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "leave_days": {
+                    "type": "integer"
+                },
+                "overtime_minutes": {
+                    "type": "integer"
+                },
+                "scheduled_minutes": {
+                    "type": "integer"
+                },
+                "staff_id": {
+                    "type": "integer"
+                },
+                "tc": {
+                    "type": "string"
+                },
+                "worked_minutes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.TimesheetDay": {
+            "type": "object",
+            "properties": {
+                "absent": {
+                    "type": "boolean"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "on_leave": {
+                    "type": "boolean"
+                },
+                "overtime_minutes": {
+                    "type": "integer"
+                },
+                "scheduled_minutes": {
+                    "type": "integer"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "working_days": {
+                "worked_minutes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.TimesheetResponse": {
+            "type": "object",
+            "properties": {
+                "month": {
                     "type": "string"
+                },
+                "staffs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StaffTimesheet"
+                    }
                 }
             }
         },
@@ -396,6 +3604,42 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.TransferStaffRequest": {
+            "type": "object",
+            "properties": {
+                "effective_date": {
+                    "description": "YYYY-MM-DD, varsayılan bugün; ileri tarih olamaz",
+                    "type": "string"
+                },
+                "hospital_polyclinic_id": {
+                    "type": "integer"
+                },
+                "job_group_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "title_id": {
+                    "description": "meslek grubu değişiyorsa zorunlu",
+                    "type": "integer"
+                },
+                "unassign": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.UpdateScheduleRequest": {
+            "type": "object",
+            "properties": {
+                "shifts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WeeklyShift"
+                    }
+                }
+            }
+        },
         "dto.UpdateStaffRequest": {
             "type": "object",
             "properties": {
@@ -414,14 +3658,38 @@ const docTemplate = `{
                 "phone": {
                     "type": "string"
                 },
+                "schedule": {
+                    "description": "gönderilmezse haftalık düzen değişmez",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WeeklyShift"
+                    }
+                },
                 "tc": {
                     "type": "string"
                 },
                 "title_id": {
                     "type": "integer"
+                }
+            }
+        },
+        "dto.WeeklyShift": {
+            "type": "object",
+            "properties": {
+                "end_time": {
+                    "description": "HH:MM",
+                    "type": "string"
+                },
+                "hospital_polyclinic_id": {
+                    "type": "integer"
                 },
-                "working_days": {
+                "start_time": {
+                    "description": "HH:MM",
                     "type": "string"
+                },
+                "weekday": {
+                    "description": "0 = Pazar ... 6 = Cumartesi",
+                    "type": "integer"
                 }
             }
         }
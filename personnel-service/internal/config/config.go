@@ -2,16 +2,25 @@ package config
 
 import (
 	"fmt"
+	"log"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	JWT      JWTConfig
-	Url      HospitalService `mapstructure:"hospital_service"`
+	Server        ServerConfig
+	Database      DatabaseConfig
+	Redis         RedisConfig
+	JWT           JWTConfig
+	Url           HospitalService `mapstructure:"hospital_service"`
+	Auth          AuthService     `mapstructure:"auth_service"`
+	Attendance    AttendanceConfig
+	Certification CertificationConfig
+	SMTP          SMTPConfig     `mapstructure:"smtp"`
+	Internal      InternalConfig `mapstructure:"internal"`
+	// Timezone gün sınırlarının (puantaj, izin, sayımlar, günlük işler) belirlendiği saat dilimidir
+	Timezone string `mapstructure:"timezone"`
 }
 
 type ServerConfig struct {
@@ -40,10 +49,51 @@ type JWTConfig struct {
 	RefreshTokenExpiry string `mapstructure:"refresh_token_expiry"`
 }
 
+// AttendanceConfig kiosk QR kodlarının yenilenme süresidir
+type AttendanceConfig struct {
+	QRStepSeconds int `mapstructure:"qr_step_seconds"`
+}
+
+// CertificationConfig günlük bildirim işinin ayarlarıdır. Saat, timezone'a göredir.
+type CertificationConfig struct {
+	ExpiryNoticeDays int `mapstructure:"expiry_notice_days"`
+	NotifyHour       int `mapstructure:"notify_hour"`
+}
+
+// SMTPConfig bildirim e-postaları için, host boşsa bildirimler sadece loglanır
+// Alanları notification.SMTPConfig ile aynı tutulmalı, notifier oluşturulurken ona dönüştürülür
+type SMTPConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     string `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	From     string `mapstructure:"from"`
+}
+
 type HospitalService struct {
 	BaseUrl string `mapstructure:"base_url"`
 }
 
+// AuthService bildirim gönderilecek yetkilileri okumak için kullanılır
+type AuthService struct {
+	BaseUrl string `mapstructure:"base_url"`
+}
+
+// InternalConfig servisler arası endpointleri koruyan paylaşılan anahtar, tüm servislerde aynı olmalı
+type InternalConfig struct {
+	Token string `mapstructure:"token"`
+}
+
+// Location Timezone'u yükler; bulunamazsa Türkiye saati kullanılır
+func (c *Config) Location() *time.Location {
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		log.Printf("timezone %q could not be loaded, using UTC+3: %v", c.Timezone, err)
+		return time.FixedZone("TRT", 3*60*60)
+	}
+	return loc
+}
+
 func LoadConfig(path string) (config Config, err error) {
 	viper.AddConfigPath(path)
 	viper.SetConfigName("config")
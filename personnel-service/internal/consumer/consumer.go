@@ -0,0 +1,31 @@
+// Package consumer personnel servisinin diğer servislerden gelen olaylara tepkilerini içerir
+package consumer
+
+import (
+	"context"
+	"time"
+
+	"hospital-shared/events"
+	"personnel-service/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+func Register(c *events.Consumer) {
+	c.On(events.HospitalPolyclinicRemoved, onHospitalPolyclinicRemoved)
+}
+
+// Kaldırılan polikliniğe bağlı personel olayda verilen polikliniğe taşınır, hedef yoksa
+// atamaları kaldırılır. O poliklinikte planlanmış vardiyalar da silinir.
+func onHospitalPolyclinicRemoved(ctx context.Context, tx *gorm.DB, e events.Event) error {
+	var p events.HospitalPolyclinicRemovedPayload
+	if err := e.Decode(&p); err != nil {
+		return err
+	}
+	if _, err := repository.NewPersonnelRepository(tx).ReassignStaff(p.HospitalID, &p.HospitalPolyclinicID, p.ReassignTo, nil); err != nil {
+		return err
+	}
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return repository.NewScheduleRepository(tx).DeletePolyclinicShifts(p.HospitalPolyclinicID, today)
+}
@@ -2,8 +2,14 @@ package database
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"hospital-shared/events"
 	"personnel-service/internal/models"
+	"personnel-service/internal/repository"
+	"personnel-service/pkg/utils"
 
 	"gorm.io/gorm"
 )
@@ -16,15 +22,267 @@ func RunMigrations(db *gorm.DB) error {
 		&models.JobGroup{},
 		&models.Title{},
 		&models.Staff{},
+		&models.ShiftTemplate{},
+		&models.ShiftOverride{},
+		&models.Roster{},
+		&models.RosterRequirement{},
+		&models.RosterHoliday{},
+		&models.RosterAssignment{},
+		&models.LeaveType{},
+		&models.LeaveEntitlement{},
+		&models.LeaveRequest{},
+		&models.Kiosk{},
+		&models.AttendanceEvent{},
+		&models.StaffImportJob{},
+		&models.StaffImportRowError{},
+		&models.Certification{},
+		&models.CertificationDocument{},
+		&models.StaffAssignment{},
 	)
 	if err != nil {
 		return err
 	}
+	if err := migrateWorkingDays(db); err != nil {
+		return fmt.Errorf("working days migration failed: %w", err)
+	}
+	if err := migrateStaffSearch(db); err != nil {
+		return fmt.Errorf("staff search migration failed: %w", err)
+	}
+	if err := migrateStaffAssignments(db); err != nil {
+		return fmt.Errorf("staff assignment migration failed: %w", err)
+	}
+	if err := migrateStaffPhones(db); err != nil {
+		return fmt.Errorf("staff phone migration failed: %w", err)
+	}
+	if err := events.Migrate(db); err != nil {
+		return err
+	}
+	if err := publishStaffCounts(db); err != nil {
+		return fmt.Errorf("staff count publish failed: %w", err)
+	}
+
+	if err := seedLeaveTypes(db); err != nil {
+		return err
+	}
 
 	// Seed data ekleme
 	return seedData(db)
 }
 
+// İzin türleri sonradan eklendiği için mevcut veritabanlarında da ayrıca eklenir
+func seedLeaveTypes(db *gorm.DB) error {
+	var count int64
+	db.Model(&models.LeaveType{}).Count(&count)
+	if count > 0 {
+		return nil
+	}
+
+	leaveTypes := []models.LeaveType{
+		{Code: "annual", Name: "Yıllık İzin", YearlyDays: 14},
+		{Code: "sick", Name: "Hastalık İzni"},
+		{Code: "administrative", Name: "İdari İzin", YearlyDays: 5},
+	}
+	for _, lt := range leaveTypes {
+		if err := db.Create(&lt).Error; err != nil {
+			return fmt.Errorf("failed to create leave type %s: %w", lt.Name, err)
+		}
+	}
+	return nil
+}
+
+// Eski working_days metninde saat bilgisi yoktu, gün başına bu vardiya varsayılır
+const (
+	legacyShiftStart = "08:00"
+	legacyShiftEnd   = "17:00"
+)
+
+// migrateWorkingDays "1,2,3,4,5" biçimindeki working_days kolonunu haftalık vardiyalara
+// dönüştürür ve kolonu kaldırır. Kolon yoksa (daha önce taşındıysa) bir şey yapmaz.
+// Eski biçimde 1 = Pazartesi ... 7 = Pazar'dır; 0 da Pazar kabul edilir.
+func migrateWorkingDays(db *gorm.DB) error {
+	if !db.Migrator().HasColumn("staffs", "working_days") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var rows []struct {
+			ID          uint
+			WorkingDays string
+		}
+		if err := tx.Table("staffs").Select("id, working_days").Where("deleted_at IS NULL").Scan(&rows).Error; err != nil {
+			return err
+		}
+
+		var shifts []models.ShiftTemplate
+		for _, row := range rows {
+			seen := make(map[time.Weekday]bool)
+			for _, part := range strings.Split(row.WorkingDays, ",") {
+				part = strings.TrimSpace(part)
+				if part == "" {
+					continue
+				}
+				day, err := strconv.Atoi(part)
+				if err != nil || day < 0 || day > 7 {
+					fmt.Printf("staff %d: skipping invalid working day %q\n", row.ID, part)
+					continue
+				}
+				weekday := time.Weekday(day % 7)
+				if seen[weekday] {
+					continue
+				}
+				seen[weekday] = true
+				shifts = append(shifts, models.ShiftTemplate{
+					StaffID:   row.ID,
+					Weekday:   weekday,
+					StartTime: legacyShiftStart,
+					EndTime:   legacyShiftEnd,
+				})
+			}
+		}
+
+		if len(shifts) > 0 {
+			if err := tx.CreateInBatches(&shifts, 500).Error; err != nil {
+				return err
+			}
+		}
+		fmt.Printf("Migrated working days of %d staff into %d weekly shifts\n", len(rows), len(shifts))
+		return tx.Migrator().DropColumn("staffs", "working_days")
+	})
+}
+
+// migrateStaffSearch arama indekslerini kurar ve search_text kolonu eklenmeden önceki kayıtları
+// doldurur. pg_trgm kurulamazsa (yetki yoksa) arama indekssiz de çalışır, yalnızca yavaşlar.
+func migrateStaffSearch(db *gorm.DB) error {
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		fmt.Printf("pg_trgm extension could not be created, staff search will not be indexed: %v\n", err)
+	} else {
+		if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_staffs_search_text_trgm ON staffs USING gin (search_text gin_trgm_ops)").Error; err != nil {
+			return err
+		}
+		// Telefon numarasının herhangi bir yerinde arama
+		if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_staffs_phone_trgm ON staffs USING gin (phone gin_trgm_ops)").Error; err != nil {
+			return err
+		}
+	}
+	// Unvan eşleşmesi unvan kimlikleri üzerinden aranır
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_staffs_title_id ON staffs (title_id)").Error; err != nil {
+		return err
+	}
+	// TC ön ek aramasının (LIKE '123%') indeksi kullanabilmesi için
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_staffs_tc_pattern ON staffs (tc text_pattern_ops)").Error; err != nil {
+		return err
+	}
+
+	var lastID uint
+	filled := 0
+	for {
+		var rows []struct {
+			ID        uint
+			FirstName string
+			LastName  string
+		}
+		err := db.Table("staffs").Select("id, first_name, last_name").
+			Where("search_text = '' AND id > ?", lastID).
+			Order("id").Limit(500).Scan(&rows).Error
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			break
+		}
+		err = db.Transaction(func(tx *gorm.DB) error {
+			for _, row := range rows {
+				text := models.StaffSearchText(row.FirstName, row.LastName)
+				if err := tx.Table("staffs").Where("id = ?", row.ID).Update("search_text", text).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		filled += len(rows)
+		lastID = rows[len(rows)-1].ID
+	}
+	if filled > 0 {
+		fmt.Printf("Filled search text of %d staff\n", filled)
+	}
+	return nil
+}
+
+// migrateStaffAssignments her personel için tek açık atama olmasını garanti eder. Geçmiş tutulmaya
+// başlamadan önce eklenmiş personelin o anki durumu ancak geçiş gününden itibaren bilindiğinden
+// dönemi o gün başlar; öncesi sorulduğunda personel bilinmiyor sayılır. O güne kadar silinmiş
+// personelin hiç bilinen dönemi olmadığından geçmişi yazılmaz.
+func migrateStaffAssignments(db *gorm.DB) error {
+	if err := db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_staff_assignments_open ON staff_assignments (staff_id) WHERE valid_to IS NULL").Error; err != nil {
+		return err
+	}
+
+	res := db.Exec(`INSERT INTO staff_assignments
+		(staff_id, hospital_id, hospital_polyclinic_id, job_group_id, title_id, valid_from, valid_to, change_type, reason, created_at)
+		SELECT s.id, s.hospital_id, s.hospital_polyclinic_id, s.job_group_id, s.title_id,
+			CURRENT_DATE, NULL, ?, '', NOW()
+		FROM staffs s
+		WHERE s.deleted_at IS NULL AND NOT EXISTS (SELECT 1 FROM staff_assignments a WHERE a.staff_id = s.id)`, models.AssignmentBackfill)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		fmt.Printf("Backfilled assignment history of %d staff\n", res.RowsAffected)
+	}
+	return nil
+}
+
+// migrateStaffPhones normalize edilmeden önce kaydedilmiş telefonları 5XXXXXXXXX biçimine getirir;
+// tekrar kontrolleri ve kiosk araması tam eşleşmeyle çalışır. Çözülemeyen ya da normalize hali başka
+// bir personelde olan numaralar olduğu gibi bırakılıp loglanır.
+func migrateStaffPhones(db *gorm.DB) error {
+	var rows []struct {
+		ID    uint
+		Phone string
+	}
+	if err := db.Table("staffs").Select("id, phone").Where("phone !~ '^5[0-9]{9}$'").Order("id").Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	migrated := 0
+	for _, row := range rows {
+		phone, ok := utils.NormalizePhone(row.Phone)
+		if !ok {
+			fmt.Printf("Staff %d has an invalid phone number %q, left as is\n", row.ID, row.Phone)
+			continue
+		}
+		res := db.Exec("UPDATE staffs SET phone = ? WHERE id = ? AND NOT EXISTS (SELECT 1 FROM staffs WHERE phone = ?)", phone, row.ID, phone)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			fmt.Printf("Staff %d phone %q normalizes to %s which is already taken, left as is\n", row.ID, row.Phone, phone)
+			continue
+		}
+		migrated++
+	}
+	if migrated > 0 {
+		fmt.Printf("Normalized phone numbers of %d staff\n", migrated)
+	}
+	return nil
+}
+
+// publishStaffCounts hospital servisindeki personel sayısı projeksiyonunu doldurur;
+// resync-staff-stats'in elle çalıştırılmasına gerek kalmaz
+func publishStaffCounts(db *gorm.DB) error {
+	hospitals, err := repository.PublishStaffCounts(db)
+	if err != nil {
+		return err
+	}
+	if hospitals > 0 {
+		fmt.Printf("Published staff counts of %d hospitals\n", hospitals)
+	}
+	return nil
+}
+
 func seedData(db *gorm.DB) error {
 	// JobGroup seed data ekleme
 	var jobGroupCount int64
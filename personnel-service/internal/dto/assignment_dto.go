@@ -0,0 +1,41 @@
+package dto
+
+// TransferStaffRequest personelin poliklinik ve/veya unvan değişikliğidir. Gönderilmeyen alanlar
+// değişmez; polikliniği kaldırmak için unassign kullanılır.
+type TransferStaffRequest struct {
+	HospitalPolyclinicID *uint  `json:"hospital_polyclinic_id"`
+	Unassign             bool   `json:"unassign"`
+	JobGroupID           *uint  `json:"job_group_id"`
+	TitleID              *uint  `json:"title_id"`       // meslek grubu değişiyorsa zorunlu
+	EffectiveDate        string `json:"effective_date"` // YYYY-MM-DD, varsayılan bugün; ileri tarih olamaz
+	Reason               string `json:"reason"`
+}
+
+type StaffAssignmentResponse struct {
+	ID                   uint    `json:"id"`
+	StaffID              uint    `json:"staff_id"`
+	HospitalPolyclinicID *uint   `json:"hospital_polyclinic_id"`
+	PolyclinicName       *string `json:"polyclinic_name"`
+	JobGroupID           uint    `json:"job_group_id"`
+	JobGroupName         string  `json:"job_group_name"`
+	TitleID              uint    `json:"title_id"`
+	TitleName            string  `json:"title_name"`
+	ValidFrom            string  `json:"valid_from"`
+	ValidTo              *string `json:"valid_to"` // hariç; boşsa güncel atamadır
+	ChangeType           string  `json:"change_type"`
+	Reason               string  `json:"reason"`
+	ApprovedBy           *uint   `json:"approved_by"`
+}
+
+// StaffAsOf personelin istenen gündeki atamasıdır
+type StaffAsOf struct {
+	StaffAssignmentResponse
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Active    bool   `json:"active"` // false ise personel sonradan silinmiştir
+}
+
+type StaffAsOfResponse struct {
+	AsOf  string      `json:"as_of"`
+	Staff []StaffAsOf `json:"staff"`
+}
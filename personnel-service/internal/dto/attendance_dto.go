@@ -0,0 +1,87 @@
+package dto
+
+type CreateKioskRequest struct {
+	Name     string `json:"name"`
+	Location string `json:"location"`
+}
+
+type KioskResponse struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	Location string `json:"location"`
+	Active   bool   `json:"active"`
+	APIKey   string `json:"api_key,omitempty"` // yalnızca oluşturulurken bir kez döner
+}
+
+// KioskQRResponse kiosk ekranında gösterilecek QR içeriğidir
+type KioskQRResponse struct {
+	Token     string `json:"token"`
+	ExpiresAt string `json:"expires_at"` // RFC3339
+}
+
+// KioskClockRequest personelin telefonundan kiosk QR'ı okutarak gönderdiği istektir.
+// QR kiosk başında olunduğunu, TC ve telefon personeli kanıtlar
+type KioskClockRequest struct {
+	Token string `json:"token"`
+	TC    string `json:"tc"`
+	Phone string `json:"phone"`
+	Type  string `json:"type"` // in | out, boşsa son kayda göre belirlenir
+}
+
+type ManualAttendanceRequest struct {
+	StaffID    uint   `json:"staff_id"`
+	Type       string `json:"type"`        // in | out
+	OccurredAt string `json:"occurred_at"` // RFC3339
+	Reason     string `json:"reason"`
+}
+
+type AttendanceEventResponse struct {
+	ID         uint   `json:"id"`
+	StaffID    uint   `json:"staff_id"`
+	Type       string `json:"type"`
+	OccurredAt string `json:"occurred_at"`
+	Source     string `json:"source"`
+	KioskID    *uint  `json:"kiosk_id,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	CreatedBy  *uint  `json:"created_by,omitempty"`
+}
+
+type AttendanceEventFilter struct {
+	StaffID *uint
+	From    string // YYYY-MM-DD
+	To      string // YYYY-MM-DD
+}
+
+type TimesheetFilter struct {
+	Month   string // YYYY-MM
+	StaffID *uint
+}
+
+// TimesheetDay bir günün planlanan ve çalışılan süreleridir (dakika)
+type TimesheetDay struct {
+	Date             string   `json:"date"`
+	ScheduledMinutes int      `json:"scheduled_minutes"`
+	WorkedMinutes    int      `json:"worked_minutes"`
+	OvertimeMinutes  int      `json:"overtime_minutes"`
+	OnLeave          bool     `json:"on_leave"`
+	Absent           bool     `json:"absent"`
+	Warnings         []string `json:"warnings,omitempty"`
+}
+
+type StaffTimesheet struct {
+	StaffID          uint           `json:"staff_id"`
+	TC               string         `json:"tc"`
+	FirstName        string         `json:"first_name"`
+	LastName         string         `json:"last_name"`
+	ScheduledMinutes int            `json:"scheduled_minutes"`
+	WorkedMinutes    int            `json:"worked_minutes"`
+	OvertimeMinutes  int            `json:"overtime_minutes"`
+	AbsentDays       int            `json:"absent_days"`
+	LeaveDays        int            `json:"leave_days"`
+	Days             []TimesheetDay `json:"days"`
+}
+
+type TimesheetResponse struct {
+	Month  string           `json:"month"`
+	Staffs []StaffTimesheet `json:"staffs"`
+}
@@ -0,0 +1,44 @@
+package dto
+
+type CertificationRequest struct {
+	Type      string  `json:"type"` // diploma | specialty | bls | acls | other
+	Number    string  `json:"number"`
+	Issuer    string  `json:"issuer"`
+	IssuedAt  string  `json:"issued_at"`  // YYYY-MM-DD
+	ExpiresAt *string `json:"expires_at"` // YYYY-MM-DD, süresiz belgelerde boş
+}
+
+type CertificationDocumentInfo struct {
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	UploadedAt  string `json:"uploaded_at"`
+}
+
+type CertificationResponse struct {
+	ID        uint    `json:"id"`
+	StaffID   uint    `json:"staff_id"`
+	Type      string  `json:"type"`
+	Number    string  `json:"number"`
+	Issuer    string  `json:"issuer"`
+	IssuedAt  string  `json:"issued_at"`
+	ExpiresAt *string `json:"expires_at"`
+	// DaysLeft bugünden bitişe kalan gündür; süresi geçmişse negatif, süresizse boştur
+	DaysLeft *int                       `json:"days_left"`
+	Expired  bool                       `json:"expired"`
+	Document *CertificationDocumentInfo `json:"document"`
+}
+
+// ExpiringCertification süresi yaklaşan ya da geçmiş sertifikadır
+type ExpiringCertification struct {
+	CertificationResponse
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	JobGroupName string `json:"job_group_name"`
+	TitleName    string `json:"title_name"`
+}
+
+type ExpiringCertificationsResponse struct {
+	Days           int                     `json:"days"`
+	Certifications []ExpiringCertification `json:"certifications"`
+}
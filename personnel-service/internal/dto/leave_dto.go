@@ -0,0 +1,82 @@
+package dto
+
+type LeaveTypeResponse struct {
+	ID         uint   `json:"id"`
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	YearlyDays int    `json:"yearly_days"` // 0 = bakiye takibi yok
+}
+
+type SetLeaveEntitlementRequest struct {
+	LeaveTypeID uint `json:"leave_type_id"`
+	Year        int  `json:"year"`
+	Days        int  `json:"days"`
+}
+
+// LeaveBalance bir izin türü için yıllık hak, kullanılan ve bekleyen gün sayısıdır.
+// Tracked false ise tür için bakiye takibi yapılmaz ve remaining anlamsızdır
+type LeaveBalance struct {
+	LeaveTypeID   uint   `json:"leave_type_id"`
+	LeaveTypeName string `json:"leave_type_name"`
+	Tracked       bool   `json:"tracked"`
+	Entitlement   int    `json:"entitlement"`
+	Used          int    `json:"used"`
+	Pending       int    `json:"pending"`
+	Remaining     int    `json:"remaining"`
+}
+
+type LeaveBalanceResponse struct {
+	StaffID  uint           `json:"staff_id"`
+	Year     int            `json:"year"`
+	Balances []LeaveBalance `json:"balances"`
+}
+
+type CreateLeaveRequest struct {
+	StaffID     uint   `json:"staff_id"`
+	LeaveTypeID uint   `json:"leave_type_id"`
+	StartDate   string `json:"start_date"` // YYYY-MM-DD
+	EndDate     string `json:"end_date"`   // YYYY-MM-DD
+	Reason      string `json:"reason"`
+}
+
+type ReviewLeaveRequest struct {
+	Note string `json:"note"`
+}
+
+// LeaveConflict izin aralığına denk gelen yayınlanmış nöbettir
+type LeaveConflict struct {
+	Date     string `json:"date"`
+	RosterID uint   `json:"roster_id"`
+}
+
+type LeaveRequestResponse struct {
+	ID            uint            `json:"id"`
+	StaffID       uint            `json:"staff_id"`
+	FirstName     string          `json:"first_name"`
+	LastName      string          `json:"last_name"`
+	LeaveTypeID   uint            `json:"leave_type_id"`
+	LeaveTypeName string          `json:"leave_type_name"`
+	StartDate     string          `json:"start_date"`
+	EndDate       string          `json:"end_date"`
+	Days          int             `json:"days"`
+	Status        string          `json:"status"`
+	Reason        string          `json:"reason,omitempty"`
+	ReviewedBy    *uint           `json:"reviewed_by,omitempty"`
+	ReviewedAt    *string         `json:"reviewed_at,omitempty"`
+	ReviewNote    string          `json:"review_note,omitempty"`
+	Conflicts     []LeaveConflict `json:"conflicts"`
+}
+
+type LeaveRequestListFilter struct {
+	Status  string
+	StaffID *uint
+	From    string // YYYY-MM-DD, bu tarihte ya da sonrasında biten izinler
+	To      string // YYYY-MM-DD, bu tarihte ya da öncesinde başlayan izinler
+}
+
+type LeaveRequestListResponse struct {
+	Requests []LeaveRequestResponse `json:"requests"`
+	Total    int                    `json:"total"`
+	Page     int                    `json:"page"`
+	Size     int                    `json:"size"`
+}
@@ -11,17 +11,81 @@ type TitleLookup struct {
 }
 
 type AddStaffRequest struct {
-	FirstName            string `json:"first_name"`
-	LastName             string `json:"last_name"`
-	TC                   string `json:"tc"`
-	Phone                string `json:"phone"`
-	JobGroupID           uint   `json:"job_group_id"`
-	TitleID              uint   `json:"title_id"`
-	HospitalPolyclinicID *uint  `json:"hospital_polyclinic_id"`
-	WorkingDays          string `json:"working_days"`
+	FirstName            string        `json:"first_name"`
+	LastName             string        `json:"last_name"`
+	TC                   string        `json:"tc"`
+	Phone                string        `json:"phone"`
+	JobGroupID           uint          `json:"job_group_id"`
+	TitleID              uint          `json:"title_id"`
+	HospitalPolyclinicID *uint         `json:"hospital_polyclinic_id"`
+	Schedule             []WeeklyShift `json:"schedule"`
 }
 
 type StaffResponse struct {
+	ID                   uint          `json:"id"`
+	FirstName            string        `json:"first_name"`
+	LastName             string        `json:"last_name"`
+	TC                   string        `json:"tc"`
+	Phone                string        `json:"phone"`
+	JobGroupID           uint          `json:"job_group_id"`
+	JobGroupName         string        `json:"job_group_name"`
+	TitleID              uint          `json:"title_id"`
+	TitleName            string        `json:"title_name"`
+	HospitalPolyclinicID *uint         `json:"hospital_polyclinic_id"`
+	PolyclinicName       *string       `json:"polyclinic_name"`
+	Schedule             []WeeklyShift `json:"schedule"`
+}
+
+// UpdateStaffRequest atama alanları mevcut atamayla aynı gönderilmeli; değişiklik transferle yapılır
+type UpdateStaffRequest struct {
+	FirstName            string        `json:"first_name"`
+	LastName             string        `json:"last_name"`
+	TC                   string        `json:"tc"`
+	Phone                string        `json:"phone"`
+	JobGroupID           uint          `json:"job_group_id"`
+	TitleID              uint          `json:"title_id"`
+	HospitalPolyclinicID *uint         `json:"hospital_polyclinic_id"`
+	Schedule             []WeeklyShift `json:"schedule"` // gönderilmezse haftalık düzen değişmez
+}
+
+type StaffListFilter struct {
+	FirstName            string
+	LastName             string
+	TC                   string
+	JobGroupID           *uint
+	TitleID              *uint
+	HospitalPolyclinicID *uint
+	Unassigned           bool // yalnızca polikliniğe atanmamış personel
+	WorkingDay           *int // haftalık vardiyası olan gün, 0 = Pazar ... 6 = Cumartesi
+}
+
+// Personel listesinin sıralanabildiği alanlar
+const (
+	StaffSortName       = "name" // soyad, ad
+	StaffSortTitle      = "title"
+	StaffSortCreatedAt  = "created_at"
+	StaffSortPolyclinic = "polyclinic" // poliklinik adı; atanmamış personel başta
+)
+
+// StaffListPage sıralama ve sayfalama seçenekleridir. Cursor verilirse Page yok sayılır ve
+// liste önceki sayfanın son kaydından devam eder.
+type StaffListPage struct {
+	Page   int
+	Size   int
+	Sort   string
+	Order  string // asc | desc
+	Cursor string
+}
+
+type StaffListResponse struct {
+	Staff      []StaffResponse `json:"staff"`
+	Total      int             `json:"total"`
+	Page       int             `json:"page"`
+	Size       int             `json:"size"`
+	NextCursor string          `json:"next_cursor,omitempty"` // sonraki sayfa yoksa boştur
+}
+
+type StaffSearchHit struct {
 	ID                   uint    `json:"id"`
 	FirstName            string  `json:"first_name"`
 	LastName             string  `json:"last_name"`
@@ -33,31 +97,10 @@ type StaffResponse struct {
 	TitleName            string  `json:"title_name"`
 	HospitalPolyclinicID *uint   `json:"hospital_polyclinic_id"`
 	PolyclinicName       *string `json:"polyclinic_name"`
-	WorkingDays          string  `json:"working_days"`
-}
-
-type UpdateStaffRequest struct {
-	FirstName            string `json:"first_name"`
-	LastName             string `json:"last_name"`
-	TC                   string `json:"tc"`
-	Phone                string `json:"phone"`
-	JobGroupID           uint   `json:"job_group_id"`
-	TitleID              uint   `json:"title_id"`
-	HospitalPolyclinicID *uint  `json:"hospital_polyclinic_id"`
-	WorkingDays          string `json:"working_days"`
+	Score                int     `json:"score"` // yalnızca sıralama içindir, sorgular arasında karşılaştırılamaz
 }
 
-type StaffListFilter struct {
-	FirstName  string
-	LastName   string
-	TC         string
-	JobGroupID *uint
-	TitleID    *uint
-}
-
-type StaffListResponse struct {
-	Staff []StaffResponse `json:"staff"`
-	Total int             `json:"total"`
-	Page  int             `json:"page"`
-	Size  int             `json:"size"`
+type StaffSearchResponse struct {
+	Query   string           `json:"query"` // katlanmış hali; "Şükrü" -> "sukru"
+	Results []StaffSearchHit `json:"results"`
 }
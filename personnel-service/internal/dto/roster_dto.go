@@ -0,0 +1,108 @@
+package dto
+
+type RosterRequirement struct {
+	JobGroupID *uint `json:"job_group_id,omitempty"`
+	TitleID    *uint `json:"title_id,omitempty"`
+	MinStaff   int   `json:"min_staff"`
+}
+
+// GenerateRosterRequest nöbet listesi üretim kurallarıdır
+type GenerateRosterRequest struct {
+	HospitalPolyclinicID *uint               `json:"hospital_polyclinic_id"` // boşsa hastanenin tamamı
+	StartDate            string              `json:"start_date"`             // YYYY-MM-DD
+	EndDate              string              `json:"end_date"`               // YYYY-MM-DD
+	DutyStart            string              `json:"duty_start"`             // HH:MM, varsayılan 08:00
+	DutyEnd              string              `json:"duty_end"`               // HH:MM, varsayılan 08:00 (24 saat)
+	MinRestHours         *int                `json:"min_rest_hours"`         // nöbet bitişinden sonraki en az dinlenme, varsayılan 24
+	MaxShiftsPerStaff    int                 `json:"max_shifts_per_staff"`   // varsayılan 8
+	Requirements         []RosterRequirement `json:"requirements"`
+	Holidays             []string            `json:"holidays"` // YYYY-MM-DD
+}
+
+type RosterAssignmentRequest struct {
+	StaffID uint   `json:"staff_id"`
+	Date    string `json:"date"` // YYYY-MM-DD
+}
+
+type RosterListFilter struct {
+	Status string
+}
+
+type RosterAssignmentResponse struct {
+	ID         uint   `json:"id"`
+	StaffID    uint   `json:"staff_id"`
+	FirstName  string `json:"first_name"`
+	LastName   string `json:"last_name"`
+	JobGroupID uint   `json:"job_group_id"`
+	TitleID    uint   `json:"title_id"`
+}
+
+type RosterDay struct {
+	Date        string                     `json:"date"`
+	Weekend     bool                       `json:"weekend"`
+	Holiday     bool                       `json:"holiday"`
+	Assignments []RosterAssignmentResponse `json:"assignments"`
+}
+
+// RosterStaffLoad personel başına nöbet yüküdür; weekend_holiday hafta sonu ve tatil nöbetlerini sayar
+type RosterStaffLoad struct {
+	StaffID        uint   `json:"staff_id"`
+	FirstName      string `json:"first_name"`
+	LastName       string `json:"last_name"`
+	Total          int    `json:"total"`
+	WeekendHoliday int    `json:"weekend_holiday"`
+}
+
+const (
+	RosterIssueUnderstaffed = "understaffed"
+	RosterIssueRest         = "rest_violation"
+	RosterIssueMaxShifts    = "max_shifts_exceeded"
+	RosterIssueUnavailable  = "unavailable"
+	RosterIssueNotEligible  = "not_eligible"
+)
+
+// RosterIssue taslakta yayınlamadan önce gözden geçirilmesi gereken kural ihlalidir
+type RosterIssue struct {
+	Type       string `json:"type"`
+	Date       string `json:"date,omitempty"`
+	StaffID    uint   `json:"staff_id,omitempty"`
+	JobGroupID *uint  `json:"job_group_id,omitempty"`
+	TitleID    *uint  `json:"title_id,omitempty"`
+	Missing    int    `json:"missing,omitempty"`
+	Message    string `json:"message"`
+}
+
+type RosterResponse struct {
+	ID                   uint                `json:"id"`
+	HospitalPolyclinicID *uint               `json:"hospital_polyclinic_id"`
+	StartDate            string              `json:"start_date"`
+	EndDate              string              `json:"end_date"`
+	DutyStart            string              `json:"duty_start"`
+	DutyEnd              string              `json:"duty_end"`
+	MinRestHours         int                 `json:"min_rest_hours"`
+	MaxShiftsPerStaff    int                 `json:"max_shifts_per_staff"`
+	Status               string              `json:"status"`
+	PublishedAt          *string             `json:"published_at"`
+	Requirements         []RosterRequirement `json:"requirements"`
+	Holidays             []string            `json:"holidays"`
+	Days                 []RosterDay         `json:"days"`
+	Summary              []RosterStaffLoad   `json:"summary"`
+	Issues               []RosterIssue       `json:"issues"`
+}
+
+type RosterSummary struct {
+	ID                   uint    `json:"id"`
+	HospitalPolyclinicID *uint   `json:"hospital_polyclinic_id"`
+	StartDate            string  `json:"start_date"`
+	EndDate              string  `json:"end_date"`
+	Status               string  `json:"status"`
+	PublishedAt          *string `json:"published_at"`
+	AssignmentCount      int     `json:"assignment_count"`
+}
+
+type RosterListResponse struct {
+	Rosters []RosterSummary `json:"rosters"`
+	Total   int             `json:"total"`
+	Page    int             `json:"page"`
+	Size    int             `json:"size"`
+}
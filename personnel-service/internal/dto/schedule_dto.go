@@ -0,0 +1,64 @@
+package dto
+
+// WeeklyShift haftalık vardiya şablonudur; end_time start_time'dan küçükse vardiya ertesi güne taşar
+type WeeklyShift struct {
+	Weekday              int    `json:"weekday"`    // 0 = Pazar ... 6 = Cumartesi
+	StartTime            string `json:"start_time"` // HH:MM
+	EndTime              string `json:"end_time"`   // HH:MM
+	HospitalPolyclinicID *uint  `json:"hospital_polyclinic_id,omitempty"`
+}
+
+type UpdateScheduleRequest struct {
+	Shifts []WeeklyShift `json:"shifts"`
+}
+
+type ShiftInterval struct {
+	StartTime            string `json:"start_time"`
+	EndTime              string `json:"end_time"`
+	HospitalPolyclinicID *uint  `json:"hospital_polyclinic_id,omitempty"`
+}
+
+// ShiftOverrideRequest bir günün vardiyalarını tamamen değiştirir; off ise o gün çalışılmaz
+type ShiftOverrideRequest struct {
+	Off    bool            `json:"off"`
+	Shifts []ShiftInterval `json:"shifts"`
+	Note   string          `json:"note"`
+}
+
+type ShiftOverrideResponse struct {
+	Date   string          `json:"date"` // YYYY-MM-DD
+	Off    bool            `json:"off"`
+	Shifts []ShiftInterval `json:"shifts"`
+	Note   string          `json:"note,omitempty"`
+}
+
+type StaffScheduleResponse struct {
+	StaffID   uint                    `json:"staff_id"`
+	Weekly    []WeeklyShift           `json:"weekly"`
+	Overrides []ShiftOverrideResponse `json:"overrides"`
+}
+
+// OnDutyFilter "X polikliniğinde Y günü 08:00-12:00 arasında kim çalışıyor" sorgusudur
+type OnDutyFilter struct {
+	HospitalPolyclinicID uint
+	Date                 string // YYYY-MM-DD
+	From                 string // HH:MM, boşsa gün başı
+	To                   string // HH:MM, boşsa gün sonu
+}
+
+// OnDutyShift sorgu aralığına denk gelen vardiyadır; gece vardiyasında date önceki gün olabilir
+type OnDutyShift struct {
+	Date                 string `json:"date"` // YYYY-MM-DD
+	StartTime            string `json:"start_time"`
+	EndTime              string `json:"end_time"`
+	HospitalPolyclinicID uint   `json:"hospital_polyclinic_id"`
+}
+
+type OnDutyStaff struct {
+	ID         uint          `json:"id"`
+	FirstName  string        `json:"first_name"`
+	LastName   string        `json:"last_name"`
+	JobGroupID uint          `json:"job_group_id"`
+	TitleID    uint          `json:"title_id"`
+	Shifts     []OnDutyShift `json:"shifts"`
+}
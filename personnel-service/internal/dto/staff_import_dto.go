@@ -0,0 +1,22 @@
+package dto
+
+type StaffImportRowError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// StaffImportJobResponse içe aktarma işinin durumudur; Errors yalnızca iş bittiğinde doludur
+type StaffImportJobResponse struct {
+	ID           uint                  `json:"id"`
+	FileName     string                `json:"file_name"`
+	DryRun       bool                  `json:"dry_run"`
+	Status       string                `json:"status"` // pending | running | validated | completed | failed
+	TotalRows    int                   `json:"total_rows"`
+	ValidRows    int                   `json:"valid_rows"`
+	ImportedRows int                   `json:"imported_rows"`
+	Error        string                `json:"error,omitempty"`
+	Errors       []StaffImportRowError `json:"errors"`
+	CreatedAt    string                `json:"created_at"`
+	FinishedAt   *string               `json:"finished_at"`
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"strconv"
+
+	"hospital-shared/jwt"
+	"personnel-service/internal/dto"
+	"personnel-service/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AssignmentHandler struct {
+	assignmentUsecase usecase.AssignmentUsecase
+}
+
+func NewAssignmentHandler(assignmentUsecase usecase.AssignmentUsecase) *AssignmentHandler {
+	return &AssignmentHandler{assignmentUsecase: assignmentUsecase}
+}
+
+// TransferStaff godoc
+// @Summary     Personeli başka polikliniğe taşır ya da unvanını değiştirir
+// @Description Transfers a staff member to another polyclinic and/or changes job group and title from the effective date. The change is recorded in the assignment history with the reason and the approving authority.
+// @Tags        Assignment
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Staff ID"
+// @Param       transfer body dto.TransferStaffRequest true "Transfer"
+// @Success     201 {object} dto.StaffAssignmentResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/staff/{id}/transfers [post]
+func (h *AssignmentHandler) TransferStaff(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid staff id"})
+	}
+
+	var req dto.TransferStaffRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.assignmentUsecase.TransferStaff(uint(id), user.HospitalID, user.AuthorityID, &req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// ListStaffAssignments godoc
+// @Summary     Personelin atama geçmişini listeler
+// @Description Lists the effective-dated polyclinic, job group and title history of a staff member, newest first. Works for deleted staff too.
+// @Tags        Assignment
+// @Produce     json
+// @Param       id path int true "Staff ID"
+// @Success     200 {array} dto.StaffAssignmentResponse
+// @Failure     404 {object} map[string]string
+// @Router      /api/personnel/staff/{id}/assignments [get]
+func (h *AssignmentHandler) ListStaffAssignments(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid staff id"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.assignmentUsecase.ListStaffAssignments(uint(id), user.HospitalID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// ListStaffAsOf godoc
+// @Summary     Belirli bir tarihteki personeli listeler
+// @Description Lists staff with the polyclinic, job group and title they had on the given date, e.g. staff of polyclinic X as of 2026-01-01. Staff deleted later are included with active=false.
+// @Tags        Assignment
+// @Produce     json
+// @Param       as_of query string true "Date (YYYY-MM-DD)"
+// @Param       hospital_polyclinic_id query string false "Hospital polyclinic ID or 'unassigned'"
+// @Param       job_group_id query int false "Job Group ID"
+// @Param       title_id query int false "Title ID"
+// @Success     200 {object} dto.StaffAsOfResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/assignments [get]
+func (h *AssignmentHandler) ListStaffAsOf(c *fiber.Ctx) error {
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	// Personel listesiyle aynı poliklinik, meslek grubu ve unvan parametreleri kullanılır
+	filter, err := staffListFilter(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	resp, err := h.assignmentUsecase.ListStaffAsOf(user.HospitalID, c.Query("as_of", ""), filter)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
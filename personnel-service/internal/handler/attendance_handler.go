@@ -0,0 +1,286 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+
+	"hospital-shared/jwt"
+	"personnel-service/internal/dto"
+	"personnel-service/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// kioskKeyHeader kiosk cihazının oluşturulurken aldığı API anahtarını taşır
+const kioskKeyHeader = "X-Kiosk-Key"
+
+type AttendanceHandler struct {
+	attendanceUsecase usecase.AttendanceUsecase
+}
+
+func NewAttendanceHandler(attendanceUsecase usecase.AttendanceUsecase) *AttendanceHandler {
+	return &AttendanceHandler{attendanceUsecase: attendanceUsecase}
+}
+
+// CreateKiosk godoc
+// @Summary     Kiosk tanımlar
+// @Description Registers a clock-in kiosk for the hospital; the API key is returned only once
+// @Tags        Attendance
+// @Accept      json
+// @Produce     json
+// @Param       kiosk body dto.CreateKioskRequest true "Kiosk info"
+// @Success     201 {object} dto.KioskResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/kiosks [post]
+func (h *AttendanceHandler) CreateKiosk(c *fiber.Ctx) error {
+	var req dto.CreateKioskRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.attendanceUsecase.CreateKiosk(user.HospitalID, &req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// ListKiosks godoc
+// @Summary     Kioskları listeler
+// @Description Lists the kiosks of the hospital
+// @Tags        Attendance
+// @Produce     json
+// @Success     200 {array} dto.KioskResponse
+// @Router      /api/personnel/kiosks [get]
+func (h *AttendanceHandler) ListKiosks(c *fiber.Ctx) error {
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.attendanceUsecase.ListKiosks(user.HospitalID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// DeactivateKiosk godoc
+// @Summary     Kioskı devre dışı bırakır
+// @Description Deactivates a kiosk; its key and QR codes stop working
+// @Tags        Attendance
+// @Produce     json
+// @Param       id path int true "Kiosk ID"
+// @Success     200 {object} map[string]string
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/kiosks/{id} [delete]
+func (h *AttendanceHandler) DeactivateKiosk(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid kiosk id"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	if err := h.attendanceUsecase.DeactivateKiosk(uint(id), user.HospitalID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"message": "Kiosk deactivated"})
+}
+
+// GetKioskQR godoc
+// @Summary     Kiosk ekranı için güncel QR içeriğini döner
+// @Description Returns the rotating QR token for the kiosk identified by the X-Kiosk-Key header
+// @Tags        Attendance
+// @Produce     json
+// @Param       X-Kiosk-Key header string true "Kiosk API key"
+// @Success     200 {object} dto.KioskQRResponse
+// @Failure     401 {object} map[string]string
+// @Router      /api/personnel/attendance/kiosk/qr [get]
+func (h *AttendanceHandler) GetKioskQR(c *fiber.Ctx) error {
+	resp, err := h.attendanceUsecase.GetKioskQR(c.Get(kioskKeyHeader))
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidKioskKey) {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// Clock godoc
+// @Summary     Kiosk QR'ı ile giriş/çıkış kaydeder
+// @Description Records a clock-in or clock-out using the scanned kiosk QR token and the staff's TC and phone
+// @Tags        Attendance
+// @Accept      json
+// @Produce     json
+// @Param       clock body dto.KioskClockRequest true "Clock request"
+// @Success     201 {object} dto.AttendanceEventResponse
+// @Failure     400 {object} map[string]string
+// @Failure     401 {object} map[string]string
+// @Failure     409 {object} map[string]string
+// @Router      /api/personnel/attendance/clock [post]
+func (h *AttendanceHandler) Clock(c *fiber.Ctx) error {
+	var req dto.KioskClockRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	resp, err := h.attendanceUsecase.ClockViaKiosk(&req)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidQRToken) {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+		}
+		if errors.Is(err, usecase.ErrQRTokenUsed) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// RecordManual godoc
+// @Summary     Elle giriş/çıkış kaydı ekler
+// @Description Records an attendance event on behalf of a staff member; a reason is required
+// @Tags        Attendance
+// @Accept      json
+// @Produce     json
+// @Param       event body dto.ManualAttendanceRequest true "Attendance event"
+// @Success     201 {object} dto.AttendanceEventResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/attendance/events [post]
+func (h *AttendanceHandler) RecordManual(c *fiber.Ctx) error {
+	var req dto.ManualAttendanceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.attendanceUsecase.RecordManual(user.HospitalID, user.AuthorityID, &req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// ListEvents godoc
+// @Summary     Giriş/çıkış kayıtlarını listeler
+// @Description Lists attendance events of the hospital in a date range (default last 7 days)
+// @Tags        Attendance
+// @Produce     json
+// @Param       staff_id query int false "Staff ID"
+// @Param       from query string false "Start date (YYYY-MM-DD)"
+// @Param       to query string false "End date (YYYY-MM-DD)"
+// @Success     200 {array} dto.AttendanceEventResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/attendance/events [get]
+func (h *AttendanceHandler) ListEvents(c *fiber.Ctx) error {
+	staffID, err := queryStaffID(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid staff_id"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.attendanceUsecase.ListEvents(user.HospitalID, dto.AttendanceEventFilter{
+		StaffID: staffID,
+		From:    c.Query("from", ""),
+		To:      c.Query("to", ""),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// GetTimesheet godoc
+// @Summary     Aylık puantajı getirir
+// @Description Returns scheduled, worked and overtime minutes, absences and leave days per staff for a month
+// @Tags        Attendance
+// @Produce     json
+// @Param       month query string true "Month (YYYY-MM)"
+// @Param       staff_id query int false "Staff ID"
+// @Success     200 {object} dto.TimesheetResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/attendance/timesheets [get]
+func (h *AttendanceHandler) GetTimesheet(c *fiber.Ctx) error {
+	staffID, err := queryStaffID(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid staff_id"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.attendanceUsecase.GetTimesheet(user.HospitalID, dto.TimesheetFilter{
+		Month:   c.Query("month", ""),
+		StaffID: staffID,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// ExportTimesheet godoc
+// @Summary     Aylık puantajı CSV olarak indirir
+// @Description Exports the monthly timesheet summary as CSV for payroll
+// @Tags        Attendance
+// @Produce     text/csv
+// @Param       month query string true "Month (YYYY-MM)"
+// @Param       staff_id query int false "Staff ID"
+// @Success     200 {file} file
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/attendance/timesheets/export [get]
+func (h *AttendanceHandler) ExportTimesheet(c *fiber.Ctx) error {
+	staffID, err := queryStaffID(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid staff_id"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	month := c.Query("month", "")
+	var buf bytes.Buffer
+	if err := h.attendanceUsecase.ExportTimesheetCSV(user.HospitalID, dto.TimesheetFilter{Month: month, StaffID: staffID}, &buf); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="puantaj-`+month+`.csv"`)
+	return c.Send(buf.Bytes())
+}
+
+func queryStaffID(c *fiber.Ctx) (*uint, error) {
+	v := c.Query("staff_id", "")
+	if v == "" {
+		return nil, nil
+	}
+	id, err := strconv.ParseUint(v, 10, 64)
+	if err != nil || id == 0 {
+		return nil, errors.New("invalid staff_id")
+	}
+	sid := uint(id)
+	return &sid, nil
+}
@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"strconv"
+
+	"hospital-shared/jwt"
+	"personnel-service/internal/dto"
+	"personnel-service/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CertificationHandler struct {
+	certificationUsecase usecase.CertificationUsecase
+}
+
+func NewCertificationHandler(certificationUsecase usecase.CertificationUsecase) *CertificationHandler {
+	return &CertificationHandler{certificationUsecase: certificationUsecase}
+}
+
+// AddCertification godoc
+// @Summary     Personele sertifika ekler
+// @Description Adds a diploma, specialty certificate, BLS/ACLS or other certification to a staff member. BLS and ACLS require an expiry date.
+// @Tags        Certification
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Staff ID"
+// @Param       certification body dto.CertificationRequest true "Certification"
+// @Success     201 {object} dto.CertificationResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/staff/{id}/certifications [post]
+func (h *CertificationHandler) AddCertification(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid staff id"})
+	}
+
+	var req dto.CertificationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.certificationUsecase.AddCertification(uint(id), user.HospitalID, &req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// ListStaffCertifications godoc
+// @Summary     Personelin sertifikalarını listeler
+// @Description Lists certifications of a staff member, soonest expiry first
+// @Tags        Certification
+// @Produce     json
+// @Param       id path int true "Staff ID"
+// @Success     200 {array} dto.CertificationResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/staff/{id}/certifications [get]
+func (h *CertificationHandler) ListStaffCertifications(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid staff id"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.certificationUsecase.ListStaffCertifications(uint(id), user.HospitalID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// UpdateCertification godoc
+// @Summary     Sertifikayı günceller
+// @Description Updates a certification; changing the expiry date re-enables the expiry notification
+// @Tags        Certification
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Certification ID"
+// @Param       certification body dto.CertificationRequest true "Certification"
+// @Success     200 {object} dto.CertificationResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/certifications/{id} [put]
+func (h *CertificationHandler) UpdateCertification(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid certification id"})
+	}
+
+	var req dto.CertificationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.certificationUsecase.UpdateCertification(uint(id), user.HospitalID, &req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// DeleteCertification godoc
+// @Summary     Sertifikayı siler
+// @Description Deletes a certification together with its document
+// @Tags        Certification
+// @Produce     json
+// @Param       id path int true "Certification ID"
+// @Success     200 {object} map[string]string
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/certifications/{id} [delete]
+func (h *CertificationHandler) DeleteCertification(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid certification id"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	if err := h.certificationUsecase.DeleteCertification(uint(id), user.HospitalID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"message": "Certification deleted"})
+}
+
+// UploadDocument godoc
+// @Summary     Sertifika belgesini yükler
+// @Description Uploads the scanned document of a certification (PDF, JPEG or PNG, max 3 MB), replacing any previous one
+// @Tags        Certification
+// @Accept      multipart/form-data
+// @Produce     json
+// @Param       id path int true "Certification ID"
+// @Param       file formData file true "Document"
+// @Success     200 {object} dto.CertificationResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/certifications/{id}/document [put]
+func (h *CertificationHandler) UploadDocument(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid certification id"})
+	}
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "file is required"})
+	}
+	if fh.Size > usecase.MaxCertificationDocumentSize {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "file is too large"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot read file"})
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, usecase.MaxCertificationDocumentSize))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot read file"})
+	}
+
+	resp, err := h.certificationUsecase.UploadDocument(uint(id), user.HospitalID, fh.Filename, data)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// GetDocument godoc
+// @Summary     Sertifika belgesini indirir
+// @Description Downloads the uploaded document of a certification
+// @Tags        Certification
+// @Produce     application/pdf
+// @Produce     image/jpeg
+// @Produce     image/png
+// @Param       id path int true "Certification ID"
+// @Success     200 {file} file
+// @Failure     404 {object} map[string]string
+// @Router      /api/personnel/certifications/{id}/document [get]
+func (h *CertificationHandler) GetDocument(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid certification id"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	doc, err := h.certificationUsecase.GetDocument(uint(id), user.HospitalID)
+	if errors.Is(err, usecase.ErrCertificationDocumentNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	c.Set(fiber.HeaderContentType, doc.ContentType)
+	// Türkçe karakterli dosya adları için RFC 2231 kodlaması kullanılır
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": doc.FileName}))
+	return c.Send(doc.Data)
+}
+
+// ListExpiring godoc
+// @Summary     Süresi yaklaşan sertifikaları listeler
+// @Description Lists certifications of the hospital that expire within the given number of days, including already expired ones
+// @Tags        Certification
+// @Produce     json
+// @Param       days query int false "Days ahead (default 30, max 365)"
+// @Success     200 {object} dto.ExpiringCertificationsResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/certifications/expiring [get]
+func (h *CertificationHandler) ListExpiring(c *fiber.Ctx) error {
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	days, _ := strconv.Atoi(c.Query("days", "0"))
+	resp, err := h.certificationUsecase.ListExpiring(user.HospitalID, days)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
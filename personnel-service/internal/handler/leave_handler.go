@@ -0,0 +1,289 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"hospital-shared/jwt"
+	"personnel-service/internal/dto"
+	"personnel-service/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type LeaveHandler struct {
+	leaveUsecase usecase.LeaveUsecase
+}
+
+func NewLeaveHandler(leaveUsecase usecase.LeaveUsecase) *LeaveHandler {
+	return &LeaveHandler{leaveUsecase: leaveUsecase}
+}
+
+// ListLeaveTypes godoc
+// @Summary     İzin türlerini listeler
+// @Description Returns all leave types with their default yearly entitlement
+// @Tags        Leave
+// @Produce     json
+// @Success     200 {array} dto.LeaveTypeResponse
+// @Router      /api/personnel/leave-types [get]
+func (h *LeaveHandler) ListLeaveTypes(c *fiber.Ctx) error {
+	resp, err := h.leaveUsecase.ListLeaveTypes()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// SetEntitlement godoc
+// @Summary     Personelin yıllık izin hakkını belirler
+// @Description Sets the yearly entitlement of a staff member for a leave type
+// @Tags        Leave
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Staff ID"
+// @Param       entitlement body dto.SetLeaveEntitlementRequest true "Entitlement"
+// @Success     200 {object} map[string]string
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/staff/{id}/leave-entitlements [put]
+func (h *LeaveHandler) SetEntitlement(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid staff id"})
+	}
+
+	var req dto.SetLeaveEntitlementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	if err := h.leaveUsecase.SetEntitlement(uint(id), user.HospitalID, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"message": "Entitlement updated"})
+}
+
+// GetBalance godoc
+// @Summary     Personelin izin bakiyesini getirir
+// @Description Returns entitlement, used, pending and remaining days per leave type
+// @Tags        Leave
+// @Produce     json
+// @Param       id path int true "Staff ID"
+// @Param       year query int false "Year (default current year)"
+// @Success     200 {object} dto.LeaveBalanceResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/staff/{id}/leave-balance [get]
+func (h *LeaveHandler) GetBalance(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid staff id"})
+	}
+	year, err := strconv.Atoi(c.Query("year", "0"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid year"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.leaveUsecase.GetBalance(uint(id), user.HospitalID, year)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// CreateLeaveRequest godoc
+// @Summary     İzin talebi oluşturur
+// @Description Creates a pending leave request; overlapping published on-call duties are listed in conflicts
+// @Tags        Leave
+// @Accept      json
+// @Produce     json
+// @Param       leave body dto.CreateLeaveRequest true "Leave request"
+// @Success     201 {object} dto.LeaveRequestResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/leave-requests [post]
+func (h *LeaveHandler) CreateLeaveRequest(c *fiber.Ctx) error {
+	var req dto.CreateLeaveRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.leaveUsecase.CreateLeaveRequest(user.HospitalID, &req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// ListLeaveRequests godoc
+// @Summary     İzin taleplerini listeler (filtreli ve sayfalı)
+// @Description Lists leave requests of the hospital
+// @Tags        Leave
+// @Produce     json
+// @Param       status query string false "pending | approved | rejected | cancelled"
+// @Param       staff_id query int false "Staff ID"
+// @Param       from query string false "YYYY-MM-DD"
+// @Param       to query string false "YYYY-MM-DD"
+// @Param       page query int false "Page number"
+// @Param       size query int false "Page size"
+// @Success     200 {object} dto.LeaveRequestListResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/leave-requests [get]
+func (h *LeaveHandler) ListLeaveRequests(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	size, _ := strconv.Atoi(c.Query("size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = 10
+	}
+
+	filter := dto.LeaveRequestListFilter{
+		Status: c.Query("status", ""),
+		From:   c.Query("from", ""),
+		To:     c.Query("to", ""),
+	}
+	if v := c.Query("staff_id", ""); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid staff_id"})
+		}
+		sid := uint(id)
+		filter.StaffID = &sid
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.leaveUsecase.ListLeaveRequests(user.HospitalID, filter, page, size)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// ApproveLeaveRequest godoc
+// @Summary     İzin talebini onaylar
+// @Description Approves a pending leave request; fails with 409 if it overlaps published duties unless force=true
+// @Tags        Leave
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Leave request ID"
+// @Param       force query bool false "Approve even if it overlaps published on-call duties"
+// @Param       review body dto.ReviewLeaveRequest false "Review note"
+// @Success     200 {object} dto.LeaveRequestResponse
+// @Failure     400 {object} map[string]string
+// @Failure     409 {object} map[string]interface{}
+// @Router      /api/personnel/leave-requests/{id}/approve [post]
+func (h *LeaveHandler) ApproveLeaveRequest(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid leave request id"})
+	}
+
+	var req dto.ReviewLeaveRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+		}
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.leaveUsecase.ApproveLeaveRequest(uint(id), user.HospitalID, user.AuthorityID, req.Note, c.QueryBool("force", false))
+	if errors.Is(err, usecase.ErrLeaveConflictsWithRoster) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error(), "conflicts": resp.Conflicts})
+	}
+	if errors.Is(err, usecase.ErrInsufficientLeaveBalance) || errors.Is(err, usecase.ErrLeaveNotPending) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// RejectLeaveRequest godoc
+// @Summary     İzin talebini reddeder
+// @Description Rejects a pending leave request
+// @Tags        Leave
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Leave request ID"
+// @Param       review body dto.ReviewLeaveRequest false "Review note"
+// @Success     200 {object} dto.LeaveRequestResponse
+// @Failure     400 {object} map[string]string
+// @Failure     409 {object} map[string]string
+// @Router      /api/personnel/leave-requests/{id}/reject [post]
+func (h *LeaveHandler) RejectLeaveRequest(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid leave request id"})
+	}
+
+	var req dto.ReviewLeaveRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+		}
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.leaveUsecase.RejectLeaveRequest(uint(id), user.HospitalID, user.AuthorityID, req.Note)
+	if errors.Is(err, usecase.ErrLeaveNotPending) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// CancelLeaveRequest godoc
+// @Summary     İzin talebini iptal eder
+// @Description Cancels a pending or approved leave request; approved days return to the balance
+// @Tags        Leave
+// @Produce     json
+// @Param       id path int true "Leave request ID"
+// @Success     200 {object} dto.LeaveRequestResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/leave-requests/{id}/cancel [post]
+func (h *LeaveHandler) CancelLeaveRequest(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid leave request id"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.leaveUsecase.CancelLeaveRequest(uint(id), user.HospitalID, user.AuthorityID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"errors"
 	"strconv"
 
+	dt "hospital-shared/dto"
 	"hospital-shared/jwt"
 	"personnel-service/internal/config"
 	"personnel-service/internal/dto"
@@ -93,7 +95,7 @@ func (h *PersonnelHandler) AddStaff(c *fiber.Ctx) error {
 
 // UpdateStaff godoc
 // @Summary     Personel günceller
-// @Description Updates a staff member
+// @Description Updates personal details and the weekly schedule of a staff member. hospital_polyclinic_id, job_group_id and title_id must match the current assignment; change them with POST /api/personnel/staff/{id}/transfers.
 // @Tags        Personnel
 // @Accept      json
 // @Produce     json
@@ -152,62 +154,129 @@ func (h *PersonnelHandler) DeleteStaff(c *fiber.Ctx) error {
 }
 
 // ListStaff godoc
-// @Summary     Personelleri listeler (filtreli ve sayfalı)
-// @Description Lists staff with filters and pagination
+// @Summary     Personelleri listeler (filtreli, sıralı ve sayfalı)
+// @Description Lists staff with filters, stable sorting and page or cursor pagination. When cursor is given page is ignored; next_cursor is empty on the last page. TC numbers are masked and the tc filter is rejected unless the caller is yetkili.
 // @Tags        Personnel
 // @Produce     json
 // @Param       page query int false "Page number"
-// @Param       size query int false "Page size"
+// @Param       size query int false "Page size (max 100)"
+// @Param       cursor query string false "next_cursor of the previous page"
+// @Param       sort query string false "name | title | created_at | polyclinic" default(name)
+// @Param       order query string false "asc | desc" default(asc)
 // @Param       first_name query string false "First name"
 // @Param       last_name query string false "Last name"
 // @Param       tc query string false "TC Kimlik No"
 // @Param       job_group_id query int false "Job Group ID"
 // @Param       title_id query int false "Title ID"
+// @Param       hospital_polyclinic_id query string false "Hospital polyclinic ID or 'unassigned'"
+// @Param       working_day query int false "Weekday with a weekly shift (0 = Sunday)"
 // @Success     201 {object} dto.StaffListResponse
 // @Failure     400 {object} map[string]string
+// @Failure     403 {object} map[string]string
 // @Router      /api/personnel/staff [get]
 func (h *PersonnelHandler) ListStaff(c *fiber.Ctx) error {
 
 	// 1. Query parametrelerinden filtreleri ve sayfa/size al
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	size, _ := strconv.Atoi(c.Query("size", "10"))
-	firstName := c.Query("first_name", "")
-	lastName := c.Query("last_name", "")
-	tc := c.Query("tc", "")
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	filter, err := staffListFilter(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	resp, err := h.personnelUsecase.ListStaff(user.HospitalID, user.Role, filter, dto.StaffListPage{
+		Page:   page,
+		Size:   size,
+		Sort:   c.Query("sort", ""),
+		Order:  c.Query("order", ""),
+		Cursor: c.Query("cursor", ""),
+	})
+
+	if errors.Is(err, usecase.ErrInvalidStaffSort) || errors.Is(err, usecase.ErrInvalidStaffCursor) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if errors.Is(err, usecase.ErrTCFilterNotAllowed) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// SearchStaff godoc
+// @Summary     Personel arar (Türkçe karakter duyarsız)
+// @Description Ranked search over name, title, TC prefix and phone. Turkish characters are folded, so "sukru" matches "Şükrü". Every word of q must match. TC numbers are masked and not searched unless the caller is yetkili.
+// @Tags        Personnel
+// @Produce     json
+// @Param       q query string true "Search text (min 2 characters)"
+// @Param       limit query int false "Max results (default 20, max 50)"
+// @Success     200 {object} dto.StaffSearchResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/staff/search [get]
+func (h *PersonnelHandler) SearchStaff(c *fiber.Ctx) error {
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit", "0"))
+	resp, err := h.personnelUsecase.SearchStaff(user.HospitalID, user.Role, c.Query("q", ""), limit)
+	if errors.Is(err, usecase.ErrStaffSearchTooShort) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// staffListFilter liste ve dışa aktarımın ortak query parametrelerini okur
+func staffListFilter(c *fiber.Ctx) (dto.StaffListFilter, error) {
+	filter := dto.StaffListFilter{
+		FirstName: c.Query("first_name", ""),
+		LastName:  c.Query("last_name", ""),
+		TC:        c.Query("tc", ""),
+	}
 
 	// job_group_id ve title_id parse edilir
-	var jobGroupID *uint
 	if v := c.Query("job_group_id", ""); v != "" {
 		if id, err := strconv.ParseUint(v, 10, 64); err == nil && id > 0 {
 			jid := uint(id)
-			jobGroupID = &jid
+			filter.JobGroupID = &jid
 		}
 	}
-	var titleID *uint
 	if v := c.Query("title_id", ""); v != "" {
 		if id, err := strconv.ParseUint(v, 10, 64); err == nil && id > 0 {
 			tid := uint(id)
-			titleID = &tid
+			filter.TitleID = &tid
 		}
 	}
 
-	user := jwt.GetUserInfo(c)
-	if user == nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	// hospital_polyclinic_id bir kimlik ya da atanmamışlar için "unassigned" olabilir
+	if v := c.Query("hospital_polyclinic_id", ""); v == "unassigned" {
+		filter.Unassigned = true
+	} else if v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil || id == 0 {
+			return filter, errors.New("hospital_polyclinic_id must be an id or 'unassigned'")
+		}
+		hpID := uint(id)
+		filter.HospitalPolyclinicID = &hpID
 	}
-
-	resp, err := h.personnelUsecase.ListStaff(user.HospitalID, dto.StaffListFilter{
-		FirstName:  firstName,
-		LastName:   lastName,
-		TC:         tc,
-		JobGroupID: jobGroupID,
-		TitleID:    titleID,
-	}, page, size)
-
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	if v := c.Query("working_day", ""); v != "" {
+		day, err := strconv.Atoi(v)
+		if err != nil || day < 0 || day > 6 {
+			return filter, errors.New("working_day must be between 0 (Sunday) and 6")
+		}
+		filter.WorkingDay = &day
 	}
-	return c.JSON(resp)
+	return filter, nil
 }
 
 func (h *PersonnelHandler) GetStaffCount(c *fiber.Ctx) error {
@@ -216,7 +285,8 @@ func (h *PersonnelHandler) GetStaffCount(c *fiber.Ctx) error {
 		return c.Status(400).SendString("Invalid ID")
 	}
 
-	count, err := h.personnelUsecase.CountPersonnelByHpID(uint(id))
+	// Poliklinik kaldırılırken izindeki personel de atanmış sayılır
+	count, err := h.personnelUsecase.CountPersonnelByHpID(uint(id), c.QueryBool("include_on_leave", false))
 	if err != nil {
 		return c.Status(500).SendString("Error fetching count")
 	}
@@ -237,3 +307,43 @@ func (h *PersonnelHandler) GetGroupCounts(c *fiber.Ctx) error {
 
 	return c.JSON(groupCounts) // [{groupName: "Doktor", count: 3}, ...]
 }
+
+// GetPersonnelStatsBatch hospital servisinin poliklinik listesi için kullanılır - servisler arası anahtar gerektirir
+func (h *PersonnelHandler) GetPersonnelStatsBatch(c *fiber.Ctx) error {
+	var req dt.PersonnelStatsBatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	stats, err := h.personnelUsecase.GetPersonnelStatsBatch(req.HospitalPolyclinicIDs)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(stats)
+}
+
+// ReassignStaff hospital servisinin tutarlılık kontrolü çağırır - servisler arası anahtar gerektirir
+func (h *PersonnelHandler) ReassignStaff(c *fiber.Ctx) error {
+	var req dt.ReassignStaffRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	resp, err := h.personnelUsecase.ReassignStaff(&req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// ListStaffRefs hospital servisinin tutarlılık kontrolü için kullanılır - JWT yerine servisler arası anahtar ister
+func (h *PersonnelHandler) ListStaffRefs(c *fiber.Ctx) error {
+	afterID, _ := strconv.ParseUint(c.Query("after_id", "0"), 10, 64)
+	limit, _ := strconv.Atoi(c.Query("limit", "0"))
+
+	page, err := h.personnelUsecase.ListStaffRefs(uint(afterID), limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(page)
+}
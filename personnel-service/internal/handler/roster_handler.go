@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"hospital-shared/jwt"
+	"personnel-service/internal/dto"
+	"personnel-service/internal/models"
+	"personnel-service/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RosterHandler struct {
+	rosterUsecase usecase.RosterUsecase
+}
+
+func NewRosterHandler(rosterUsecase usecase.RosterUsecase) *RosterHandler {
+	return &RosterHandler{rosterUsecase: rosterUsecase}
+}
+
+// GenerateRoster godoc
+// @Summary     Nöbet listesi taslağı üretir
+// @Description Generates a draft on-call roster for the hospital or a polyclinic over a date range
+// @Tags        Roster
+// @Accept      json
+// @Produce     json
+// @Param       roster body dto.GenerateRosterRequest true "Roster rules"
+// @Success     201 {object} dto.RosterResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/rosters/generate [post]
+func (h *RosterHandler) GenerateRoster(c *fiber.Ctx) error {
+	var req dto.GenerateRosterRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.rosterUsecase.GenerateRoster(user.HospitalID, &req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// ListRosters godoc
+// @Summary     Nöbet listelerini listeler
+// @Description Lists rosters of the hospital; employees only see published rosters
+// @Tags        Roster
+// @Produce     json
+// @Param       status query string false "draft | published"
+// @Param       page query int false "Page number"
+// @Param       size query int false "Page size"
+// @Success     200 {object} dto.RosterListResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/rosters [get]
+func (h *RosterHandler) ListRosters(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	size, _ := strconv.Atoi(c.Query("size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = 10
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	filter := dto.RosterListFilter{Status: c.Query("status", "")}
+	if user.Role != "yetkili" {
+		filter.Status = models.RosterStatusPublished
+	}
+
+	resp, err := h.rosterUsecase.ListRosters(user.HospitalID, filter, page, size)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// GetRoster godoc
+// @Summary     Nöbet listesini kural ihlalleri ve yük dağılımıyla getirir
+// @Description Returns a roster with its days, per-staff load and rule violations
+// @Tags        Roster
+// @Produce     json
+// @Param       id path int true "Roster ID"
+// @Success     200 {object} dto.RosterResponse
+// @Failure     404 {object} map[string]string
+// @Router      /api/personnel/rosters/{id} [get]
+func (h *RosterHandler) GetRoster(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid roster id"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.rosterUsecase.GetRoster(uint(id), user.HospitalID, user.Role == "yetkili")
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// AddAssignment godoc
+// @Summary     Taslak nöbet listesine nöbet ekler
+// @Description Adds a duty to a draft roster; rule violations are reported in issues
+// @Tags        Roster
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Roster ID"
+// @Param       assignment body dto.RosterAssignmentRequest true "Assignment"
+// @Success     200 {object} dto.RosterResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/rosters/{id}/assignments [post]
+func (h *RosterHandler) AddAssignment(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid roster id"})
+	}
+
+	var req dto.RosterAssignmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.rosterUsecase.AddAssignment(uint(id), user.HospitalID, &req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// RemoveAssignment godoc
+// @Summary     Taslak nöbet listesinden nöbet çıkarır
+// @Description Removes a duty from a draft roster
+// @Tags        Roster
+// @Produce     json
+// @Param       id path int true "Roster ID"
+// @Param       assignmentId path int true "Assignment ID"
+// @Success     200 {object} dto.RosterResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/rosters/{id}/assignments/{assignmentId} [delete]
+func (h *RosterHandler) RemoveAssignment(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid roster id"})
+	}
+	assignmentID, err := strconv.ParseUint(c.Params("assignmentId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid assignment id"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.rosterUsecase.RemoveAssignment(uint(id), uint(assignmentID), user.HospitalID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// PublishRoster godoc
+// @Summary     Nöbet listesini yayınlar
+// @Description Publishes a draft roster; fails with 409 and the issues unless force=true
+// @Tags        Roster
+// @Produce     json
+// @Param       id path int true "Roster ID"
+// @Param       force query bool false "Publish even if there are issues"
+// @Success     200 {object} dto.RosterResponse
+// @Failure     400 {object} map[string]string
+// @Failure     409 {object} map[string]interface{}
+// @Router      /api/personnel/rosters/{id}/publish [post]
+func (h *RosterHandler) PublishRoster(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid roster id"})
+	}
+	force := c.QueryBool("force", false)
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.rosterUsecase.PublishRoster(uint(id), user.HospitalID, force)
+	if errors.Is(err, usecase.ErrRosterHasIssues) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error(), "issues": resp.Issues})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// DeleteRoster godoc
+// @Summary     Taslak nöbet listesini siler
+// @Description Deletes a draft roster
+// @Tags        Roster
+// @Produce     json
+// @Param       id path int true "Roster ID"
+// @Success     200 {object} map[string]string
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/rosters/{id} [delete]
+func (h *RosterHandler) DeleteRoster(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid roster id"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	if err := h.rosterUsecase.DeleteRoster(uint(id), user.HospitalID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"message": "Roster deleted"})
+}
@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"strconv"
+
+	"hospital-shared/jwt"
+	"personnel-service/internal/dto"
+	"personnel-service/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ScheduleHandler struct {
+	scheduleUsecase usecase.ScheduleUsecase
+}
+
+func NewScheduleHandler(scheduleUsecase usecase.ScheduleUsecase) *ScheduleHandler {
+	return &ScheduleHandler{scheduleUsecase: scheduleUsecase}
+}
+
+// GetSchedule godoc
+// @Summary     Personelin haftalık vardiyalarını ve gün istisnalarını getirir
+// @Description Returns the weekly shifts and the date overrides of a staff member
+// @Tags        Schedule
+// @Produce     json
+// @Param       id path int true "Staff ID"
+// @Param       from query string false "YYYY-MM-DD (default today)"
+// @Param       to query string false "YYYY-MM-DD (default from + 30 days)"
+// @Success     200 {object} dto.StaffScheduleResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/staff/{id}/schedule [get]
+func (h *ScheduleHandler) GetSchedule(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid staff id"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.scheduleUsecase.GetSchedule(uint(id), user.HospitalID, c.Query("from"), c.Query("to"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// UpdateWeeklySchedule godoc
+// @Summary     Personelin haftalık vardiyalarını günceller
+// @Description Replaces the weekly shift template of a staff member
+// @Tags        Schedule
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Staff ID"
+// @Param       schedule body dto.UpdateScheduleRequest true "Weekly shifts"
+// @Success     200 {array} dto.WeeklyShift
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/staff/{id}/schedule [put]
+func (h *ScheduleHandler) UpdateWeeklySchedule(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid staff id"})
+	}
+
+	var req dto.UpdateScheduleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.scheduleUsecase.UpdateWeeklySchedule(uint(id), user.HospitalID, &req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// SetOverride godoc
+// @Summary     Personelin bir günlük vardiyasını değiştirir
+// @Description Replaces the shifts of a staff member for a single date (or marks the day off)
+// @Tags        Schedule
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Staff ID"
+// @Param       date path string true "YYYY-MM-DD"
+// @Param       override body dto.ShiftOverrideRequest true "Override"
+// @Success     200 {object} dto.ShiftOverrideResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/staff/{id}/schedule/overrides/{date} [put]
+func (h *ScheduleHandler) SetOverride(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid staff id"})
+	}
+
+	var req dto.ShiftOverrideRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.scheduleUsecase.SetOverride(uint(id), user.HospitalID, c.Params("date"), &req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
+
+// DeleteOverride godoc
+// @Summary     Günlük vardiya istisnasını kaldırır
+// @Description Removes the override of a date so the weekly template applies again
+// @Tags        Schedule
+// @Produce     json
+// @Param       id path int true "Staff ID"
+// @Param       date path string true "YYYY-MM-DD"
+// @Success     200 {object} map[string]string
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/staff/{id}/schedule/overrides/{date} [delete]
+func (h *ScheduleHandler) DeleteOverride(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid staff id"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	if err := h.scheduleUsecase.DeleteOverride(uint(id), user.HospitalID, c.Params("date")); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"message": "Override deleted"})
+}
+
+// ListOnDuty godoc
+// @Summary     Poliklinikte belirli gün ve saatlerde çalışan personeli listeler
+// @Description Lists staff whose shifts overlap the given time window in a hospital polyclinic
+// @Tags        Schedule
+// @Produce     json
+// @Param       hospital_polyclinic_id query int true "Hospital Polyclinic ID"
+// @Param       date query string true "YYYY-MM-DD"
+// @Param       from query string false "HH:MM (default 00:00)"
+// @Param       to query string false "HH:MM (default end of day)"
+// @Success     200 {array} dto.OnDutyStaff
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/on-duty [get]
+func (h *ScheduleHandler) ListOnDuty(c *fiber.Ctx) error {
+	hpID, err := strconv.ParseUint(c.Query("hospital_polyclinic_id", "0"), 10, 64)
+	if err != nil || hpID == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid hospital_polyclinic_id"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	resp, err := h.scheduleUsecase.ListOnDuty(user.HospitalID, dto.OnDutyFilter{
+		HospitalPolyclinicID: uint(hpID),
+		Date:                 c.Query("date"),
+		From:                 c.Query("from"),
+		To:                   c.Query("to"),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"bufio"
+	"errors"
+	"log"
+
+	"hospital-shared/jwt"
+	"personnel-service/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type StaffExportHandler struct {
+	exportUsecase usecase.StaffExportUsecase
+}
+
+func NewStaffExportHandler(exportUsecase usecase.StaffExportUsecase) *StaffExportHandler {
+	return &StaffExportHandler{exportUsecase: exportUsecase}
+}
+
+// ExportStaff godoc
+// @Summary     Personel listesini dosya olarak dışa aktarır
+// @Description Exports every staff member matching the list filters as CSV, XLSX or PDF. The file is streamed, not paginated. TC numbers are masked and the tc filter is rejected unless the caller is yetkili. CSV cells that could be read as formulas are prefixed with an apostrophe.
+// @Tags        Personnel
+// @Produce     text/csv
+// @Produce     application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce     application/pdf
+// @Param       format query string true "Export format (csv, xlsx, pdf)"
+// @Param       first_name query string false "First name"
+// @Param       last_name query string false "Last name"
+// @Param       tc query string false "TC Kimlik No"
+// @Param       job_group_id query int false "Job Group ID"
+// @Param       title_id query int false "Title ID"
+// @Param       hospital_polyclinic_id query string false "Hospital polyclinic ID or 'unassigned'"
+// @Param       working_day query int false "Weekday with a weekly shift (0 = Sunday)"
+// @Success     200 {file} file
+// @Failure     400 {object} map[string]string
+// @Failure     403 {object} map[string]string
+// @Router      /api/personnel/staff/export [get]
+func (h *StaffExportHandler) ExportStaff(c *fiber.Ctx) error {
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	filter, err := staffListFilter(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	export, err := h.exportUsecase.PrepareExport(user.HospitalID, user.Role, filter, c.Query("format", ""))
+	if errors.Is(err, usecase.ErrTCFilterNotAllowed) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	c.Set(fiber.HeaderContentType, export.ContentType)
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+export.FileName+`"`)
+
+	// Yanıt başladıktan sonra durum kodu değiştirilemez; yarım kalan dosya loglanır
+	hospitalID := user.HospitalID
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := export.Write(w); err != nil {
+			log.Printf("staff export for hospital %d failed: %v", hospitalID, err)
+		}
+		w.Flush()
+	})
+	return nil
+}
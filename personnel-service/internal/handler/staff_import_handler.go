@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"hospital-shared/jwt"
+	"personnel-service/internal/dto"
+	"personnel-service/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Fiber'ın varsayılan gövde sınırı 4MB olduğundan dosya bu sınırın altında tutulur
+const maxImportFileSize = 4 << 20
+
+type StaffImportHandler struct {
+	importUsecase usecase.StaffImportUsecase
+}
+
+func NewStaffImportHandler(importUsecase usecase.StaffImportUsecase) *StaffImportHandler {
+	return &StaffImportHandler{importUsecase: importUsecase}
+}
+
+// ImportStaff godoc
+// @Summary     Dosyadan toplu personel aktarır
+// @Description Starts a bulk staff import from a CSV or XLSX file. Columns are matched by header name (Ad, Soyad, TC, Telefon, Meslek Grubu, Unvan, Poliklinik) unless a columns mapping is given. The file is validated and imported in the background; poll the job for the result. In dry-run mode nothing is saved.
+// @Tags        Personnel
+// @Accept      multipart/form-data
+// @Produce     json
+// @Param       file formData file true "CSV or XLSX file"
+// @Param       columns formData string false "JSON mapping of fields to header names, e.g. {\"first_name\":\"Adı\"}"
+// @Param       dry_run query bool false "Only validate, do not import"
+// @Success     202 {object} dto.StaffImportJobResponse
+// @Failure     400 {object} map[string]string
+// @Router      /api/personnel/staff/import [post]
+func (h *StaffImportHandler) ImportStaff(c *fiber.Ctx) error {
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "file is required"})
+	}
+	if fh.Size > maxImportFileSize {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "file is too large"})
+	}
+
+	var columns map[string]string
+	if v := c.FormValue("columns"); v != "" {
+		if err := json.Unmarshal([]byte(v), &columns); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid columns mapping"})
+		}
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot read file"})
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, maxImportFileSize))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot read file"})
+	}
+
+	var resp *dto.StaffImportJobResponse
+	resp, err = h.importUsecase.StartImport(user.HospitalID, user.AuthorityID, fh.Filename, data, columns, c.QueryBool("dry_run", false))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusAccepted).JSON(resp)
+}
+
+// GetImportJob godoc
+// @Summary     Toplu aktarım işinin durumunu getirir
+// @Description Returns the status of a staff import job with per-row validation errors
+// @Tags        Personnel
+// @Produce     json
+// @Param       id path int true "Import job ID"
+// @Success     200 {object} dto.StaffImportJobResponse
+// @Failure     404 {object} map[string]string
+// @Router      /api/personnel/staff/import/{id} [get]
+func (h *StaffImportHandler) GetImportJob(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid job id"})
+	}
+
+	user := jwt.GetUserInfo(c)
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	var resp *dto.StaffImportJobResponse
+	resp, err = h.importUsecase.GetJob(uint(id), user.HospitalID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(resp)
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	dt "hospital-shared/dto"
+	"hospital-shared/middleware"
+)
+
+type AuthClient interface {
+	// ListHospitalContacts hastanenin verilen roldeki kullanıcılarını e-postalarıyla döner
+	ListHospitalContacts(hospitalID uint, role string) ([]dt.AuthorityContact, error)
+}
+
+type authClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewAuthClient(baseURL, internalToken string) AuthClient {
+	return &authClient{
+		baseURL: baseURL,
+		client: &http.Client{
+			Timeout:   time.Second * 5,
+			Transport: middleware.InternalTransport(internalToken),
+		},
+	}
+}
+
+func (a *authClient) ListHospitalContacts(hospitalID uint, role string) ([]dt.AuthorityContact, error) {
+	u := fmt.Sprintf("%s/api/auth/internal/hospitals/%d/contacts?role=%s", a.baseURL, hospitalID, url.QueryEscape(role))
+	resp, err := a.client.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("invalid status: %d", resp.StatusCode)
+	}
+
+	var contacts []dt.AuthorityContact
+	if err := json.NewDecoder(resp.Body).Decode(&contacts); err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	dt "hospital-shared/dto"
+	"hospital-shared/jwt"
+	"hospital-shared/middleware"
+)
+
+type HospitalClient interface {
+	GetHospitalStatus(hospitalID uint) (string, error)
+}
+
+type hospitalClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewHospitalClient(baseURL, internalToken string) HospitalClient {
+	return &hospitalClient{
+		baseURL: baseURL,
+		client: &http.Client{
+			Timeout:   time.Second * 5,
+			Transport: middleware.InternalTransport(internalToken),
+		},
+	}
+}
+
+func (h *hospitalClient) GetHospitalStatus(hospitalID uint) (string, error) {
+	url := fmt.Sprintf("%s/api/hospital/%d/status", h.baseURL, hospitalID)
+	resp, err := h.client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", jwt.ErrHospitalNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("invalid status: %d", resp.StatusCode)
+	}
+
+	var info dt.HospitalStatusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return "", err
+	}
+
+	return info.Status, nil
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	dt "hospital-shared/dto"
+)
+
+// Aynı kimlik başka bir hastane için sorulduğunda bulunamaz, bu yüzden kayıtlar hastaneyle birlikte tutulur
+type polyclinicCacheKey struct {
+	hospitalID uint
+	id         uint
+}
+
+type cachedPolyclinic struct {
+	value     dt.HospitalPolyclinicResponseDTO
+	found     bool
+	expiresAt time.Time
+	staleAt   time.Time
+}
+
+// cachedPolyclinicClient toplu ad çözümlemesini süreç içinde kısa süreli önbelleğe alır.
+// Tekil sorgular doğrulama için kullanıldığından her zaman hospital servisine gider.
+type cachedPolyclinicClient struct {
+	PolyclinicClient
+	ttl      time.Duration
+	staleTTL time.Duration
+
+	mu      sync.Mutex
+	entries map[polyclinicCacheKey]cachedPolyclinic
+}
+
+// NewCachedPolyclinicClient ttl boyunca önbellekten cevap verir. Hospital servisi hata verirse
+// staleTTL dolmamış eski kayıtlar kullanılır; hiç bilinmeyen kimlikler sonuçta yer almaz.
+func NewCachedPolyclinicClient(inner PolyclinicClient, ttl, staleTTL time.Duration) PolyclinicClient {
+	return &cachedPolyclinicClient{
+		PolyclinicClient: inner,
+		ttl:              ttl,
+		staleTTL:         staleTTL,
+		entries:          make(map[polyclinicCacheKey]cachedPolyclinic),
+	}
+}
+
+func (c *cachedPolyclinicClient) GetHospitalPolyclinicsByIDs(hospitalID uint, ids []uint) (map[uint]dt.HospitalPolyclinicResponseDTO, error) {
+	now := time.Now()
+	result := make(map[uint]dt.HospitalPolyclinicResponseDTO, len(ids))
+	var missing []uint
+
+	c.mu.Lock()
+	for _, id := range ids {
+		entry, ok := c.entries[polyclinicCacheKey{hospitalID, id}]
+		if !ok || now.After(entry.expiresAt) {
+			missing = append(missing, id)
+			continue
+		}
+		if entry.found {
+			result[id] = entry.value
+		}
+	}
+	c.mu.Unlock()
+
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	fetched, err := c.PolyclinicClient.GetHospitalPolyclinicsByIDs(hospitalID, missing)
+	if err != nil {
+		log.Printf("hospital polyclinic lookup failed, serving stale names: %v", err)
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for _, id := range missing {
+			if entry, ok := c.entries[polyclinicCacheKey{hospitalID, id}]; ok && entry.found && now.Before(entry.staleAt) {
+				result[id] = entry.value
+			}
+		}
+		return result, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, id := range missing {
+		hp, found := fetched[id]
+		c.entries[polyclinicCacheKey{hospitalID, id}] = cachedPolyclinic{value: hp, found: found, expiresAt: now.Add(c.ttl), staleAt: now.Add(c.staleTTL)}
+		if found {
+			result[id] = hp
+		}
+	}
+	c.evictExpired(now)
+	return result, nil
+}
+
+// evictExpired bayatlık süresi de geçmiş kayıtları siler; çağıran kilidi tutar
+func (c *cachedPolyclinicClient) evictExpired(now time.Time) {
+	for key, entry := range c.entries {
+		if now.After(entry.staleAt) {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -1,28 +1,39 @@
 package client
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 
 	dt "hospital-shared/dto"
+	"hospital-shared/middleware"
 )
 
 type PolyclinicClient interface {
 	GetHospitalPolyclinicByID(id uint) (*dt.HospitalPolyclinicResponseDTO, error)
+	ListHospitalPolyclinics(hospitalID uint) ([]dt.HospitalPolyclinicResponseDTO, error)
+	// GetHospitalPolyclinicsByIDs hastanenin bulunan polikliniklerini kimliklerine göre döner; silinmiş ya da
+	// başka hastaneye ait olanlar sonuçta yer almaz
+	GetHospitalPolyclinicsByIDs(hospitalID uint, ids []uint) (map[uint]dt.HospitalPolyclinicResponseDTO, error)
 }
 
+// Liste sayfası hospital servisi yavaşken beklememek için daha kısa sürede vazgeçer
+const batchLookupTimeout = 2 * time.Second
+
 type polyclinicClient struct {
 	baseURL string
 	client  *http.Client
 }
 
-func NewPolyclinicClient(baseURL string) PolyclinicClient {
+func NewPolyclinicClient(baseURL, internalToken string) PolyclinicClient {
 	return &polyclinicClient{
 		baseURL: baseURL,
 		client: &http.Client{
-			Timeout: time.Second * 5,
+			Timeout:   time.Second * 5,
+			Transport: middleware.InternalTransport(internalToken),
 		},
 	}
 }
@@ -46,3 +57,62 @@ func (p *polyclinicClient) GetHospitalPolyclinicByID(id uint) (*dt.HospitalPolyc
 
 	return &info, nil
 }
+
+func (p *polyclinicClient) ListHospitalPolyclinics(hospitalID uint) ([]dt.HospitalPolyclinicResponseDTO, error) {
+	url := fmt.Sprintf("%s/api/polyclinic/internal/hospitals/%d/hospital-polyclinics", p.baseURL, hospitalID)
+	resp, err := p.client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("invalid status: %d", resp.StatusCode)
+	}
+
+	var list []dt.HospitalPolyclinicResponseDTO
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (p *polyclinicClient) GetHospitalPolyclinicsByIDs(hospitalID uint, ids []uint) (map[uint]dt.HospitalPolyclinicResponseDTO, error) {
+	result := make(map[uint]dt.HospitalPolyclinicResponseDTO, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	body, err := json.Marshal(dt.HospitalPolyclinicBatchRequest{HospitalID: hospitalID, HospitalPolyclinicIDs: ids})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), batchLookupTimeout)
+	defer cancel()
+	url := fmt.Sprintf("%s/api/polyclinic/internal/hospital-polyclinics/batch", p.baseURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("invalid status: %d", resp.StatusCode)
+	}
+
+	var list []dt.HospitalPolyclinicResponseDTO
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, err
+	}
+	for _, hp := range list {
+		result[hp.ID] = hp
+	}
+	return result, nil
+}
@@ -0,0 +1,221 @@
+// Package pdf tablo biçimindeki raporlar için harici bağımlılık olmadan basit PDF üretir
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// A4 yatay, birimler PDF noktasıdır (1/72 inç)
+const (
+	pageWidth  = 842.0
+	pageHeight = 595.0
+	margin     = 36.0
+	fontSize   = 9.0
+	rowHeight  = 14.0
+)
+
+// Sabit nesneler; sayfa nesneleri bunlardan sonra numaralanır
+const (
+	catalogObj  = 1
+	pagesObj    = 2
+	fontObj     = 3
+	boldFontObj = 4
+)
+
+// Helvetica standart yazı tipidir ve gömülmez. WinAnsi Türkçe harflerin bir kısmını içermediğinden
+// ilgili kodlar Windows-1254'teki yerlerine göre yeniden adlandırılır.
+const fontEncoding = "<< /Type /Encoding /BaseEncoding /WinAnsiEncoding " +
+	"/Differences [208 /Gbreve 221 /Idotaccent 222 /Scedilla 240 /gbreve 253 /dotlessi 254 /scedilla] >>"
+
+type Column struct {
+	Title string
+	Width float64 // nokta; toplam genişlik sayfaya sığmalıdır
+}
+
+// TableWriter satırları sayfalara bölerek yazar; her sayfa dolduğunda çıktıya aktarılır,
+// tüm tablo bellekte tutulmaz
+type TableWriter struct {
+	w       *countingWriter
+	title   string
+	columns []Column
+	offsets map[int]int64
+	nextObj int
+	pageIDs []int
+	page    bytes.Buffer
+	y       float64
+}
+
+func NewTableWriter(w io.Writer, title string, columns []Column) (*TableWriter, error) {
+	t := &TableWriter{
+		w:       &countingWriter{w: w},
+		title:   title,
+		columns: columns,
+		offsets: make(map[int]int64),
+		nextObj: boldFontObj + 1,
+	}
+	if _, err := io.WriteString(t.w, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n"); err != nil {
+		return nil, err
+	}
+	if err := t.writeObject(fontObj, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding "+fontEncoding+" >>"); err != nil {
+		return nil, err
+	}
+	if err := t.writeObject(boldFontObj, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding "+fontEncoding+" >>"); err != nil {
+		return nil, err
+	}
+	t.startPage()
+	return t, nil
+}
+
+func (t *TableWriter) WriteRow(cells []string) error {
+	if t.y-rowHeight < margin {
+		if err := t.flushPage(); err != nil {
+			return err
+		}
+		t.startPage()
+	}
+	t.writeCells("F1", cells)
+	return nil
+}
+
+// Close son sayfayı, sayfa ağacını ve xref tablosunu yazar
+func (t *TableWriter) Close() error {
+	if err := t.flushPage(); err != nil {
+		return err
+	}
+
+	var kids bytes.Buffer
+	for _, id := range t.pageIDs {
+		fmt.Fprintf(&kids, "%d 0 R ", id)
+	}
+	if err := t.writeObject(pagesObj, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", kids.String(), len(t.pageIDs))); err != nil {
+		return err
+	}
+	if err := t.writeObject(catalogObj, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesObj)); err != nil {
+		return err
+	}
+
+	xrefAt := t.w.n
+	size := t.nextObj
+	var xref bytes.Buffer
+	fmt.Fprintf(&xref, "xref\n0 %d\n0000000000 65535 f \n", size)
+	for id := 1; id < size; id++ {
+		fmt.Fprintf(&xref, "%010d 00000 n \n", t.offsets[id])
+	}
+	fmt.Fprintf(&xref, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", size, catalogObj, xrefAt)
+	_, err := t.w.Write(xref.Bytes())
+	return err
+}
+
+func (t *TableWriter) startPage() {
+	t.page.Reset()
+	t.y = pageHeight - margin - 12
+
+	t.text("F2", 12, margin, t.y, t.title)
+	pageLabel := "Sayfa " + strconv.Itoa(len(t.pageIDs)+1)
+	t.text("F1", fontSize, pageWidth-margin-60, t.y, pageLabel)
+	t.text("F1", fontSize, pageWidth-margin-200, t.y, time.Now().Format("02.01.2006 15:04"))
+	t.y -= 24
+
+	titles := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		titles[i] = c.Title
+	}
+	t.writeCells("F2", titles)
+	fmt.Fprintf(&t.page, "0.5 w %.2f %.2f m %.2f %.2f l S\n", margin, t.y+rowHeight-4, pageWidth-margin, t.y+rowHeight-4)
+}
+
+func (t *TableWriter) writeCells(font string, cells []string) {
+	x := margin
+	for i, c := range t.columns {
+		if i < len(cells) {
+			t.text(font, fontSize, x, t.y, fit(cells[i], c.Width))
+		}
+		x += c.Width
+	}
+	t.y -= rowHeight
+}
+
+func (t *TableWriter) text(font string, size, x, y float64, s string) {
+	fmt.Fprintf(&t.page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, encodeText(s))
+}
+
+func (t *TableWriter) flushPage() error {
+	contentID := t.nextObj
+	pageID := t.nextObj + 1
+	t.nextObj += 2
+
+	content := fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", t.page.Len(), t.page.String())
+	if err := t.writeObject(contentID, content); err != nil {
+		return err
+	}
+	page := fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.0f %.0f] /Contents %d 0 R "+
+		"/Resources << /Font << /F1 %d 0 R /F2 %d 0 R >> >> >>",
+		pagesObj, pageWidth, pageHeight, contentID, fontObj, boldFontObj)
+	if err := t.writeObject(pageID, page); err != nil {
+		return err
+	}
+	t.pageIDs = append(t.pageIDs, pageID)
+	return nil
+}
+
+func (t *TableWriter) writeObject(id int, body string) error {
+	t.offsets[id] = t.w.n
+	_, err := fmt.Fprintf(t.w, "%d 0 obj\n%s\nendobj\n", id, body)
+	return err
+}
+
+// fit metni sütuna sığacak şekilde kısaltır. Helvetica'da ortalama karakter genişliği
+// yazı boyutunun yarısı civarında olduğundan yaklaşık bir hesap yeterlidir.
+func fit(s string, width float64) string {
+	max := int((width - 4) / (fontSize * 0.55))
+	r := []rune(s)
+	if max <= 2 || len(r) <= max {
+		return s
+	}
+	return string(r[:max-2]) + ".."
+}
+
+// Windows-1254'te Latin-1'den farklı olan Türkçe harfler
+var turkishCodes = map[rune]byte{
+	'Ğ': 0xD0, 'İ': 0xDD, 'Ş': 0xDE,
+	'ğ': 0xF0, 'ı': 0xFD, 'ş': 0xFE,
+}
+
+// encodeText metni yazı tipi kodlamasına çevirir ve PDF dizgisi için kaçışlar;
+// karşılığı olmayan karakterler '?' olur
+func encodeText(s string) string {
+	var b bytes.Buffer
+	for _, r := range s {
+		var c byte
+		switch code, ok := turkishCodes[r]; {
+		case ok:
+			c = code
+		case r >= 0x20 && r < 0x7F:
+			c = byte(r)
+		case r >= 0xA0 && r <= 0xFF && r != 0xD0 && r != 0xDD && r != 0xDE && r != 0xF0 && r != 0xFD && r != 0xFE:
+			c = byte(r)
+		default:
+			c = '?'
+		}
+		if c == '(' || c == ')' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,46 @@
+package pdf
+
+import "testing"
+
+func TestEncodeText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Ahmet Kaya", "Ahmet Kaya"},
+		// Ğ İ Ş ğ ı ş Windows-1254 kodlarına, Ç Ö Ü ç ö ü Latin-1'deki yerlerine gider
+		{"ĞİŞğış", "\xd0\xdd\xde\xf0\xfd\xfe"},
+		{"ÇÖÜçöü", "\xc7\xd6\xdc\xe7\xf6\xfc"},
+		{"Şükrü Yılmaz", "\xde\xfckr\xfc Y\xfdlmaz"},
+		// Latin-1'de bu kodlardaki harfler yazı tipinde Türkçe harflere dönüştüğü için basılamaz
+		{"ÐÝÞðýþ", "??????"},
+		{"(a) \\ b", `\(a\) \\ b`},
+		{"€ ✓\t", "? ??"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := encodeText(tt.in); got != tt.want {
+			t.Errorf("encodeText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		in    string
+		width float64
+		want  string
+	}{
+		{"Kardiyoloji", 80, "Kardiyoloji"},
+		// 54 noktaya 10 karakter sığar; Türkçe harfler bayt değil karakter olarak sayılır
+		{"Şükrü Yılmaz Öztürk", 54, "Şükrü Yı.."},
+		{"Çocuk Sağlığı", 54, "Çocuk Sa.."},
+		{"0123456789", 54, "0123456789"},
+		// Çok dar sütunda kısaltma yapılmaz
+		{"Göğüs Hastalıkları", 10, "Göğüs Hastalıkları"},
+	}
+	for _, tt := range tests {
+		if got := fit(tt.in, tt.width); got != tt.want {
+			t.Errorf("fit(%q, %v) = %q, want %q", tt.in, tt.width, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestXLSXWriterRoundTrip(t *testing.T) {
+	rows := [][]string{
+		{"TC", "Ad", "Soyad", "Poliklinik"},
+		{"01234567890", "Şükrü", "Yılmaz", "Göğüs Hastalıkları"},
+		{"12345678950", "Ayşe <Gül>", "O'Neil & Co", ""},
+		{"=1+1", "  boşluklu  ", "satır\nsonu", "Kardiyoloji"},
+	}
+
+	var buf bytes.Buffer
+	w, err := NewXLSXWriter(&buf, "Personel & Ekip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range rows {
+		if err := w.WriteRow(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Read("export.xlsx", buf.Bytes())
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("round trip = %q, want %q", got, rows)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/_rels/workbook.xml.rels", "xl/workbook.xml", "xl/worksheets/sheet1.xml"} {
+		f, ok := files[name]
+		if !ok {
+			t.Errorf("part %s is missing", name)
+			continue
+		}
+		var root struct {
+			XMLName xml.Name
+		}
+		if err := decodeZipXML(f, &root); err != nil {
+			t.Errorf("part %s is not valid XML: %v", name, err)
+		}
+	}
+
+	var wb struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := decodeZipXML(files["xl/workbook.xml"], &wb); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, s := range wb.Sheets {
+		names = append(names, s.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"Personel & Ekip"}) {
+		t.Errorf("sheet names = %q, want [Personel & Ekip]", names)
+	}
+}
@@ -0,0 +1,82 @@
+// Package jobs servis içinde zamanlanmış çalışan arka plan işlerini içerir
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Job her gün Hour'da çalışan bir iştir; Name aynı zamanda kilit anahtarıdır
+type Job struct {
+	Name string
+	Hour int
+	Run  func(now time.Time) error
+}
+
+// Günlük işlerin advisory lock sınıfı; diğer advisory lock kullanımlarıyla çakışmaz
+const jobLockClass = 7302
+
+// Daily job'u servis açılırken bir kez, ardından her gün loc saat dilimine göre job.Hour'da
+// çalıştırır; ctx iptal edilince durur. Açılıştaki çalıştırma yeniden başlatmalarda günün
+// kaçırılmaması içindir. Birden fazla instance aynı anda çalıştırmasın diye iş advisory lock
+// altında yürür, kilidi alamayan instance o turu atlar. Yine de job'un aynı gün birden fazla
+// çağrılmaya dayanıklı olması gerekir.
+func Daily(ctx context.Context, db *gorm.DB, loc *time.Location, job Job) {
+	hour := job.Hour
+	if hour < 0 || hour > 23 {
+		hour = 8
+	}
+	run := func() {
+		ran, err := runLocked(ctx, db, job)
+		if err != nil {
+			log.Printf("%s failed: %v", job.Name, err)
+		} else if !ran {
+			log.Printf("%s skipped: running on another instance", job.Name)
+		}
+	}
+
+	go func() {
+		run()
+		for {
+			timer := time.NewTimer(time.Until(nextRun(time.Now(), hour, loc)))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				run()
+			}
+		}
+	}()
+}
+
+// runLocked kilit bir transaction boyunca tutulur; job kendi bağlantılarını kullanır,
+// transaction yalnızca kilidi taşır ve iş bitince ya da servis düşünce kilit bırakılır
+func runLocked(ctx context.Context, db *gorm.DB, job Job) (bool, error) {
+	ran := false
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?, hashtext(?))", jobLockClass, job.Name).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+		ran = true
+		return job.Run(time.Now())
+	})
+	return ran, err
+}
+
+// nextRun now'dan sonraki ilk hour:00 anıdır
+func nextRun(now time.Time, hour int, loc *time.Location) time.Time {
+	local := now.In(loc)
+	next := time.Date(local.Year(), local.Month(), local.Day(), hour, 0, 0, 0, loc)
+	if !next.After(local) {
+		next = time.Date(local.Year(), local.Month(), local.Day()+1, hour, 0, 0, 0, loc)
+	}
+	return next
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Atama geçmişi kaydının nasıl oluştuğu
+const (
+	AssignmentInitial           = "initial"            // personel eklendiğinde
+	AssignmentUpdate            = "update"             // personel düzenlemesiyle; artık yalnızca eski kayıtlarda
+	AssignmentTransfer          = "transfer"           // poliklinik değişikliği
+	AssignmentPromotion         = "promotion"          // meslek grubu/unvan değişikliği
+	AssignmentTransferPromotion = "transfer_promotion" // ikisi birlikte
+	AssignmentReassign          = "reassign"           // poliklinik kaldırıldığında ya da hospital servisinin toplu taşımasında
+	AssignmentBackfill          = "backfill"           // geçmiş tutulmadan önce eklenmiş personelin geçiş günündeki durumu; öncesi bilinmez
+)
+
+// StaffAssignment personelin bir dönemdeki polikliniği, meslek grubu ve unvanıdır. ValidFrom dahil,
+// ValidTo hariçtir; ValidTo boşsa güncel atamadır. Geçmiş kaydı olduğu için silinmez ve personel
+// silindiğinde yalnızca kapatılır.
+type StaffAssignment struct {
+	ID                   uint       `gorm:"primaryKey"`
+	StaffID              uint       `gorm:"not null;index"`
+	HospitalID           uint       `gorm:"not null;index"`
+	HospitalPolyclinicID *uint      `gorm:"index"`
+	JobGroupID           uint       `gorm:"not null"`
+	TitleID              uint       `gorm:"not null"`
+	ValidFrom            time.Time  `gorm:"type:date;not null;index"`
+	ValidTo              *time.Time `gorm:"type:date;index"`
+	ChangeType           string     `gorm:"not null"`
+	Reason               string     `gorm:"not null;default:''"`
+	ApprovedBy           *uint      // onaylayan yetkilinin authority ID'si; otomatik kayıtlarda boş
+	CreatedAt            time.Time
+
+	Staff    Staff    `gorm:"foreignKey:StaffID;-:migration"`
+	JobGroup JobGroup `gorm:"foreignKey:JobGroupID;-:migration"`
+	Title    Title    `gorm:"foreignKey:TitleID;-:migration"`
+}
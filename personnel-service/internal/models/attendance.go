@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	AttendanceIn  = "in"
+	AttendanceOut = "out"
+
+	AttendanceSourceKiosk  = "kiosk"
+	AttendanceSourceManual = "manual"
+)
+
+// Kiosk giriş/çıkış için dönen QR kodu gösteren cihazdır.
+// Cihaz KeyHash'i tutulan API anahtarıyla doğrulanır, QR kodları Secret ile imzalanır
+type Kiosk struct {
+	gorm.Model
+	HospitalID uint   `gorm:"not null;index"`
+	Name       string `gorm:"not null"`
+	Location   string
+	KeyHash    string `gorm:"not null;uniqueIndex"`
+	Secret     string `gorm:"not null"`
+	Active     bool   `gorm:"not null;default:true"`
+}
+
+// AttendanceEvent personelin giriş ya da çıkış kaydıdır.
+// Aynı QR kodu aynı personel için ikinci kez kullanılamaz (kiosk + token adımı tekildir)
+type AttendanceEvent struct {
+	gorm.Model
+	StaffID    uint      `gorm:"not null;index:idx_attendance_staff_time;uniqueIndex:idx_attendance_kiosk_token"`
+	HospitalID uint      `gorm:"not null;index"`
+	Type       string    `gorm:"not null"` // in | out
+	OccurredAt time.Time `gorm:"not null;index:idx_attendance_staff_time"`
+	Source     string    `gorm:"not null"` // kiosk | manual
+	KioskID    *uint     `gorm:"uniqueIndex:idx_attendance_kiosk_token"`
+	TokenStep  *int64    `gorm:"uniqueIndex:idx_attendance_kiosk_token"`
+	Reason     string    // elle girişte zorunlu
+	CreatedBy  *uint     // elle giren yetkilinin AuthorityID'si
+}
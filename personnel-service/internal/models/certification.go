@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Sertifika türleri; BLS ve ACLS süreli olduğundan bitiş tarihi zorunludur
+const (
+	CertificationDiploma   = "diploma"
+	CertificationSpecialty = "specialty"
+	CertificationBLS       = "bls"
+	CertificationACLS      = "acls"
+	CertificationOther     = "other"
+)
+
+// Certification personelin diploma, uzmanlık belgesi ya da BLS/ACLS gibi sertifikasıdır
+type Certification struct {
+	gorm.Model
+	StaffID    uint       `gorm:"not null;index"`
+	HospitalID uint       `gorm:"not null;index"`
+	Type       string     `gorm:"not null"`
+	Number     string     `gorm:"not null;default:''"`
+	Issuer     string     `gorm:"not null"`
+	IssuedAt   time.Time  `gorm:"type:date;not null"`
+	ExpiresAt  *time.Time `gorm:"type:date;index"` // diploma gibi süresiz belgelerde boş
+	// Yaklaşan bitiş için yetkililere bildirim gönderildiği an; bitiş tarihi değişince sıfırlanır
+	ExpiryNotifiedAt *time.Time
+	Document         *CertificationDocument `gorm:"foreignKey:CertificationID"`
+	// Süresi yaklaşanlar listelenirken ad ve unvan için join edilir
+	Staff Staff `gorm:"foreignKey:StaffID;-:migration"`
+}
+
+// CertificationDocument sertifikanın yüklenen taranmış belgesidir. Listelerde Data okunmaz.
+type CertificationDocument struct {
+	ID              uint   `gorm:"primaryKey"`
+	CertificationID uint   `gorm:"uniqueIndex;not null"`
+	FileName        string `gorm:"not null"`
+	ContentType     string `gorm:"not null"`
+	Size            int64  `gorm:"not null"`
+	Data            []byte `gorm:"type:bytea;not null"`
+	CreatedAt       time.Time
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	LeaveStatusPending   = "pending"
+	LeaveStatusApproved  = "approved"
+	LeaveStatusRejected  = "rejected"
+	LeaveStatusCancelled = "cancelled"
+)
+
+// LeaveType izin türüdür; YearlyDays 0 ise (hastalık izni gibi) bakiye takibi yapılmaz
+type LeaveType struct {
+	gorm.Model
+	Code       string `gorm:"unique;not null"`
+	Name       string `gorm:"unique;not null"`
+	YearlyDays int    `gorm:"not null;default:0"`
+}
+
+// LeaveEntitlement personelin bir yıl için izin hakkıdır; yoksa izin türünün varsayılanı geçerlidir
+type LeaveEntitlement struct {
+	gorm.Model
+	StaffID     uint `gorm:"not null;uniqueIndex:idx_leave_entitlement"`
+	LeaveTypeID uint `gorm:"not null;uniqueIndex:idx_leave_entitlement"`
+	Year        int  `gorm:"not null;uniqueIndex:idx_leave_entitlement"`
+	Days        int  `gorm:"not null"`
+}
+
+// LeaveRequest izin talebidir; Days aralıktaki çalışma günü sayısıdır
+type LeaveRequest struct {
+	gorm.Model
+	StaffID     uint      `gorm:"not null;index"`
+	HospitalID  uint      `gorm:"not null;index"`
+	LeaveTypeID uint      `gorm:"not null"`
+	StartDate   time.Time `gorm:"type:date;not null;index"`
+	EndDate     time.Time `gorm:"type:date;not null;index"`
+	Days        int       `gorm:"not null"`
+	Status      string    `gorm:"not null;default:pending;index"`
+	Reason      string
+	ReviewedBy  *uint // onaylayan/reddeden yetkilinin AuthorityID'si
+	ReviewedAt  *time.Time
+	ReviewNote  string
+	LeaveType   LeaveType `gorm:"foreignKey:LeaveTypeID"`
+}
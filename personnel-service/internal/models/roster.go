@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	RosterStatusDraft     = "draft"
+	RosterStatusPublished = "published"
+)
+
+// Roster bir tarih aralığı için nöbet listesidir; taslak olarak üretilir, gözden geçirilip yayınlanır.
+// HospitalPolyclinicID boşsa liste hastanenin tamamı içindir
+type Roster struct {
+	gorm.Model
+	HospitalID           uint      `gorm:"not null;index"`
+	HospitalPolyclinicID *uint     `gorm:"index"`
+	StartDate            time.Time `gorm:"type:date;not null"`
+	EndDate              time.Time `gorm:"type:date;not null"`
+	DutyStart            string    `gorm:"not null"` // HH:MM
+	DutyEnd              string    `gorm:"not null"` // HH:MM, başlangıçtan küçük ya da eşitse ertesi gün biter
+	MinRestHours         int       `gorm:"not null"`
+	MaxShiftsPerStaff    int       `gorm:"not null"`
+	Status               string    `gorm:"not null;default:draft;index"`
+	PublishedAt          *time.Time
+	Requirements         []RosterRequirement `gorm:"foreignKey:RosterID"`
+	Holidays             []RosterHoliday     `gorm:"foreignKey:RosterID"`
+	Assignments          []RosterAssignment  `gorm:"foreignKey:RosterID"`
+}
+
+// RosterRequirement her nöbet günü için meslek grubu ya da unvan bazında en az personel sayısıdır
+type RosterRequirement struct {
+	gorm.Model
+	RosterID   uint `gorm:"not null;index"`
+	JobGroupID *uint
+	TitleID    *uint
+	MinStaff   int `gorm:"not null"`
+}
+
+// RosterHoliday hafta sonu gibi ağır sayılan resmi tatil günüdür
+type RosterHoliday struct {
+	gorm.Model
+	RosterID uint      `gorm:"not null;index"`
+	Date     time.Time `gorm:"type:date;not null"`
+}
+
+type RosterAssignment struct {
+	gorm.Model
+	RosterID uint      `gorm:"not null;uniqueIndex:idx_roster_assignment"`
+	StaffID  uint      `gorm:"not null;uniqueIndex:idx_roster_assignment;index"`
+	Date     time.Time `gorm:"type:date;not null;uniqueIndex:idx_roster_assignment"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ShiftTemplate personelin haftalık vardiyasıdır; bir gün için birden fazla vardiya olabilir.
+// EndTime StartTime'dan küçük ya da eşitse vardiya ertesi güne taşar (gece nöbeti gibi)
+type ShiftTemplate struct {
+	gorm.Model
+	StaffID              uint         `gorm:"not null;index"`
+	Weekday              time.Weekday `gorm:"not null"` // 0 = Pazar ... 6 = Cumartesi
+	StartTime            string       `gorm:"not null"` // HH:MM
+	EndTime              string       `gorm:"not null"` // HH:MM
+	HospitalPolyclinicID *uint        `gorm:"index"`    // boşsa personelin kendi polikliniğinde çalışır
+}
+
+// ShiftOverride belirli bir gün için haftalık vardiyaların yerine geçer.
+// Off işaretli kayıt o gün çalışılmadığını belirtir
+type ShiftOverride struct {
+	gorm.Model
+	StaffID              uint      `gorm:"not null;index:idx_shift_override_staff_date"`
+	Date                 time.Time `gorm:"type:date;not null;index:idx_shift_override_staff_date"`
+	Off                  bool      `gorm:"not null;default:false"`
+	StartTime            string
+	EndTime              string
+	HospitalPolyclinicID *uint `gorm:"index"`
+	Note                 string
+}
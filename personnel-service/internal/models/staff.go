@@ -1,6 +1,10 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"personnel-service/pkg/utils"
+
+	"gorm.io/gorm"
+)
 
 type Staff struct {
 	gorm.Model
@@ -8,9 +12,28 @@ type Staff struct {
 	LastName             string `gorm:"not null"`
 	TC                   string `gorm:"unique;not null"`
 	Phone                string `gorm:"unique;not null"`
-	WorkingDays          string `gorm:"not null"` // "1,2,3,4,5"
 	HospitalID           uint   `gorm:"not null"`
 	JobGroupID           uint   `gorm:"not null"`
 	TitleID              uint   `gorm:"not null"`
 	HospitalPolyclinicID *uint
+	// Ad ve soyadın Türkçe karakterleri katlanmış hali; trigram indeksiyle aranır
+	SearchText string `gorm:"type:text;not null;default:''"`
+	// Listeleme için join edilir; kısıtlar JobGroup/Title tarafındaki ilişkilerden gelir
+	JobGroup  JobGroup        `gorm:"foreignKey:JobGroupID;-:migration"`
+	Title     Title           `gorm:"foreignKey:TitleID;-:migration"`
+	Shifts    []ShiftTemplate `gorm:"foreignKey:StaffID"`
+	Overrides []ShiftOverride `gorm:"foreignKey:StaffID"`
+	Leaves    []LeaveRequest  `gorm:"foreignKey:StaffID"`
+	// Personel silinince sertifikaları da silinir ve süre bildirimlerinden çıkar
+	Certifications []Certification `gorm:"foreignKey:StaffID"`
+}
+
+// BeforeSave arama kolonunu her kayıtta isimlerden yeniden üretir
+func (s *Staff) BeforeSave(tx *gorm.DB) error {
+	s.SearchText = StaffSearchText(s.FirstName, s.LastName)
+	return nil
+}
+
+func StaffSearchText(firstName, lastName string) string {
+	return utils.FoldTurkish(firstName + " " + lastName)
 }
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	StaffImportPending   = "pending"
+	StaffImportRunning   = "running"
+	StaffImportValidated = "validated" // dry-run tamamlandı, hatalar satır bazında raporlanır
+	StaffImportCompleted = "completed"
+	StaffImportFailed    = "failed"
+)
+
+// StaffImportJob dosyadan toplu personel aktarımıdır. Satırlardan biri bile hatalıysa hiçbir kayıt eklenmez.
+type StaffImportJob struct {
+	gorm.Model
+	HospitalID   uint   `gorm:"not null;index"`
+	CreatedBy    uint   `gorm:"not null"` // işlemi başlatan yetkilinin AuthorityID'si
+	FileName     string `gorm:"not null"`
+	DryRun       bool   `gorm:"not null;default:false"`
+	Status       string `gorm:"not null;default:pending"`
+	TotalRows    int    `gorm:"not null;default:0"`
+	ValidRows    int    `gorm:"not null;default:0"`
+	ImportedRows int    `gorm:"not null;default:0"`
+	Error        string // satırlardan bağımsız genel hata
+	FinishedAt   *time.Time
+	RowErrors    []StaffImportRowError `gorm:"foreignKey:JobID"`
+}
+
+// StaffImportRowError satır numarası başlık satırı dahil dosyadaki satırdır
+type StaffImportRowError struct {
+	ID      uint   `gorm:"primaryKey"`
+	JobID   uint   `gorm:"not null;index"`
+	Row     int    `gorm:"not null"`
+	Column  string // genel satır hatalarında boştur
+	Message string `gorm:"not null"`
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"personnel-service/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrAssignmentBeforeCurrent geçerlilik tarihi güncel atamanın başlangıcından önceyse döner;
+// geçmiş dönemler sonradan değiştirilemez
+var ErrAssignmentBeforeCurrent = errors.New("effective date cannot be before the start of the current assignment")
+
+// AssignmentChange geçmişe yazılacak değişikliğin türü, sebebi ve onaylayanıdır
+type AssignmentChange struct {
+	Type       string
+	Reason     string
+	ApprovedBy *uint
+}
+
+// AssignmentQuery belirli bir gündeki atamaların filtresidir; sıfır değerli alanlar filtrelenmez
+type AssignmentQuery struct {
+	HospitalID           uint
+	AsOf                 time.Time
+	HospitalPolyclinicID *uint
+	Unassigned           bool
+	JobGroupID           *uint
+	TitleID              *uint
+}
+
+type AssignmentRepository interface {
+	TransferStaff(staff *models.Staff, before models.Staff, from time.Time, change AssignmentChange) (*models.StaffAssignment, error)
+	ListStaffAssignments(staffID, hospitalID uint) ([]models.StaffAssignment, error)
+	ListAssignmentsAsOf(q AssignmentQuery) ([]models.StaffAssignment, error)
+}
+
+type assignmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAssignmentRepository(db *gorm.DB) AssignmentRepository {
+	return &assignmentRepository{db: db}
+}
+
+// assignmentDay otomatik değişikliklerin geçerlilik günüdür; tarih kolonlarıyla uyumlu olsun diye UTC gece yarısıdır
+func assignmentDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// recordAssignment personelin açık atamasını from gününde kapatıp güncel durumu yeni dönem olarak
+// açar. Poliklinik, meslek grubu ve unvan değişmemişse bir şey yapmaz. Aynı gün içindeki birden
+// fazla değişiklik sıfır uzunluklu dönemler bırakır; tarih sorgularında görünmezler.
+func recordAssignment(tx *gorm.DB, staff *models.Staff, from time.Time, change AssignmentChange) (*models.StaffAssignment, error) {
+	var open models.StaffAssignment
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("staff_id = ? AND valid_to IS NULL", staff.ID).
+		First(&open).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+	case err != nil:
+		return nil, err
+	default:
+		if sameHospitalPolyclinic(open.HospitalPolyclinicID, staff.HospitalPolyclinicID) &&
+			open.JobGroupID == staff.JobGroupID && open.TitleID == staff.TitleID {
+			return nil, nil
+		}
+		if from.Before(open.ValidFrom) {
+			return nil, ErrAssignmentBeforeCurrent
+		}
+		if err := tx.Model(&open).Update("valid_to", from).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	next := &models.StaffAssignment{
+		StaffID:              staff.ID,
+		HospitalID:           staff.HospitalID,
+		HospitalPolyclinicID: staff.HospitalPolyclinicID,
+		JobGroupID:           staff.JobGroupID,
+		TitleID:              staff.TitleID,
+		ValidFrom:            from,
+		ChangeType:           change.Type,
+		Reason:               change.Reason,
+		ApprovedBy:           change.ApprovedBy,
+	}
+	if err := tx.Omit(clause.Associations).Create(next).Error; err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// closeAssignment silinen personelin açık atamasını kapatır
+func closeAssignment(tx *gorm.DB, staffID uint, at time.Time) error {
+	return tx.Model(&models.StaffAssignment{}).
+		Where("staff_id = ? AND valid_to IS NULL", staffID).
+		Update("valid_to", at).Error
+}
+
+// TransferStaff personelin polikliniğini, meslek grubunu ve unvanını from gününden geçerli olarak değiştirir
+func (r *assignmentRepository) TransferStaff(staff *models.Staff, before models.Staff, from time.Time, change AssignmentChange) (*models.StaffAssignment, error) {
+	var assignment *models.StaffAssignment
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(staff).
+			Select("hospital_polyclinic_id", "job_group_id", "title_id", "updated_at").
+			Updates(staff).Error
+		if err != nil {
+			return err
+		}
+		if assignment, err = recordAssignment(tx, staff, from, change); err != nil {
+			return err
+		}
+		return enqueueAssignmentChange(tx, staff, &before)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+func (r *assignmentRepository) ListStaffAssignments(staffID, hospitalID uint) ([]models.StaffAssignment, error) {
+	var assignments []models.StaffAssignment
+	err := r.db.Joins("JobGroup").Joins("Title").
+		Where("staff_assignments.staff_id = ? AND staff_assignments.hospital_id = ?", staffID, hospitalID).
+		Order("staff_assignments.valid_from DESC, staff_assignments.id DESC").
+		Find(&assignments).Error
+	return assignments, err
+}
+
+// ListAssignmentsAsOf verilen gün geçerli olan atamaları döner. Sonradan silinmiş personel de o gün
+// çalıştığı için listelenir.
+func (r *assignmentRepository) ListAssignmentsAsOf(q AssignmentQuery) ([]models.StaffAssignment, error) {
+	query := r.db.Unscoped().Joins("Staff").Joins("JobGroup").Joins("Title").
+		Where("staff_assignments.hospital_id = ?", q.HospitalID).
+		Where("staff_assignments.valid_from <= ? AND (staff_assignments.valid_to IS NULL OR staff_assignments.valid_to > ?)", q.AsOf, q.AsOf)
+	if q.Unassigned {
+		query = query.Where("staff_assignments.hospital_polyclinic_id IS NULL")
+	} else if q.HospitalPolyclinicID != nil {
+		query = query.Where("staff_assignments.hospital_polyclinic_id = ?", *q.HospitalPolyclinicID)
+	}
+	if q.JobGroupID != nil {
+		query = query.Where("staff_assignments.job_group_id = ?", *q.JobGroupID)
+	}
+	if q.TitleID != nil {
+		query = query.Where("staff_assignments.title_id = ?", *q.TitleID)
+	}
+
+	var assignments []models.StaffAssignment
+	err := query.Order(`"Staff".last_name, "Staff".first_name, staff_assignments.staff_id`).Find(&assignments).Error
+	return assignments, err
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"personnel-service/internal/models"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// ErrAttendanceTokenUsed aynı kiosk QR'ı aynı personel için ikinci kez kullanıldığında döner
+var ErrAttendanceTokenUsed = errors.New("qr token already used")
+
+// PostgreSQL unique_violation hata kodu
+const uniqueViolation = "23505"
+
+type AttendanceRepository interface {
+	CreateKiosk(kiosk *models.Kiosk) error
+	ListKiosks(hospitalID uint) ([]models.Kiosk, error)
+	GetKioskByID(id uint) (*models.Kiosk, error)
+	GetKioskByKeyHash(keyHash string) (*models.Kiosk, error)
+	DeactivateKiosk(kiosk *models.Kiosk) error
+
+	FindStaffByTCAndPhone(hospitalID uint, tc, phone string) (*models.Staff, error)
+	GetLastEvent(staffID uint) (*models.AttendanceEvent, error)
+	CreateEvent(event *models.AttendanceEvent) error
+	ListEvents(hospitalID uint, staffID *uint, from, to time.Time) ([]models.AttendanceEvent, error)
+
+	ListTimesheetStaff(hospitalID uint, staffID *uint, from, to time.Time) ([]models.Staff, error)
+}
+
+type attendanceRepository struct {
+	db *gorm.DB
+}
+
+func NewAttendanceRepository(db *gorm.DB) AttendanceRepository {
+	return &attendanceRepository{db: db}
+}
+
+func (r *attendanceRepository) CreateKiosk(kiosk *models.Kiosk) error {
+	return r.db.Create(kiosk).Error
+}
+
+func (r *attendanceRepository) ListKiosks(hospitalID uint) ([]models.Kiosk, error) {
+	var kiosks []models.Kiosk
+	err := r.db.Where("hospital_id = ?", hospitalID).Order("id").Find(&kiosks).Error
+	return kiosks, err
+}
+
+func (r *attendanceRepository) GetKioskByID(id uint) (*models.Kiosk, error) {
+	var kiosk models.Kiosk
+	if err := r.db.First(&kiosk, id).Error; err != nil {
+		return nil, err
+	}
+	return &kiosk, nil
+}
+
+func (r *attendanceRepository) GetKioskByKeyHash(keyHash string) (*models.Kiosk, error) {
+	var kiosk models.Kiosk
+	if err := r.db.Where("key_hash = ? AND active = ?", keyHash, true).First(&kiosk).Error; err != nil {
+		return nil, err
+	}
+	return &kiosk, nil
+}
+
+func (r *attendanceRepository) DeactivateKiosk(kiosk *models.Kiosk) error {
+	return r.db.Model(kiosk).Update("active", false).Error
+}
+
+func (r *attendanceRepository) FindStaffByTCAndPhone(hospitalID uint, tc, phone string) (*models.Staff, error) {
+	var staff models.Staff
+	err := r.db.Where("hospital_id = ? AND tc = ? AND phone = ?", hospitalID, tc, phone).First(&staff).Error
+	if err != nil {
+		return nil, err
+	}
+	return &staff, nil
+}
+
+func (r *attendanceRepository) GetLastEvent(staffID uint) (*models.AttendanceEvent, error) {
+	var event models.AttendanceEvent
+	err := r.db.Where("staff_id = ?", staffID).Order("occurred_at DESC").First(&event).Error
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// CreateEvent kiosk kaydında aynı QR adımı idx_attendance_kiosk_token ile tekrar yazılamaz
+func (r *attendanceRepository) CreateEvent(event *models.AttendanceEvent) error {
+	err := r.db.Create(event).Error
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return ErrAttendanceTokenUsed
+	}
+	return err
+}
+
+func (r *attendanceRepository) ListEvents(hospitalID uint, staffID *uint, from, to time.Time) ([]models.AttendanceEvent, error) {
+	query := r.db.Where("hospital_id = ? AND occurred_at >= ? AND occurred_at < ?", hospitalID, from, to)
+	if staffID != nil {
+		query = query.Where("staff_id = ?", *staffID)
+	}
+
+	var events []models.AttendanceEvent
+	err := query.Order("staff_id, occurred_at").Find(&events).Error
+	return events, err
+}
+
+// Puantaj için personeli vardiya, istisna ve onaylı izinleriyle getirir
+func (r *attendanceRepository) ListTimesheetStaff(hospitalID uint, staffID *uint, from, to time.Time) ([]models.Staff, error) {
+	query := r.db.
+		Preload("Shifts", orderShifts).
+		Preload("Overrides", "date BETWEEN ? AND ?", from, to).
+		Preload("Leaves", onApprovedLeave(from, to)).
+		Where("hospital_id = ?", hospitalID)
+	if staffID != nil {
+		query = query.Where("id = ?", *staffID)
+	}
+
+	var staffs []models.Staff
+	err := query.Order("last_name, first_name, id").Find(&staffs).Error
+	return staffs, err
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"personnel-service/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrCertificationDocumentNotFound sertifikaya belge yüklenmemişse döner
+var ErrCertificationDocumentNotFound = errors.New("certification has no document")
+
+type CertificationRepository interface {
+	CreateCertification(c *models.Certification) error
+	GetCertificationByID(id uint) (*models.Certification, error)
+	UpdateCertification(c *models.Certification) error
+	DeleteCertification(c *models.Certification) error
+	ListStaffCertifications(staffID uint) ([]models.Certification, error)
+
+	SaveDocument(doc *models.CertificationDocument) error
+	GetDocument(certificationID uint) (*models.CertificationDocument, error)
+
+	ListExpiring(hospitalID uint, until time.Time) ([]models.Certification, error)
+	ClaimExpiryNotifications(until, now time.Time) ([]models.Certification, error)
+	ReleaseExpiryNotifications(ids []uint) error
+}
+
+type certificationRepository struct {
+	db *gorm.DB
+}
+
+func NewCertificationRepository(db *gorm.DB) CertificationRepository {
+	return &certificationRepository{db: db}
+}
+
+// documentInfo belge içeriğini okumadan yalnızca bilgilerini yükler
+func documentInfo(db *gorm.DB) *gorm.DB {
+	return db.Select("id, certification_id, file_name, content_type, size, created_at")
+}
+
+func (r *certificationRepository) CreateCertification(c *models.Certification) error {
+	return r.db.Omit(clause.Associations).Create(c).Error
+}
+
+func (r *certificationRepository) GetCertificationByID(id uint) (*models.Certification, error) {
+	var c models.Certification
+	if err := r.db.Preload("Document", documentInfo).First(&c, id).Error; err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *certificationRepository) UpdateCertification(c *models.Certification) error {
+	return r.db.Omit(clause.Associations).Save(c).Error
+}
+
+// DeleteCertification sertifikayı siler, belgesi yer kaplamasın diye kalıcı olarak silinir
+func (r *certificationRepository) DeleteCertification(c *models.Certification) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("certification_id = ?", c.ID).Delete(&models.CertificationDocument{}).Error; err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Delete(c).Error
+	})
+}
+
+func (r *certificationRepository) ListStaffCertifications(staffID uint) ([]models.Certification, error) {
+	var certs []models.Certification
+	err := r.db.Preload("Document", documentInfo).
+		Where("staff_id = ?", staffID).
+		Order("expires_at IS NULL, expires_at, id").
+		Find(&certs).Error
+	return certs, err
+}
+
+// SaveDocument sertifikanın belgesini ekler ya da mevcut belgenin yerine koyar
+func (r *certificationRepository) SaveDocument(doc *models.CertificationDocument) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "certification_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"file_name", "content_type", "size", "data", "created_at"}),
+	}).Create(doc).Error
+}
+
+func (r *certificationRepository) GetDocument(certificationID uint) (*models.CertificationDocument, error) {
+	var doc models.CertificationDocument
+	err := r.db.Where("certification_id = ?", certificationID).First(&doc).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCertificationDocumentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// expiringWithStaff bitiş tarihi until'e kadar olan (geçmişler dahil) sertifikaları personel,
+// meslek grubu ve unvanla birlikte seçer
+func expiringWithStaff(db *gorm.DB, until time.Time) *gorm.DB {
+	return db.InnerJoins("Staff").
+		Joins("Staff.JobGroup").
+		Joins("Staff.Title").
+		Preload("Document", documentInfo).
+		Where("certifications.expires_at IS NOT NULL AND certifications.expires_at <= ?", until).
+		Order("certifications.expires_at, certifications.id")
+}
+
+func (r *certificationRepository) ListExpiring(hospitalID uint, until time.Time) ([]models.Certification, error) {
+	var certs []models.Certification
+	err := expiringWithStaff(r.db, until).
+		Where("certifications.hospital_id = ?", hospitalID).
+		Find(&certs).Error
+	return certs, err
+}
+
+// ClaimExpiryNotifications bildirimi gönderilmemiş ve bitişi until'e kadar olan sertifikaları
+// işaretleyip döner. Satırlar tek sorguda işaretlendiği için aynı anda çalışan başka bir servis
+// örneği aynı sertifikayı almaz.
+func (r *certificationRepository) ClaimExpiryNotifications(until, now time.Time) ([]models.Certification, error) {
+	var ids []uint
+	err := r.db.Raw(`UPDATE certifications SET expiry_notified_at = ? WHERE id IN (
+		SELECT id FROM certifications
+		WHERE deleted_at IS NULL AND expiry_notified_at IS NULL AND expires_at IS NOT NULL AND expires_at <= ?
+		FOR UPDATE SKIP LOCKED) RETURNING id`, now, until).Scan(&ids).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var certs []models.Certification
+	err = expiringWithStaff(r.db, until).
+		Where("certifications.id IN ?", ids).
+		Find(&certs).Error
+	return certs, err
+}
+
+// ReleaseExpiryNotifications bildirimi gönderilemeyen sertifikaları bir sonraki çalıştırmaya bırakır
+func (r *certificationRepository) ReleaseExpiryNotifications(ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&models.Certification{}).
+		Where("id IN ?", ids).
+		Update("expiry_notified_at", nil).Error
+}
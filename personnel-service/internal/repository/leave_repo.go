@@ -0,0 +1,296 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"personnel-service/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrLeaveNotPending          = errors.New("leave request is not pending")
+	ErrInsufficientLeaveBalance = errors.New("insufficient leave balance")
+)
+
+// LeaveRequestQuery izin talebi listesi filtresidir; sıfır değerli alanlar filtrelenmez
+type LeaveRequestQuery struct {
+	HospitalID uint
+	Status     string
+	StaffID    *uint
+	From       *time.Time
+	To         *time.Time
+}
+
+type LeaveRepository interface {
+	ListLeaveTypes() ([]models.LeaveType, error)
+	GetLeaveTypeByID(id uint) (*models.LeaveType, error)
+	GetEntitlements(staffID uint, year int) (map[uint]int, error)
+	UpsertEntitlement(e *models.LeaveEntitlement) error
+
+	CreateLeaveRequest(req *models.LeaveRequest) error
+	GetLeaveRequestByID(id uint) (*models.LeaveRequest, error)
+	ListLeaveRequests(q LeaveRequestQuery, page, size int) ([]models.LeaveRequest, int64, error)
+	HasOverlappingLeave(staffID uint, from, to time.Time) (bool, error)
+	SumLeaveDays(staffID uint, year int) (map[uint]map[string]int, error)
+
+	ApproveLeaveRequest(id, reviewerID uint, note string, allowance int) error
+	CloseLeaveRequest(id uint, fromStatuses []string, status string, reviewerID uint, note string) error
+	PublishLeaveBoundaryCounts(now time.Time) (int, error)
+
+	GetStaffWithSchedule(staffID uint, from, to time.Time) (*models.Staff, error)
+	ListPublishedDutiesOfStaff(staffID uint, from, to time.Time) ([]PublishedDuty, error)
+}
+
+type leaveRepository struct {
+	db *gorm.DB
+}
+
+func NewLeaveRepository(db *gorm.DB) LeaveRepository {
+	return &leaveRepository{db: db}
+}
+
+// onApprovedLeave verilen aralıkla kesişen onaylı izinleri seçer; Preload koşulu olarak kullanılır
+func onApprovedLeave(from, to time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("status = ? AND start_date <= ? AND end_date >= ?", models.LeaveStatusApproved, to, from)
+	}
+}
+
+func (r *leaveRepository) ListLeaveTypes() ([]models.LeaveType, error) {
+	var types []models.LeaveType
+	err := r.db.Order("id").Find(&types).Error
+	return types, err
+}
+
+func (r *leaveRepository) GetLeaveTypeByID(id uint) (*models.LeaveType, error) {
+	var lt models.LeaveType
+	if err := r.db.First(&lt, id).Error; err != nil {
+		return nil, err
+	}
+	return &lt, nil
+}
+
+// Personelin yıl için tanımlı özel izin haklarını tür bazında döner
+func (r *leaveRepository) GetEntitlements(staffID uint, year int) (map[uint]int, error) {
+	var rows []models.LeaveEntitlement
+	if err := r.db.Where("staff_id = ? AND year = ?", staffID, year).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		result[row.LeaveTypeID] = row.Days
+	}
+	return result, nil
+}
+
+func (r *leaveRepository) UpsertEntitlement(e *models.LeaveEntitlement) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "staff_id"}, {Name: "leave_type_id"}, {Name: "year"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"days": e.Days, "updated_at": time.Now(), "deleted_at": nil}),
+	}).Create(e).Error
+}
+
+func (r *leaveRepository) CreateLeaveRequest(req *models.LeaveRequest) error {
+	return r.db.Create(req).Error
+}
+
+func (r *leaveRepository) GetLeaveRequestByID(id uint) (*models.LeaveRequest, error) {
+	var req models.LeaveRequest
+	if err := r.db.Preload("LeaveType").First(&req, id).Error; err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+func (r *leaveRepository) ListLeaveRequests(q LeaveRequestQuery, page, size int) ([]models.LeaveRequest, int64, error) {
+	query := r.db.Model(&models.LeaveRequest{}).Where("hospital_id = ?", q.HospitalID)
+	if q.Status != "" {
+		query = query.Where("status = ?", q.Status)
+	}
+	if q.StaffID != nil {
+		query = query.Where("staff_id = ?", *q.StaffID)
+	}
+	if q.From != nil {
+		query = query.Where("end_date >= ?", *q.From)
+	}
+	if q.To != nil {
+		query = query.Where("start_date <= ?", *q.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var reqs []models.LeaveRequest
+	err := query.Preload("LeaveType").Order("start_date DESC, id DESC").Offset((page - 1) * size).Limit(size).Find(&reqs).Error
+	return reqs, total, err
+}
+
+// Bekleyen ya da onaylı bir izinle kesişme olup olmadığını kontrol eder
+func (r *leaveRepository) HasOverlappingLeave(staffID uint, from, to time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.LeaveRequest{}).
+		Where("staff_id = ? AND status IN ? AND start_date <= ? AND end_date >= ?",
+			staffID, []string{models.LeaveStatusPending, models.LeaveStatusApproved}, to, from).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Yıl içindeki izin günlerini tür ve durum bazında toplar
+func (r *leaveRepository) SumLeaveDays(staffID uint, year int) (map[uint]map[string]int, error) {
+	var rows []struct {
+		LeaveTypeID uint
+		Status      string
+		Days        int
+	}
+	err := r.db.Model(&models.LeaveRequest{}).
+		Select("leave_type_id, status, SUM(days) as days").
+		Where("staff_id = ? AND EXTRACT(YEAR FROM start_date) = ?", staffID, year).
+		Group("leave_type_id, status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint]map[string]int)
+	for _, row := range rows {
+		if result[row.LeaveTypeID] == nil {
+			result[row.LeaveTypeID] = make(map[string]int)
+		}
+		result[row.LeaveTypeID][row.Status] = row.Days
+	}
+	return result, nil
+}
+
+// ApproveLeaveRequest bakiyeyi personel satırı kilitliyken yeniden hesaplayarak talebi onaylar.
+// allowance < 0 ise bakiye kontrolü yapılmaz.
+func (r *leaveRepository) ApproveLeaveRequest(id, reviewerID uint, note string, allowance int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var req models.LeaveRequest
+		if err := tx.First(&req, id).Error; err != nil {
+			return err
+		}
+		// Aynı personelin eşzamanlı onayları sırayla işlenir
+		var staff models.Staff
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "hospital_id", "hospital_polyclinic_id").First(&staff, req.StaffID).Error; err != nil {
+			return err
+		}
+
+		if allowance >= 0 {
+			var used int
+			err := tx.Model(&models.LeaveRequest{}).
+				Select("COALESCE(SUM(days), 0)").
+				Where("staff_id = ? AND leave_type_id = ? AND status = ? AND EXTRACT(YEAR FROM start_date) = ?",
+					req.StaffID, req.LeaveTypeID, models.LeaveStatusApproved, req.StartDate.Year()).
+				Scan(&used).Error
+			if err != nil {
+				return err
+			}
+			if used+req.Days > allowance {
+				return ErrInsufficientLeaveBalance
+			}
+		}
+
+		if err := updateLeaveStatus(tx, id, []string{models.LeaveStatusPending}, models.LeaveStatusApproved, reviewerID, note); err != nil {
+			return err
+		}
+		// Bugünü kapsayan izin personel sayısını değiştirir; sayılar mutlak olduğundan her onayda yayınlanır
+		return enqueueLeaveStaffCounts(tx, &staff)
+	})
+}
+
+func (r *leaveRepository) CloseLeaveRequest(id uint, fromStatuses []string, status string, reviewerID uint, note string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Önceki durum onaylıysa sayım yayınlanacağı için talep değişene kadar kilitlenir
+		var req models.LeaveRequest
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&req, id).Error; err != nil {
+			return err
+		}
+		if err := updateLeaveStatus(tx, id, fromStatuses, status, reviewerID, note); err != nil {
+			return err
+		}
+		// Yalnızca onaylı iznin iptali personeli sayıma geri döndürür
+		if req.Status != models.LeaveStatusApproved {
+			return nil
+		}
+		var staff models.Staff
+		if err := tx.Select("id", "hospital_id", "hospital_polyclinic_id").First(&staff, req.StaffID).Error; err != nil {
+			return err
+		}
+		return enqueueLeaveStaffCounts(tx, &staff)
+	})
+}
+
+func enqueueLeaveStaffCounts(tx *gorm.DB, staff *models.Staff) error {
+	if staff.HospitalPolyclinicID == nil {
+		return nil
+	}
+	return enqueueStaffCounts(tx, staff.HospitalID, []uint{*staff.HospitalPolyclinicID})
+}
+
+// PublishLeaveBoundaryCounts now gününde başlayan ya da bir önceki gün biten onaylı izinlerin
+// polikliniklerinin sayılarını yayınlar; izin günü geldiğinde ya da bittiğinde projeksiyon böylece
+// güncellenir. Gün, sayım saat dilimine göre now'dan hesaplanır.
+func (r *leaveRepository) PublishLeaveBoundaryCounts(now time.Time) (int, error) {
+	day := countDay(now)
+	var refs []hospitalPolyclinicRef
+	err := r.db.Table("leave_requests lr").
+		Select("DISTINCT staffs.hospital_id, staffs.hospital_polyclinic_id").
+		Joins("JOIN staffs ON staffs.id = lr.staff_id AND staffs.deleted_at IS NULL").
+		Where("lr.status = ? AND lr.deleted_at IS NULL AND staffs.hospital_polyclinic_id IS NOT NULL", models.LeaveStatusApproved).
+		Where("lr.start_date = ? OR lr.end_date = ?", day, day.AddDate(0, 0, -1)).
+		Order("staffs.hospital_id, staffs.hospital_polyclinic_id").
+		Scan(&refs).Error
+	if err != nil {
+		return 0, err
+	}
+	return publishStaffCountsOf(r.db, refs, day)
+}
+
+// Durum yalnızca beklenen durumdan değiştirilir; aynı talep iki kez işlenemez
+func updateLeaveStatus(db *gorm.DB, id uint, fromStatuses []string, status string, reviewerID uint, note string) error {
+	res := db.Model(&models.LeaveRequest{}).
+		Where("id = ? AND status IN ?", id, fromStatuses).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"reviewed_by": reviewerID,
+			"reviewed_at": time.Now(),
+			"review_note": note,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrLeaveNotPending
+	}
+	return nil
+}
+
+// İzin günü hesabı için personeli haftalık vardiyaları ve aralıktaki istisnalarıyla getirir
+func (r *leaveRepository) GetStaffWithSchedule(staffID uint, from, to time.Time) (*models.Staff, error) {
+	var staff models.Staff
+	err := r.db.
+		Preload("Shifts", orderShifts).
+		Preload("Overrides", "date BETWEEN ? AND ?", from, to).
+		First(&staff, staffID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &staff, nil
+}
+
+func (r *leaveRepository) ListPublishedDutiesOfStaff(staffID uint, from, to time.Time) ([]PublishedDuty, error) {
+	var duties []PublishedDuty
+	err := r.db.Table("roster_assignments ra").
+		Select("ra.roster_id, ra.staff_id, ra.date, r.duty_start, r.duty_end").
+		Joins("JOIN rosters r ON r.id = ra.roster_id AND r.deleted_at IS NULL").
+		Where("ra.staff_id = ? AND r.status = ?", staffID, models.RosterStatusPublished).
+		Where("ra.date BETWEEN ? AND ? AND ra.deleted_at IS NULL", from, to).
+		Order("ra.date").
+		Scan(&duties).Error
+	return duties, err
+}
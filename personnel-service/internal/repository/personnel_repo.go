@@ -1,11 +1,20 @@
 package repository
 
 import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
 	dt "hospital-shared/dto"
+	"hospital-shared/events"
 	"personnel-service/internal/dto"
 	"personnel-service/internal/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PersonnelRepository interface {
@@ -20,16 +29,28 @@ type PersonnelRepository interface {
 
 	GetStaffByID(id uint) (*models.Staff, error)
 	IsTCOrPhoneExistsExcludeID(id uint, tc, phone string) (bool, error)
+	// UpdateStaff kişisel bilgileri ve haftalık düzeni günceller; atama değişiklikleri TransferStaff ile yapılır
 	UpdateStaff(staff *models.Staff) error
 	DeleteStaff(staff *models.Staff) error
 
-	ListStaffWithFilter(hospitalID uint, filter dto.StaffListFilter, page, size int) ([]models.Staff, error)
+	ListStaffWithFilter(hospitalID uint, filter dto.StaffListFilter, q StaffListQuery) ([]models.Staff, error)
 	CountStaffWithFilter(hospitalID uint, filter dto.StaffListFilter) (int64, error)
+	StreamStaff(hospitalID uint, filter dto.StaffListFilter, batchSize int, fn func([]StaffExportRow) error) error
+	SearchStaff(hospitalID uint, terms []string, limit int, matchTC bool) ([]StaffSearchHit, error)
 
-	CountPersonnel(hospitalPolyclinicID uint) (int64, error)
+	CountPersonnel(hospitalPolyclinicID uint, includeOnLeave bool) (int64, error)
 	GetGroupCountsByHospitalPolyclinicID(hpID uint) ([]dt.PolyclinicPersonnelGroup, error)
+	GetPersonnelStatsByHospitalPolyclinicIDs(hpIDs []uint) (map[uint]*dt.PolyclinicPersonnelStats, error)
+	ReassignStaff(hospitalID uint, from, to *uint, staffIDs []uint) ([]uint, error)
+	ListStaffAfter(afterID uint, limit int) ([]models.Staff, error)
 }
 
+// ErrInvalidStaffCursor imleç bozuk ya da başka bir sıralamaya ait
+var ErrInvalidStaffCursor = errors.New("invalid cursor")
+
+// EventSource outbox olaylarında servis adı olarak kullanılır
+const EventSource = "personnel"
+
 type personnelRepository struct {
 	db *gorm.DB
 }
@@ -77,21 +98,63 @@ func (r *personnelRepository) GetTitleByID(id uint) (*models.Title, error) {
 }
 
 func (r *personnelRepository) CountHospitalHeads(hospitalID uint) (int64, error) {
+	return countHospitalHeads(r.db, hospitalID)
+}
+
+func countHospitalHeads(db *gorm.DB, hospitalID uint) (int64, error) {
 	var count int64
-	err := r.db.Table("staffs").
+	err := db.Table("staffs").
 		Joins("JOIN titles ON staffs.title_id = titles.id").
 		Where("titles.name = ? AND staffs.hospital_id = ?", "Başhekim", hospitalID).
 		Count(&count).Error
 	return count, err
 }
 
+// Başhekim kuralı için advisory lock sınıfı; diğer advisory lock kullanımlarıyla çakışmaz
+const hospitalHeadLockClass = 7303
+
+// ErrHospitalHeadExists hastaneye ikinci başhekim eklenmek istendiğinde döner
+var ErrHospitalHeadExists = errors.New("there can be only one Başhekim in a hospital")
+
+// lockHospitalHeads aynı hastaneye personel ekleyen transactionları sıraya sokar; kuralın
+// eklemeden sonra sayılarak kontrol edilmesi eşzamanlı eklemelerde de doğru sonuç verir
+func lockHospitalHeads(tx *gorm.DB, hospitalID uint) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", hospitalHeadLockClass, hospitalID).Error
+}
+
+// checkHospitalHeads eklemeler yapıldıktan sonra, aynı transaction içinde çağrılır
+func checkHospitalHeads(tx *gorm.DB, hospitalID uint) error {
+	count, err := countHospitalHeads(tx, hospitalID)
+	if err != nil {
+		return err
+	}
+	if count > 1 {
+		return ErrHospitalHeadExists
+	}
+	return nil
+}
+
 func (r *personnelRepository) CreateStaff(staff *models.Staff) error {
-	return r.db.Create(staff).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockHospitalHeads(tx, staff.HospitalID); err != nil {
+			return err
+		}
+		if err := tx.Create(staff).Error; err != nil {
+			return err
+		}
+		if err := checkHospitalHeads(tx, staff.HospitalID); err != nil {
+			return err
+		}
+		if _, err := recordAssignment(tx, staff, assignmentDay(time.Now()), AssignmentChange{Type: models.AssignmentInitial}); err != nil {
+			return err
+		}
+		return enqueueAssignmentChange(tx, staff, nil)
+	})
 }
 
 func (r *personnelRepository) GetStaffByID(id uint) (*models.Staff, error) {
 	var staff models.Staff
-	if err := r.db.First(&staff, id).Error; err != nil {
+	if err := r.db.Preload("Shifts", orderShifts).First(&staff, id).Error; err != nil {
 		return nil, err
 	}
 	return &staff, nil
@@ -100,80 +163,430 @@ func (r *personnelRepository) GetStaffByID(id uint) (*models.Staff, error) {
 func (r *personnelRepository) IsTCOrPhoneExistsExcludeID(id uint, tc, phone string) (bool, error) {
 	var count int64
 	err := r.db.Model(&models.Staff{}).
-		Where("id = ? AND (tc = ? OR phone = ?)", id, tc, phone).
+		Where("id <> ? AND (tc = ? OR phone = ?)", id, tc, phone).
 		Count(&count).Error
 	return count > 0, err
 }
 
 func (r *personnelRepository) UpdateStaff(staff *models.Staff) error {
-	return r.db.Save(staff).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(staff).Error; err != nil {
+			return err
+		}
+		// Shifts nil ise haftalık düzen değişmez
+		if staff.Shifts == nil {
+			return nil
+		}
+		return replaceShifts(tx, staff.ID, staff.Shifts)
+	})
 }
 
 func (r *personnelRepository) DeleteStaff(staff *models.Staff) error {
-	return r.db.Delete(staff).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Select(clause.Associations).Delete(staff).Error; err != nil {
+			return err
+		}
+		if err := closeAssignment(tx, staff.ID, assignmentDay(time.Now())); err != nil {
+			return err
+		}
+		// Silinen personel polikliniğinden ayrılmış sayılır
+		removed := *staff
+		removed.HospitalPolyclinicID = nil
+		return enqueueAssignmentChange(tx, &removed, staff)
+	})
+}
+
+// Poliklinik ataması ya da meslek grubu değiştiyse StaffAssigned olayını ve etkilenen
+// polikliniklerin güncel sayılarını outbox'a yazar. before yeni personel için nil'dir.
+func enqueueAssignmentChange(tx *gorm.DB, after, before *models.Staff) error {
+	if !assignmentChanged(after, before) {
+		return nil
+	}
+	if err := enqueueStaffAssigned(tx, after, before); err != nil {
+		return err
+	}
+	return enqueueStaffCounts(tx, after.HospitalID, affectedPolyclinics(after, before))
+}
+
+func assignmentChanged(after, before *models.Staff) bool {
+	if before == nil {
+		return after.HospitalPolyclinicID != nil
+	}
+	return !sameHospitalPolyclinic(after.HospitalPolyclinicID, before.HospitalPolyclinicID) || after.JobGroupID != before.JobGroupID
+}
+
+// affectedPolyclinics personelin önceki ve yeni polikliniklerini döner
+func affectedPolyclinics(after, before *models.Staff) []uint {
+	var ids []uint
+	if after.HospitalPolyclinicID != nil {
+		ids = append(ids, *after.HospitalPolyclinicID)
+	}
+	if before != nil && before.HospitalPolyclinicID != nil {
+		ids = append(ids, *before.HospitalPolyclinicID)
+	}
+	return ids
+}
+
+// enqueueStaffAssigned atama değişikliğini outbox'a yazar; değişiklik olup olmadığına çağıran bakar
+func enqueueStaffAssigned(tx *gorm.DB, after, before *models.Staff) error {
+	payload := events.StaffAssignedPayload{
+		StaffID:              after.ID,
+		HospitalID:           after.HospitalID,
+		HospitalPolyclinicID: after.HospitalPolyclinicID,
+		JobGroupID:           after.JobGroupID,
+	}
+	if before != nil {
+		payload.PreviousHospitalPolyclinicID = before.HospitalPolyclinicID
+		payload.PreviousJobGroupID = before.JobGroupID
+	}
+
+	if err := tx.Model(&models.JobGroup{}).Where("id = ?", after.JobGroupID).Pluck("name", &payload.JobGroupName).Error; err != nil {
+		return err
+	}
+	return events.Enqueue(tx, EventSource, events.StaffAssigned, payload)
+}
+
+// Sayım kilitleri için advisory lock sınıfı; diğer advisory lock kullanımlarıyla çakışmaz
+const staffCountLockClass = 7301
+
+// enqueueStaffCounts verilen polikliniklerin mutlak personel sayılarını outbox'a yazar.
+// Aynı polikliniği değiştiren transactionlar sayım öncesinde sırayla kilitlenir; böylece
+// sonra sayan önceki değişikliği görür ve CountedAt sırası sayımların sırasıyla aynı olur.
+func enqueueStaffCounts(tx *gorm.DB, hospitalID uint, hpIDs []uint) error {
+	return enqueueStaffCountsOn(tx, hospitalID, hpIDs, countDay(time.Now()))
+}
+
+// enqueueStaffCountsOn sayıları day gününe göre yazar; günlük iş kendi çalıştığı günü verir
+func enqueueStaffCountsOn(tx *gorm.DB, hospitalID uint, hpIDs []uint, day time.Time) error {
+	ids := uniqueSortedIDs(hpIDs)
+	if len(ids) == 0 {
+		return nil
+	}
+	// Kilitler her zaman artan ID sırasıyla alınır, transactionlar birbirini kilitlemez
+	for _, id := range ids {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", staffCountLockClass, id).Error; err != nil {
+			return err
+		}
+	}
+
+	payload := events.PolyclinicStaffCountedPayload{HospitalID: hospitalID}
+	// Sunucular arasındaki saat farkı sırayı bozmasın diye zaman veritabanından alınır
+	if err := tx.Raw("SELECT clock_timestamp()").Scan(&payload.CountedAt).Error; err != nil {
+		return err
+	}
+	stats, err := polyclinicStaffStats(tx, ids, day)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		payload.Stats = append(payload.Stats, *stats[id])
+	}
+	return events.Enqueue(tx, EventSource, events.PolyclinicStaffCounted, payload)
+}
+
+// PublishStaffCounts poliklinikte personeli olan her hastanenin güncel sayılarını yayınlar.
+// Hospital servisindeki projeksiyon ilk kez bu olaylarla dolar; sayılar mutlak olduğundan
+// tekrar yayınlamak zararsızdır ve kaçırılmış olaylardan kalan farkları da kapatır.
+func PublishStaffCounts(db *gorm.DB) (int, error) {
+	var rows []hospitalPolyclinicRef
+	err := db.Model(&models.Staff{}).
+		Distinct("hospital_id", "hospital_polyclinic_id").
+		Where("hospital_polyclinic_id IS NOT NULL").
+		Order("hospital_id, hospital_polyclinic_id").
+		Scan(&rows).Error
+	if err != nil {
+		return 0, err
+	}
+	return publishStaffCountsOf(db, rows, countDay(time.Now()))
+}
+
+type hospitalPolyclinicRef struct {
+	HospitalID           uint
+	HospitalPolyclinicID uint
 }
 
-// Filtreli ve sayfalı personel listesini getirir
-func (r *personnelRepository) ListStaffWithFilter(hospitalID uint, filter dto.StaffListFilter, page, size int) ([]models.Staff, error) {
-	query := r.db.Model(&models.Staff{}).Where("hospital_id = ?", hospitalID)
+// publishStaffCountsOf her hastane için ayrı bir transaction'da day gününün sayılarını yayınlar
+func publishStaffCountsOf(db *gorm.DB, refs []hospitalPolyclinicRef, day time.Time) (int, error) {
+	byHospital := make(map[uint][]uint)
+	var hospitalIDs []uint
+	for _, ref := range refs {
+		if _, ok := byHospital[ref.HospitalID]; !ok {
+			hospitalIDs = append(hospitalIDs, ref.HospitalID)
+		}
+		byHospital[ref.HospitalID] = append(byHospital[ref.HospitalID], ref.HospitalPolyclinicID)
+	}
+	for _, hospitalID := range hospitalIDs {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			return enqueueStaffCountsOn(tx, hospitalID, byHospital[hospitalID], day)
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(hospitalIDs), nil
+}
+
+func uniqueSortedIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	sort.Slice(unique, func(i, j int) bool { return unique[i] < unique[j] })
+	return unique
+}
+
+func sameHospitalPolyclinic(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// filterStaff liste, sayım ve dışa aktarımın ortak filtre koşullarını uygular; sorgular
+// ayrı ayrı filtrelenmediği için toplam sayı ile liste her zaman aynı kayıtları kapsar
+func filterStaff(db *gorm.DB, hospitalID uint, filter dto.StaffListFilter) *gorm.DB {
+	query := db.Where("staffs.hospital_id = ?", hospitalID)
 
 	// Filtreleme alanları
 	if filter.FirstName != "" {
-		query = query.Where("first_name ILIKE ?", "%"+filter.FirstName+"%")
+		query = query.Where("staffs.first_name ILIKE ?", "%"+filter.FirstName+"%")
 	}
 	if filter.LastName != "" {
-		query = query.Where("last_name ILIKE ?", "%"+filter.LastName+"%")
+		query = query.Where("staffs.last_name ILIKE ?", "%"+filter.LastName+"%")
 	}
 	if filter.TC != "" {
-		query = query.Where("tc ILIKE ?", "%"+filter.TC+"%")
+		query = query.Where("staffs.tc ILIKE ?", "%"+filter.TC+"%")
 	}
 	if filter.JobGroupID != nil {
-		query = query.Where("job_group_id = ?", *filter.JobGroupID)
+		query = query.Where("staffs.job_group_id = ?", *filter.JobGroupID)
 	}
 	if filter.TitleID != nil {
-		query = query.Where("title_id = ?", *filter.TitleID)
+		query = query.Where("staffs.title_id = ?", *filter.TitleID)
+	}
+	if filter.Unassigned {
+		query = query.Where("staffs.hospital_polyclinic_id IS NULL")
+	} else if filter.HospitalPolyclinicID != nil {
+		query = query.Where("staffs.hospital_polyclinic_id = ?", *filter.HospitalPolyclinicID)
+	}
+	if filter.WorkingDay != nil {
+		query = query.Where("EXISTS (SELECT 1 FROM shift_templates WHERE shift_templates.staff_id = staffs.id "+
+			"AND shift_templates.weekday = ? AND shift_templates.deleted_at IS NULL)", *filter.WorkingDay)
+	}
+	return query
+}
+
+// StaffListQuery liste sıralaması ve sayfasıdır. After doluysa Offset yerine önceki sayfanın son
+// kaydının sıralama değerlerinden (StaffSortValues) devam edilir.
+type StaffListQuery struct {
+	Sort   string
+	Desc   bool
+	Offset int
+	Limit  int
+	After  []string
+	// PolyclinicNames poliklinik adına göre sıralamada kullanılır; adlar hospital servisinde tutulur
+	PolyclinicNames map[uint]string
+}
+
+// Sıralama ifadeleri; eşitlikte staffs.id eklenerek sıra kararlı hale getirilir.
+// "Title" ListStaffWithFilter'daki Joins("Title") takma adıdır, hp_names ise
+// joinPolyclinicNames'in eklediği ad tablosudur.
+var staffSortColumns = map[string][]string{
+	dto.StaffSortName:       {"staffs.last_name", "staffs.first_name"},
+	dto.StaffSortTitle:      {`"Title".name`},
+	dto.StaffSortCreatedAt:  {"staffs.created_at"},
+	dto.StaffSortPolyclinic: {"COALESCE(hp_names.name, '')"},
+}
+
+// IsStaffSortField sıralama alanının desteklenip desteklenmediğini söyler
+func IsStaffSortField(sort string) bool {
+	_, ok := staffSortColumns[sort]
+	return ok
+}
+
+// StaffSortValues kaydın sıralama değerlerini ve kimliğini imleç olarak kullanılmak üzere döner
+func StaffSortValues(s *models.Staff, q StaffListQuery) []string {
+	var values []string
+	switch q.Sort {
+	case dto.StaffSortName:
+		values = []string{s.LastName, s.FirstName}
+	case dto.StaffSortTitle:
+		values = []string{s.Title.Name}
+	case dto.StaffSortCreatedAt:
+		values = []string{s.CreatedAt.Format(time.RFC3339Nano)}
+	case dto.StaffSortPolyclinic:
+		var name string
+		if s.HospitalPolyclinicID != nil {
+			name = q.PolyclinicNames[*s.HospitalPolyclinicID]
+		}
+		values = []string{name}
+	}
+	return append(values, strconv.FormatUint(uint64(s.ID), 10))
+}
+
+// staffSortArgs imleçteki metin değerlerini sütun tiplerine çevirir
+func staffSortArgs(sort string, values []string) ([]interface{}, error) {
+	cols, ok := staffSortColumns[sort]
+	if !ok || len(values) != len(cols)+1 {
+		return nil, ErrInvalidStaffCursor
+	}
+
+	args := make([]interface{}, 0, len(values))
+	for _, v := range values[:len(cols)] {
+		switch sort {
+		case dto.StaffSortCreatedAt:
+			t, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				return nil, ErrInvalidStaffCursor
+			}
+			args = append(args, t)
+		default:
+			args = append(args, v)
+		}
+	}
+	id, err := strconv.ParseUint(values[len(cols)], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidStaffCursor
+	}
+	return append(args, id), nil
+}
+
+func (r *personnelRepository) ListStaffWithFilter(hospitalID uint, filter dto.StaffListFilter, q StaffListQuery) ([]models.Staff, error) {
+	cols, ok := staffSortColumns[q.Sort]
+	if !ok {
+		return nil, errors.New("unknown sort field")
+	}
+	direction, op := "ASC", ">"
+	if q.Desc {
+		direction, op = "DESC", "<"
+	}
+
+	query := filterStaff(r.db.Model(&models.Staff{}), hospitalID, filter).Joins("JobGroup").Joins("Title")
+	if q.Sort == dto.StaffSortPolyclinic {
+		query = joinPolyclinicNames(query, q.PolyclinicNames)
+	}
+	keyset := append(append([]string{}, cols...), "staffs.id")
+	if q.After != nil {
+		args, err := staffSortArgs(q.Sort, q.After)
+		if err != nil {
+			return nil, err
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ")
+		query = query.Where(fmt.Sprintf("(%s) %s (%s)", strings.Join(keyset, ", "), op, placeholders), args...)
+	} else {
+		query = query.Offset(q.Offset)
+	}
+	for _, col := range keyset {
+		query = query.Order(col + " " + direction)
 	}
 
 	var staffs []models.Staff
-	// Sayfalama
-	if err := query.Offset((page - 1) * size).Limit(size).Find(&staffs).Error; err != nil {
+	if err := query.Preload("Shifts", orderShifts).Limit(q.Limit).Find(&staffs).Error; err != nil {
 		return nil, err
 	}
 	return staffs, nil
 }
 
+// joinPolyclinicNames hospital servisinden gelen adları hp_names olarak sorguya ekler; adı
+// bilinmeyen (silinmiş) poliklinikler atanmamış personelle birlikte başta sıralanır
+func joinPolyclinicNames(query *gorm.DB, names map[uint]string) *gorm.DB {
+	// Boş VALUES yazılamadığından hiçbir personelle eşleşmeyen 0 kimliği her zaman eklenir
+	rows := []string{"(0::bigint, ''::text)"}
+	args := []interface{}{}
+	for id, name := range names {
+		rows = append(rows, "(?::bigint, ?::text)")
+		args = append(args, id, name)
+	}
+	return query.Joins("LEFT JOIN (VALUES "+strings.Join(rows, ", ")+") AS hp_names (id, name) "+
+		"ON hp_names.id = staffs.hospital_polyclinic_id", args...)
+}
+
 // Aynı filtreyle toplam kayıt sayısını bulur
 func (r *personnelRepository) CountStaffWithFilter(hospitalID uint, filter dto.StaffListFilter) (int64, error) {
-	query := r.db.Model(&models.Staff{}).Where("hospital_id = ?", hospitalID)
+	var count int64
+	err := filterStaff(r.db.Model(&models.Staff{}), hospitalID, filter).Count(&count).Error
+	return count, err
+}
 
-	// Filtreleme alanları
-	if filter.FirstName != "" {
-		query = query.Where("first_name ILIKE ?", "%"+filter.FirstName+"%")
-	}
-	if filter.LastName != "" {
-		query = query.Where("last_name ILIKE ?", "%"+filter.LastName+"%")
-	}
-	if filter.TC != "" {
-		query = query.Where("tc ILIKE ?", "%"+filter.TC+"%")
-	}
-	if filter.JobGroupID != nil {
-		query = query.Where("job_group_id = ?", *filter.JobGroupID)
-	}
-	if filter.TitleID != nil {
-		query = query.Where("title_id = ?", *filter.TitleID)
+// StaffExportRow dışa aktarım satırıdır; meslek grubu ve unvan adları sorguda çözülür
+type StaffExportRow struct {
+	ID                   uint
+	FirstName            string
+	LastName             string
+	TC                   string
+	Phone                string
+	JobGroupName         string
+	TitleName            string
+	HospitalPolyclinicID *uint
+}
+
+// StreamStaff filtreye uyan tüm personeli id sırasıyla parça parça fn'e verir. Parçalar arasında
+// bağlantı bırakıldığından yavaş istemciye yazarken veritabanı bağlantısı tutulmaz.
+func (r *personnelRepository) StreamStaff(hospitalID uint, filter dto.StaffListFilter, batchSize int, fn func([]StaffExportRow) error) error {
+	var lastID uint
+	for {
+		var rows []StaffExportRow
+		err := filterStaff(r.db.Model(&models.Staff{}), hospitalID, filter).
+			Select("staffs.id, staffs.first_name, staffs.last_name, staffs.tc, staffs.phone, "+
+				"job_groups.name AS job_group_name, titles.name AS title_name, staffs.hospital_polyclinic_id").
+			Joins("JOIN job_groups ON job_groups.id = staffs.job_group_id").
+			Joins("JOIN titles ON titles.id = staffs.title_id").
+			Where("staffs.id > ?", lastID).
+			Order("staffs.id").
+			Limit(batchSize).
+			Scan(&rows).Error
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		if err := fn(rows); err != nil {
+			return err
+		}
+		if len(rows) < batchSize {
+			return nil
+		}
+		lastID = rows[len(rows)-1].ID
 	}
+}
+
+// countLocation sayımlarda "bugün"ün belirlendiği saat dilimidir; servis açılırken ayarlanır
+var countLocation = time.UTC
+
+// SetCountLocation personel sayımlarının gün sınırları için saat dilimini ayarlar. Veritabanının
+// CURRENT_DATE'i sunucunun saat dilimine göre olduğundan gün uygulamada hesaplanıp sorguya verilir.
+func SetCountLocation(loc *time.Location) {
+	countLocation = loc
+}
+
+// countDay t'nin sayım saat dilimindeki günüdür; tarih kolonlarıyla uyumlu olsun diye UTC gece yarısıdır
+func countDay(t time.Time) time.Time {
+	return assignmentDay(t.In(countLocation))
+}
 
-	var totalCount int64
-	err := query.Count(&totalCount).Error
-	return totalCount, err
+// notOnLeaveOn day günü onaylı izinde olan personeli sayımlardan çıkarır. Tüm personel sayıları
+// (tekil sayım, meslek grubu dağılımı ve hospital servisine yayınlanan sayılar) bu koşulu kullanır.
+func notOnLeaveOn(day time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(`NOT EXISTS (SELECT 1 FROM leave_requests lr WHERE lr.staff_id = staffs.id AND lr.status = ?
+			AND lr.start_date <= ? AND lr.end_date >= ? AND lr.deleted_at IS NULL)`, models.LeaveStatusApproved, day, day)
+	}
 }
 
-// Toplam personel sayısı
-func (r *personnelRepository) CountPersonnel(hospitalPolyclinicID uint) (int64, error) {
+// Aktif personel sayısı; bugün onaylı izinde olanlar sayılmaz
+// CountPersonnel polikliniğe atanmış personeli sayar; includeOnLeave false ise bugün izinde olanlar sayılmaz
+func (r *personnelRepository) CountPersonnel(hospitalPolyclinicID uint, includeOnLeave bool) (int64, error) {
 	var count int64
-	err := r.db.Model(&models.Staff{}).
-		Where("hospital_polyclinic_id = ?", hospitalPolyclinicID).
-		Count(&count).Error
+	query := r.db.Model(&models.Staff{}).
+		Where("hospital_polyclinic_id = ?", hospitalPolyclinicID)
+	if !includeOnLeave {
+		query = query.Scopes(notOnLeaveOn(countDay(time.Now())))
+	}
+	err := query.Count(&count).Error
 
 	return count, err
 }
@@ -185,9 +598,212 @@ func (r *personnelRepository) GetGroupCountsByHospitalPolyclinicID(hpID uint) ([
 	err := r.db.Table("staffs").
 		Select("job_groups.name as group_name, COUNT(*) as count").
 		Joins("JOIN job_groups ON staffs.job_group_id = job_groups.id").
-		Where("staffs.hospital_polyclinic_id = ?", hpID).
+		Where("staffs.hospital_polyclinic_id = ? AND staffs.deleted_at IS NULL", hpID).
+		Scopes(notOnLeaveOn(countDay(time.Now()))).
 		Group("job_groups.name").
 		Scan(&groupCounts).Error
 
 	return groupCounts, err
 }
+
+// Birden fazla poliklinik için toplam ve meslek grubu dağılımını tek sorguda getirir
+func (r *personnelRepository) GetPersonnelStatsByHospitalPolyclinicIDs(hpIDs []uint) (map[uint]*dt.PolyclinicPersonnelStats, error) {
+	return polyclinicStaffStats(r.db, hpIDs, countDay(time.Now()))
+}
+
+// polyclinicStaffStats hem istatistik endpointinin hem de hospital servisine yayınlanan
+// sayıların kaynağıdır; ikisi aynı koşullarla sayar. day günü izinde olanlar sayılmaz.
+func polyclinicStaffStats(db *gorm.DB, hpIDs []uint, day time.Time) (map[uint]*dt.PolyclinicPersonnelStats, error) {
+	result := make(map[uint]*dt.PolyclinicPersonnelStats, len(hpIDs))
+	if len(hpIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		HospitalPolyclinicID uint
+		GroupID              *uint
+		GroupName            *string
+		Count                int
+	}
+	// Meslek grubu olmayan personel toplam sayıya dahil edilir, dağılıma girmez
+	err := db.Table("staffs").
+		Select("staffs.hospital_polyclinic_id, job_groups.id as group_id, job_groups.name as group_name, COUNT(*) as count").
+		Joins("LEFT JOIN job_groups ON staffs.job_group_id = job_groups.id").
+		Where("staffs.hospital_polyclinic_id IN ? AND staffs.deleted_at IS NULL", hpIDs).
+		Scopes(notOnLeaveOn(day)).
+		Group("staffs.hospital_polyclinic_id, job_groups.id, job_groups.name").
+		Order("staffs.hospital_polyclinic_id, job_groups.name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range hpIDs {
+		result[id] = &dt.PolyclinicPersonnelStats{
+			HospitalPolyclinicID: id,
+			Groups:               []dt.PolyclinicPersonnelGroup{},
+		}
+	}
+	for _, row := range rows {
+		stats := result[row.HospitalPolyclinicID]
+		stats.Total += row.Count
+		if row.GroupID != nil && row.GroupName != nil {
+			stats.Groups = append(stats.Groups, dt.PolyclinicPersonnelGroup{GroupID: *row.GroupID, GroupName: *row.GroupName, Count: row.Count})
+		}
+	}
+	return result, nil
+}
+
+// Personelin poliklinik atamasını tek transaction içinde değiştirir, etkilenen personel ID'lerini döner
+func (r *personnelRepository) ReassignStaff(hospitalID uint, from, to *uint, staffIDs []uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&models.Staff{}).Where("hospital_id = ?", hospitalID)
+		if from != nil {
+			query = query.Where("hospital_polyclinic_id = ?", *from)
+		} else {
+			query = query.Where("hospital_polyclinic_id IS NULL")
+		}
+		if len(staffIDs) > 0 {
+			query = query.Where("id IN ?", staffIDs)
+		}
+
+		// Satırlar kilitlenir; aynı anda eklenen/güncellenen personel arada kalmaz
+		var staffs []models.Staff
+		if err := query.Clauses(clause.Locking{Strength: "UPDATE"}).Order("id").Find(&staffs).Error; err != nil {
+			return err
+		}
+		if len(staffs) == 0 {
+			return nil
+		}
+		for _, s := range staffs {
+			ids = append(ids, s.ID)
+		}
+		if err := tx.Model(&models.Staff{}).Where("id IN ?", ids).Update("hospital_polyclinic_id", to).Error; err != nil {
+			return err
+		}
+		today := assignmentDay(time.Now())
+		for i := range staffs {
+			moved := staffs[i]
+			moved.HospitalPolyclinicID = to
+			if _, err := recordAssignment(tx, &moved, today, AssignmentChange{Type: models.AssignmentReassign}); err != nil {
+				return err
+			}
+			if err := enqueueStaffAssigned(tx, &moved, &staffs[i]); err != nil {
+				return err
+			}
+		}
+		// Sayılar tüm taşıma bittikten sonra bir kez yayınlanır
+		var affected []uint
+		if from != nil {
+			affected = append(affected, *from)
+		}
+		if to != nil {
+			affected = append(affected, *to)
+		}
+		return enqueueStaffCounts(tx, hospitalID, affected)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *personnelRepository) ListStaffAfter(afterID uint, limit int) ([]models.Staff, error) {
+	var staffs []models.Staff
+	err := r.db.Select("id", "hospital_id", "hospital_polyclinic_id").
+		Where("id > ?", afterID).
+		Order("id").
+		Limit(limit).
+		Find(&staffs).Error
+	return staffs, err
+}
+
+// StaffSearchHit arama sonucudur; Score eşleşmenin gücüdür, büyük olan önce gelir
+type StaffSearchHit struct {
+	ID                   uint
+	FirstName            string
+	LastName             string
+	TC                   string
+	Phone                string
+	JobGroupID           uint
+	JobGroupName         string
+	TitleID              uint
+	TitleName            string
+	HospitalPolyclinicID *uint
+	Score                int
+}
+
+// Unvan adları az olduğundan ayrı kolon tutulmaz, sorguda katlanır. translate önce çalışır ki
+// lower'ın İ/I için veritabanı yereline bağlı sonucu karşılaştırmayı bozmasın.
+const foldedTitleName = "lower(translate(titles.name, 'ÇçĞğİIıÖöŞşÜüÂâÎîÛû', 'ccggiiioossuuaaiiuu'))"
+
+// Terim koşulundaki her OR kolu kendi indeksiyle aranabilmeli, yoksa planlayıcı BitmapOr yerine
+// hastanenin tüm personelini tarar. Unvan eşleşmesi bu yüzden join üzerinden değil küçük titles
+// tablosundan bulunan kimliklerle yapılır (idx_staffs_title_id).
+const titleIDsMatching = "staffs.title_id IN (SELECT titles.id FROM titles WHERE " + foldedTitleName + " LIKE ?)"
+
+// SearchStaff katlanmış arama terimlerinin hepsine uyan personeli sıralı döner. Her terim ad/soyad,
+// unvan, rakamsa TC başı ya da telefon içinde aranır. Tam ad ve ad başı eşleşmeleri, TC başı
+// eşleşmeleri içerik eşleşmelerinden önce gelir. matchTC false ise TC'de aranmaz.
+func (r *personnelRepository) SearchStaff(hospitalID uint, terms []string, limit int, matchTC bool) ([]StaffSearchHit, error) {
+	text := strings.Join(terms, " ")
+	scores := []string{"CASE WHEN staffs.search_text = ? THEN 100 WHEN staffs.search_text LIKE ? THEN 60 " +
+		"WHEN staffs.search_text LIKE ? THEN 40 ELSE 0 END"}
+	scoreArgs := []interface{}{text, text + "%", "% " + text + "%"}
+
+	query := r.db.Model(&models.Staff{}).
+		Joins("JOIN job_groups ON job_groups.id = staffs.job_group_id").
+		Joins("JOIN titles ON titles.id = staffs.title_id").
+		Where("staffs.hospital_id = ?", hospitalID)
+
+	for _, t := range terms {
+		conds := []string{"staffs.search_text LIKE ?", titleIDsMatching}
+		args := []interface{}{"%" + t + "%", "%" + t + "%"}
+		scores = append(scores,
+			"CASE WHEN staffs.search_text LIKE ? OR staffs.search_text LIKE ? THEN 10 WHEN staffs.search_text LIKE ? THEN 4 ELSE 0 END",
+			"CASE WHEN "+foldedTitleName+" LIKE ? THEN 3 ELSE 0 END")
+		scoreArgs = append(scoreArgs, t+"%", "% "+t+"%", "%"+t+"%", "%"+t+"%")
+
+		if isDigits(t) {
+			// Telefonlar başında 0 ile ya da 0 olmadan kayıtlı olabilir
+			phone := strings.TrimPrefix(t, "0")
+			if phone == "" {
+				phone = t
+			}
+			conds = append(conds, "staffs.phone LIKE ?")
+			args = append(args, "%"+phone+"%")
+			scores = append(scores, "CASE WHEN staffs.phone LIKE ? THEN 20 ELSE 0 END")
+			scoreArgs = append(scoreArgs, "%"+phone+"%")
+			if matchTC {
+				conds = append(conds, "staffs.tc LIKE ?")
+				args = append(args, t+"%")
+				scores = append(scores, "CASE WHEN staffs.tc = ? THEN 80 WHEN staffs.tc LIKE ? THEN 30 ELSE 0 END")
+				scoreArgs = append(scoreArgs, t, t+"%")
+			}
+		}
+		query = query.Where("("+strings.Join(conds, " OR ")+")", args...)
+	}
+
+	var hits []StaffSearchHit
+	err := query.
+		Select("staffs.id, staffs.first_name, staffs.last_name, staffs.tc, staffs.phone, "+
+			"staffs.job_group_id, job_groups.name AS job_group_name, staffs.title_id, titles.name AS title_name, "+
+			"staffs.hospital_polyclinic_id, ("+strings.Join(scores, " + ")+") AS score", scoreArgs...).
+		Order("score DESC, staffs.last_name, staffs.first_name, staffs.id").
+		Limit(limit).
+		Scan(&hits).Error
+	if err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
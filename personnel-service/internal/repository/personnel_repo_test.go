@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"personnel-service/internal/dto"
+	"personnel-service/internal/models"
+)
+
+func TestStaffSortArgs(t *testing.T) {
+	created := time.Date(2026, 3, 2, 9, 30, 0, 123456789, time.UTC)
+	tests := []struct {
+		sort   string
+		values []string
+		want   []interface{}
+	}{
+		{dto.StaffSortName, []string{"Yılmaz", "Şükrü", "7"}, []interface{}{"Yılmaz", "Şükrü", uint64(7)}},
+		{dto.StaffSortTitle, []string{"Uzman", "7"}, []interface{}{"Uzman", uint64(7)}},
+		{dto.StaffSortCreatedAt, []string{created.Format(time.RFC3339Nano), "7"}, []interface{}{created, uint64(7)}},
+		{dto.StaffSortPolyclinic, []string{"Kardiyoloji", "7"}, []interface{}{"Kardiyoloji", uint64(7)}},
+	}
+	for _, tt := range tests {
+		got, err := staffSortArgs(tt.sort, tt.values)
+		if err != nil {
+			t.Fatalf("staffSortArgs(%s, %v) returned error: %v", tt.sort, tt.values, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("staffSortArgs(%s, %v) = %#v, want %#v", tt.sort, tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestStaffSortArgsRejectsInvalid(t *testing.T) {
+	tests := []struct {
+		sort   string
+		values []string
+	}{
+		{"unknown", []string{"7"}},
+		{dto.StaffSortName, []string{"Yılmaz", "7"}},
+		{dto.StaffSortName, []string{"Yılmaz", "Şükrü", "x"}},
+		{dto.StaffSortCreatedAt, []string{"2026-03-02", "7"}},
+	}
+	for _, tt := range tests {
+		if _, err := staffSortArgs(tt.sort, tt.values); !errors.Is(err, ErrInvalidStaffCursor) {
+			t.Errorf("staffSortArgs(%s, %v) error = %v, want ErrInvalidStaffCursor", tt.sort, tt.values, err)
+		}
+	}
+}
+
+func TestStaffSortValuesMatchArgs(t *testing.T) {
+	hpID := uint(3)
+	staff := models.Staff{FirstName: "Şükrü", LastName: "Yılmaz", HospitalPolyclinicID: &hpID}
+	staff.ID = 7
+	staff.CreatedAt = time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	staff.Title.Name = "Uzman"
+
+	q := StaffListQuery{PolyclinicNames: map[uint]string{3: "Kardiyoloji"}}
+	for sort := range staffSortColumns {
+		q.Sort = sort
+		values := StaffSortValues(&staff, q)
+		if _, err := staffSortArgs(sort, values); err != nil {
+			t.Errorf("cursor values %v of sort %s are not accepted: %v", values, sort, err)
+		}
+	}
+
+	q.Sort = dto.StaffSortPolyclinic
+	if got := StaffSortValues(&staff, q); !reflect.DeepEqual(got, []string{"Kardiyoloji", "7"}) {
+		t.Errorf("polyclinic cursor values = %v, want polyclinic name and id", got)
+	}
+}
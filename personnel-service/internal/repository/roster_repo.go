@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"time"
+
+	"personnel-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// PublishedDuty başka bir yayınlanmış listedeki nöbettir; dinlenme kuralı hesabında sabit kabul edilir
+type PublishedDuty struct {
+	RosterID  uint
+	StaffID   uint
+	Date      time.Time
+	DutyStart string
+	DutyEnd   string
+}
+
+// RosterStaffQuery nöbet adayı ya da atanmış personeli yüklemek içindir
+type RosterStaffQuery struct {
+	HospitalID           uint
+	HospitalPolyclinicID *uint
+	StaffIDs             []uint
+	From                 time.Time
+	To                   time.Time
+}
+
+type RosterRepository interface {
+	CreateRoster(roster *models.Roster) error
+	GetRosterByID(id uint) (*models.Roster, error)
+	ListRosters(hospitalID uint, status string, page, size int) ([]models.Roster, int64, error)
+	CountAssignments(rosterIDs []uint) (map[uint]int, error)
+	DeleteRoster(roster *models.Roster) error
+
+	AddAssignment(a *models.RosterAssignment) error
+	DeleteAssignment(rosterID, assignmentID uint) (int64, error)
+	PublishRoster(id uint, at time.Time) (int64, error)
+
+	ListRosterStaff(q RosterStaffQuery) ([]models.Staff, error)
+	ListPublishedDuties(hospitalID uint, from, to time.Time, excludeRosterID uint) ([]PublishedDuty, error)
+}
+
+type rosterRepository struct {
+	db *gorm.DB
+}
+
+func NewRosterRepository(db *gorm.DB) RosterRepository {
+	return &rosterRepository{db: db}
+}
+
+// Liste kuralları ve atamalarıyla birlikte tek transaction içinde yazılır
+func (r *rosterRepository) CreateRoster(roster *models.Roster) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Create(roster).Error
+	})
+}
+
+func (r *rosterRepository) GetRosterByID(id uint) (*models.Roster, error) {
+	var roster models.Roster
+	err := r.db.
+		Preload("Requirements").
+		Preload("Holidays", func(db *gorm.DB) *gorm.DB { return db.Order("date") }).
+		Preload("Assignments", func(db *gorm.DB) *gorm.DB { return db.Order("date, staff_id") }).
+		First(&roster, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &roster, nil
+}
+
+func (r *rosterRepository) ListRosters(hospitalID uint, status string, page, size int) ([]models.Roster, int64, error) {
+	query := r.db.Model(&models.Roster{}).Where("hospital_id = ?", hospitalID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rosters []models.Roster
+	err := query.Order("start_date DESC, id DESC").Offset((page - 1) * size).Limit(size).Find(&rosters).Error
+	return rosters, total, err
+}
+
+func (r *rosterRepository) CountAssignments(rosterIDs []uint) (map[uint]int, error) {
+	counts := make(map[uint]int, len(rosterIDs))
+	if len(rosterIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		RosterID uint
+		Count    int
+	}
+	err := r.db.Model(&models.RosterAssignment{}).
+		Select("roster_id, COUNT(*) as count").
+		Where("roster_id IN ?", rosterIDs).
+		Group("roster_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.RosterID] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *rosterRepository) DeleteRoster(roster *models.Roster) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("roster_id = ?", roster.ID).Delete(&models.RosterAssignment{}).Error; err != nil {
+			return err
+		}
+		return tx.Select("Requirements", "Holidays").Delete(roster).Error
+	})
+}
+
+func (r *rosterRepository) AddAssignment(a *models.RosterAssignment) error {
+	return r.db.Create(a).Error
+}
+
+// Taslaktaki atamalar geçmiş tutmaz; aynı gün tekrar eklenebilmesi için kalıcı silinir
+func (r *rosterRepository) DeleteAssignment(rosterID, assignmentID uint) (int64, error) {
+	res := r.db.Unscoped().Where("roster_id = ? AND id = ?", rosterID, assignmentID).Delete(&models.RosterAssignment{})
+	return res.RowsAffected, res.Error
+}
+
+// Yalnızca taslak durumundaki liste yayınlanır; etkilenen satır yoksa liste zaten yayınlanmıştır
+func (r *rosterRepository) PublishRoster(id uint, at time.Time) (int64, error) {
+	res := r.db.Model(&models.Roster{}).
+		Where("id = ? AND status = ?", id, models.RosterStatusDraft).
+		Updates(map[string]interface{}{"status": models.RosterStatusPublished, "published_at": at})
+	return res.RowsAffected, res.Error
+}
+
+// Personeli aralıktaki boş (off) günleri ve onaylı izinleriyle birlikte getirir
+func (r *rosterRepository) ListRosterStaff(q RosterStaffQuery) ([]models.Staff, error) {
+	query := r.db.
+		Preload("Overrides", "off = ? AND date BETWEEN ? AND ?", true, q.From, q.To).
+		Preload("Leaves", onApprovedLeave(q.From, q.To)).
+		Where("hospital_id = ?", q.HospitalID)
+	if q.HospitalPolyclinicID != nil {
+		query = query.Where("hospital_polyclinic_id = ?", *q.HospitalPolyclinicID)
+	}
+	if q.StaffIDs != nil {
+		query = query.Where("id IN ?", q.StaffIDs)
+	}
+
+	var staffs []models.Staff
+	err := query.Order("id").Find(&staffs).Error
+	return staffs, err
+}
+
+func (r *rosterRepository) ListPublishedDuties(hospitalID uint, from, to time.Time, excludeRosterID uint) ([]PublishedDuty, error) {
+	var duties []PublishedDuty
+	err := r.db.Table("roster_assignments ra").
+		Select("ra.roster_id, ra.staff_id, ra.date, r.duty_start, r.duty_end").
+		Joins("JOIN rosters r ON r.id = ra.roster_id AND r.deleted_at IS NULL").
+		Where("r.hospital_id = ? AND r.status = ? AND r.id <> ?", hospitalID, models.RosterStatusPublished, excludeRosterID).
+		Where("ra.date BETWEEN ? AND ? AND ra.deleted_at IS NULL", from, to).
+		Scan(&duties).Error
+	return duties, err
+}
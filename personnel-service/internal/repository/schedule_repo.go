@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"time"
+
+	"personnel-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type ScheduleRepository interface {
+	GetShiftsByStaffID(staffID uint) ([]models.ShiftTemplate, error)
+	ReplaceShifts(staffID uint, shifts []models.ShiftTemplate) error
+
+	ListOverrides(staffID uint, from, to time.Time) ([]models.ShiftOverride, error)
+	ReplaceOverrides(staffID uint, date time.Time, overrides []models.ShiftOverride) error
+	DeleteOverrides(staffID uint, date time.Time) (int64, error)
+
+	ListScheduledStaff(hospitalID, hospitalPolyclinicID uint, from, to time.Time) ([]models.Staff, error)
+	DeletePolyclinicShifts(hospitalPolyclinicID uint, from time.Time) error
+}
+
+type scheduleRepository struct {
+	db *gorm.DB
+}
+
+func NewScheduleRepository(db *gorm.DB) ScheduleRepository {
+	return &scheduleRepository{db: db}
+}
+
+func orderShifts(db *gorm.DB) *gorm.DB {
+	return db.Order("weekday, start_time")
+}
+
+func orderOverrides(db *gorm.DB) *gorm.DB {
+	return db.Order("date, start_time")
+}
+
+func (r *scheduleRepository) GetShiftsByStaffID(staffID uint) ([]models.ShiftTemplate, error) {
+	var shifts []models.ShiftTemplate
+	err := r.db.Scopes(orderShifts).Where("staff_id = ?", staffID).Find(&shifts).Error
+	return shifts, err
+}
+
+func (r *scheduleRepository) ReplaceShifts(staffID uint, shifts []models.ShiftTemplate) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return replaceShifts(tx, staffID, shifts)
+	})
+}
+
+// Haftalık şablon geçmiş tutmaz; eski vardiyalar kalıcı olarak silinip yenileri yazılır
+func replaceShifts(tx *gorm.DB, staffID uint, shifts []models.ShiftTemplate) error {
+	if err := tx.Unscoped().Where("staff_id = ?", staffID).Delete(&models.ShiftTemplate{}).Error; err != nil {
+		return err
+	}
+	if len(shifts) == 0 {
+		return nil
+	}
+	for i := range shifts {
+		shifts[i].ID = 0
+		shifts[i].StaffID = staffID
+	}
+	return tx.Create(&shifts).Error
+}
+
+func (r *scheduleRepository) ListOverrides(staffID uint, from, to time.Time) ([]models.ShiftOverride, error) {
+	var overrides []models.ShiftOverride
+	err := r.db.Scopes(orderOverrides).
+		Where("staff_id = ? AND date BETWEEN ? AND ?", staffID, from, to).
+		Find(&overrides).Error
+	return overrides, err
+}
+
+// Günün tüm istisnaları tek seferde değiştirilir
+func (r *scheduleRepository) ReplaceOverrides(staffID uint, date time.Time, overrides []models.ShiftOverride) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("staff_id = ? AND date = ?", staffID, date).Delete(&models.ShiftOverride{}).Error; err != nil {
+			return err
+		}
+		for i := range overrides {
+			overrides[i].StaffID = staffID
+			overrides[i].Date = date
+		}
+		return tx.Create(&overrides).Error
+	})
+}
+
+func (r *scheduleRepository) DeleteOverrides(staffID uint, date time.Time) (int64, error) {
+	res := r.db.Where("staff_id = ? AND date = ?", staffID, date).Delete(&models.ShiftOverride{})
+	return res.RowsAffected, res.Error
+}
+
+// Verilen tarih aralığında polikliniğe kendisi, haftalık vardiyası ya da istisnası ile bağlı personeli
+// vardiya, istisna ve onaylı izinleriyle birlikte getirir. Saat kesişimi usecase'te hesaplanır.
+func (r *scheduleRepository) ListScheduledStaff(hospitalID, hospitalPolyclinicID uint, from, to time.Time) ([]models.Staff, error) {
+	var staffs []models.Staff
+	err := r.db.
+		Preload("Shifts", orderShifts).
+		Preload("Overrides", func(db *gorm.DB) *gorm.DB {
+			return orderOverrides(db.Where("date BETWEEN ? AND ?", from, to))
+		}).
+		Preload("Leaves", onApprovedLeave(from, to)).
+		Where("hospital_id = ?", hospitalID).
+		Where(`(hospital_polyclinic_id = ?
+			OR EXISTS (SELECT 1 FROM shift_templates st WHERE st.staff_id = staffs.id AND st.hospital_polyclinic_id = ? AND st.deleted_at IS NULL)
+			OR EXISTS (SELECT 1 FROM shift_overrides so WHERE so.staff_id = staffs.id AND so.hospital_polyclinic_id = ? AND so.date BETWEEN ? AND ? AND so.deleted_at IS NULL))`,
+			hospitalPolyclinicID, hospitalPolyclinicID, hospitalPolyclinicID, from, to).
+		Order("last_name, first_name").
+		Find(&staffs).Error
+	return staffs, err
+}
+
+// Kaldırılan poliklinikteki haftalık vardiyaları ve from'dan sonraki istisnaları siler; geçmiş istisnalar kalır
+func (r *scheduleRepository) DeletePolyclinicShifts(hospitalPolyclinicID uint, from time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("hospital_polyclinic_id = ?", hospitalPolyclinicID).Delete(&models.ShiftTemplate{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("hospital_polyclinic_id = ? AND date >= ?", hospitalPolyclinicID, from).Delete(&models.ShiftOverride{}).Error
+	})
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"time"
+
+	"personnel-service/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StaffImportRepository interface {
+	CreateJob(job *models.StaffImportJob) error
+	GetJobByID(id uint) (*models.StaffImportJob, error)
+	UpdateJob(job *models.StaffImportJob) error
+	FinishJob(job *models.StaffImportJob, rowErrors []models.StaffImportRowError) error
+
+	ListTitles() ([]models.Title, error)
+	FindExistingTCsAndPhones(tcs, phones []string) (map[string]bool, map[string]bool, error)
+	CreateStaffBatch(staffs []models.Staff) error
+}
+
+type staffImportRepository struct {
+	db *gorm.DB
+}
+
+func NewStaffImportRepository(db *gorm.DB) StaffImportRepository {
+	return &staffImportRepository{db: db}
+}
+
+func (r *staffImportRepository) CreateJob(job *models.StaffImportJob) error {
+	return r.db.Create(job).Error
+}
+
+func (r *staffImportRepository) GetJobByID(id uint) (*models.StaffImportJob, error) {
+	var job models.StaffImportJob
+	err := r.db.Preload("RowErrors", func(db *gorm.DB) *gorm.DB {
+		return db.Order("row, id")
+	}).First(&job, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *staffImportRepository) UpdateJob(job *models.StaffImportJob) error {
+	return r.db.Omit(clause.Associations).Save(job).Error
+}
+
+// FinishJob işin son durumunu ve satır hatalarını birlikte kaydeder
+func (r *staffImportRepository) FinishJob(job *models.StaffImportJob, rowErrors []models.StaffImportRowError) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(job).Error; err != nil {
+			return err
+		}
+		if len(rowErrors) == 0 {
+			return nil
+		}
+		for i := range rowErrors {
+			rowErrors[i].JobID = job.ID
+		}
+		return tx.CreateInBatches(rowErrors, 500).Error
+	})
+}
+
+func (r *staffImportRepository) ListTitles() ([]models.Title, error) {
+	var titles []models.Title
+	if err := r.db.Find(&titles).Error; err != nil {
+		return nil, err
+	}
+	return titles, nil
+}
+
+// normalizedPhoneSQL kayıtlı telefonu içe aktarmadaki normalizePhone ile aynı biçime getirir:
+// rakam dışı karakterler atılır, baştaki 90 ve 0 kaldırılır
+const normalizedPhoneSQL = `regexp_replace(regexp_replace(phone, '[^0-9]', '', 'g'), '^(90)?0?', '')`
+
+// FindExistingTCsAndPhones kayıtlı TC ve telefonları döner; phones normalize edilmiş olmalıdır ve
+// kayıtlı telefonlar da aynı biçimde karşılaştırılır. Benzersizlik kısıtı silinmiş kayıtları
+// da kapsadığı için sorgu Unscoped çalışır.
+func (r *staffImportRepository) FindExistingTCsAndPhones(tcs, phones []string) (map[string]bool, map[string]bool, error) {
+	existingTCs := make(map[string]bool)
+	existingPhones := make(map[string]bool)
+	if len(tcs) == 0 && len(phones) == 0 {
+		return existingTCs, existingPhones, nil
+	}
+
+	var rows []struct {
+		TC    string
+		Phone string
+	}
+	err := r.db.Unscoped().Model(&models.Staff{}).
+		Select("tc, "+normalizedPhoneSQL+" AS phone").
+		Where("tc IN ? OR "+normalizedPhoneSQL+" IN ?", tcs, phones).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, row := range rows {
+		existingTCs[row.TC] = true
+		existingPhones[row.Phone] = true
+	}
+	return existingTCs, existingPhones, nil
+}
+
+// CreateStaffBatch tüm personeli tek işlemde ekler; biri bile eklenemezse hiçbiri eklenmez.
+// Başhekim kuralı doğrulamadan sonra başlayan eşzamanlı eklemelere karşı burada tekrar kontrol edilir.
+func (r *staffImportRepository) CreateStaffBatch(staffs []models.Staff) error {
+	if len(staffs) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockHospitalHeads(tx, staffs[0].HospitalID); err != nil {
+			return err
+		}
+		if err := tx.CreateInBatches(staffs, 200).Error; err != nil {
+			return err
+		}
+		if err := checkHospitalHeads(tx, staffs[0].HospitalID); err != nil {
+			return err
+		}
+		today := assignmentDay(time.Now())
+		var affected []uint
+		for i := range staffs {
+			if _, err := recordAssignment(tx, &staffs[i], today, AssignmentChange{Type: models.AssignmentInitial}); err != nil {
+				return err
+			}
+			if staffs[i].HospitalPolyclinicID == nil {
+				continue
+			}
+			if err := enqueueStaffAssigned(tx, &staffs[i], nil); err != nil {
+				return err
+			}
+			affected = append(affected, *staffs[i].HospitalPolyclinicID)
+		}
+		return enqueueStaffCounts(tx, staffs[0].HospitalID, affected)
+	})
+}
@@ -1,6 +1,9 @@
 package router
 
 import (
+	"time"
+
+	"hospital-shared/cache"
 	sharedjwt "hospital-shared/jwt"
 	"personnel-service/internal/config"
 	"personnel-service/internal/database"
@@ -13,4 +16,7 @@ type RouterDeps struct {
 	DB              *database.Database
 	Config          *config.Config
 	JWTSharedConfig *sharedjwt.JWTConfig
+	Cache           *cache.Cache
+	// Location config'teki saat diliminin yüklenmiş hali; servis açılırken bir kez yüklenir
+	Location *time.Location
 }
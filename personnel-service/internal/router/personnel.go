@@ -1,20 +1,48 @@
 package router
 
 import (
+	"time"
+
 	"hospital-shared/jwt"
+	"hospital-shared/notification"
 	"personnel-service/internal/handler"
 	"personnel-service/internal/infrastructure/client"
+	"personnel-service/internal/jobs"
 	"personnel-service/internal/repository"
 	"personnel-service/internal/usecase"
 
 	"hospital-shared/middleware"
 )
 
-func PersonnelRoutes(deps RouterDeps) {
+// PersonnelRoutes endpointleri kaydeder ve servisin günlük işlerini döner; işleri cmd/main başlatır
+func PersonnelRoutes(deps RouterDeps) []jobs.Job {
 	personnelRepo := repository.NewPersonnelRepository(deps.DB.SQL)
-	polyclinicClient := client.NewPolyclinicClient(deps.Config.Url.BaseUrl)
-	personnelUsecase := usecase.NewPersonnelUsecase(personnelRepo, deps.DB.Redis, polyclinicClient)
+	// Liste sayfalarındaki poliklinik adları kısa süre önbellekte tutulur, hospital servisi düşerse eski adlar kullanılır
+	polyclinicClient := client.NewCachedPolyclinicClient(client.NewPolyclinicClient(deps.Config.Url.BaseUrl, deps.Config.Internal.Token), 30*time.Second, 10*time.Minute)
+	personnelUsecase := usecase.NewPersonnelUsecase(personnelRepo, deps.Cache, polyclinicClient)
 	personnelHandler := handler.NewPersonnelHandler(personnelUsecase, deps.Config)
+	scheduleRepo := repository.NewScheduleRepository(deps.DB.SQL)
+	scheduleUsecase := usecase.NewScheduleUsecase(scheduleRepo, personnelRepo, polyclinicClient)
+	scheduleHandler := handler.NewScheduleHandler(scheduleUsecase)
+	rosterRepo := repository.NewRosterRepository(deps.DB.SQL)
+	rosterUsecase := usecase.NewRosterUsecase(rosterRepo, personnelRepo, polyclinicClient)
+	rosterHandler := handler.NewRosterHandler(rosterUsecase)
+	leaveRepo := repository.NewLeaveRepository(deps.DB.SQL)
+	leaveUsecase := usecase.NewLeaveUsecase(leaveRepo, personnelRepo)
+	leaveHandler := handler.NewLeaveHandler(leaveUsecase)
+	attendanceRepo := repository.NewAttendanceRepository(deps.DB.SQL)
+	attendanceUsecase := usecase.NewAttendanceUsecase(attendanceRepo, personnelRepo,
+		time.Duration(deps.Config.Attendance.QRStepSeconds)*time.Second, deps.Location)
+	attendanceHandler := handler.NewAttendanceHandler(attendanceUsecase)
+	importRepo := repository.NewStaffImportRepository(deps.DB.SQL)
+	importUsecase := usecase.NewStaffImportUsecase(importRepo, personnelRepo, polyclinicClient)
+	importHandler := handler.NewStaffImportHandler(importUsecase)
+	exportHandler := handler.NewStaffExportHandler(usecase.NewStaffExportUsecase(personnelRepo, polyclinicClient))
+	certificationUsecase := usecase.NewCertificationUsecase(repository.NewCertificationRepository(deps.DB.SQL), personnelRepo,
+		client.NewAuthClient(deps.Config.Auth.BaseUrl, deps.Config.Internal.Token), notification.NewNotifier(notification.SMTPConfig(deps.Config.SMTP)),
+		deps.Config.Certification.ExpiryNoticeDays, deps.Location)
+	certificationHandler := handler.NewCertificationHandler(certificationUsecase)
+	assignmentHandler := handler.NewAssignmentHandler(usecase.NewAssignmentUsecase(repository.NewAssignmentRepository(deps.DB.SQL), personnelRepo, polyclinicClient))
 
 	api := deps.App.Group("/api")
 
@@ -27,9 +55,70 @@ func PersonnelRoutes(deps RouterDeps) {
 	personnelGroup.Put("/staff/:id", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), personnelHandler.UpdateStaff)
 	personnelGroup.Delete("/staff/:id", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), personnelHandler.DeleteStaff)
 	personnelGroup.Get("/staff", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili", "calisan"), personnelHandler.ListStaff)
+	personnelGroup.Get("/staff/search", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili", "calisan"), personnelHandler.SearchStaff)
+	personnelGroup.Get("/staff/export", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili", "calisan"), exportHandler.ExportStaff)
+	personnelGroup.Post("/staff/import", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), importHandler.ImportStaff)
+	personnelGroup.Get("/staff/import/:id", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), importHandler.GetImportJob)
+
+	personnelGroup.Get("/staff/:id/schedule", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili", "calisan"), scheduleHandler.GetSchedule)
+	personnelGroup.Put("/staff/:id/schedule", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), scheduleHandler.UpdateWeeklySchedule)
+	personnelGroup.Put("/staff/:id/schedule/overrides/:date", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), scheduleHandler.SetOverride)
+	personnelGroup.Delete("/staff/:id/schedule/overrides/:date", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), scheduleHandler.DeleteOverride)
+	personnelGroup.Get("/on-duty", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili", "calisan"), scheduleHandler.ListOnDuty)
+
+	personnelGroup.Post("/rosters/generate", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), rosterHandler.GenerateRoster)
+	personnelGroup.Get("/rosters", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili", "calisan"), rosterHandler.ListRosters)
+	personnelGroup.Get("/rosters/:id", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili", "calisan"), rosterHandler.GetRoster)
+	personnelGroup.Post("/rosters/:id/assignments", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), rosterHandler.AddAssignment)
+	personnelGroup.Delete("/rosters/:id/assignments/:assignmentId", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), rosterHandler.RemoveAssignment)
+	personnelGroup.Post("/rosters/:id/publish", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), rosterHandler.PublishRoster)
+	personnelGroup.Delete("/rosters/:id", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), rosterHandler.DeleteRoster)
+
+	personnelGroup.Get("/leave-types", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili", "calisan"), leaveHandler.ListLeaveTypes)
+	personnelGroup.Put("/staff/:id/leave-entitlements", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), leaveHandler.SetEntitlement)
+	personnelGroup.Get("/staff/:id/leave-balance", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili", "calisan"), leaveHandler.GetBalance)
+	personnelGroup.Post("/leave-requests", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili", "calisan"), leaveHandler.CreateLeaveRequest)
+	personnelGroup.Get("/leave-requests", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili", "calisan"), leaveHandler.ListLeaveRequests)
+	personnelGroup.Post("/leave-requests/:id/approve", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), leaveHandler.ApproveLeaveRequest)
+	personnelGroup.Post("/leave-requests/:id/reject", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), leaveHandler.RejectLeaveRequest)
+	personnelGroup.Post("/leave-requests/:id/cancel", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), leaveHandler.CancelLeaveRequest)
+
+	personnelGroup.Post("/kiosks", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), attendanceHandler.CreateKiosk)
+	personnelGroup.Get("/kiosks", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), attendanceHandler.ListKiosks)
+	personnelGroup.Delete("/kiosks/:id", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), attendanceHandler.DeactivateKiosk)
+	personnelGroup.Post("/attendance/events", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), attendanceHandler.RecordManual)
+	personnelGroup.Get("/attendance/events", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), attendanceHandler.ListEvents)
+	personnelGroup.Get("/attendance/timesheets", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), attendanceHandler.GetTimesheet)
+	personnelGroup.Get("/attendance/timesheets/export", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), attendanceHandler.ExportTimesheet)
+
+	personnelGroup.Post("/staff/:id/transfers", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), assignmentHandler.TransferStaff)
+	personnelGroup.Get("/staff/:id/assignments", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili", "calisan"), assignmentHandler.ListStaffAssignments)
+	personnelGroup.Get("/assignments", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili", "calisan"), assignmentHandler.ListStaffAsOf)
+
+	personnelGroup.Post("/staff/:id/certifications", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), certificationHandler.AddCertification)
+	personnelGroup.Get("/staff/:id/certifications", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili", "calisan"), certificationHandler.ListStaffCertifications)
+	personnelGroup.Get("/certifications/expiring", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili", "calisan"), certificationHandler.ListExpiring)
+	personnelGroup.Put("/certifications/:id", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), certificationHandler.UpdateCertification)
+	personnelGroup.Delete("/certifications/:id", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), certificationHandler.DeleteCertification)
+	personnelGroup.Put("/certifications/:id/document", middleware.AdminRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili"), certificationHandler.UploadDocument)
+	personnelGroup.Get("/certifications/:id/document", middleware.GeneralRateLimiter(), jwt.AuthRequired(deps.JWTSharedConfig), jwt.RequireRole("yetkili", "calisan"), certificationHandler.GetDocument)
+
+	// Kiosk cihazı kendi anahtarıyla, personel ise QR + TC + telefon ile doğrulanır - JWT gerektirmez
+	personnelGroup.Get("/attendance/kiosk/qr", middleware.PublicRateLimiter(), attendanceHandler.GetKioskQR)
+	personnelGroup.Post("/attendance/clock", middleware.PublicRateLimiter(), attendanceHandler.Clock)
 
-	//Hospital servisi bu endpointlere http istekleri atıyor - JWT gerektirmez (mikroservis arası iletişim)
-	personnelGroup.Get("/:id", personnelHandler.GetStaffCount)
-	personnelGroup.Get("/groups/:id", personnelHandler.GetGroupCounts)
+	// Hospital servisi bu endpointlere http istekleri atıyor - JWT yerine servisler arası paylaşılan anahtar ister
+	internalAuth := middleware.InternalAuth(deps.Config.Internal.Token)
+	personnelGroup.Get("/:id", internalAuth, personnelHandler.GetStaffCount)
+	personnelGroup.Get("/groups/:id", internalAuth, personnelHandler.GetGroupCounts)
+	personnelGroup.Post("/stats/batch", internalAuth, personnelHandler.GetPersonnelStatsBatch)
+	personnelGroup.Post("/staff/reassign", internalAuth, personnelHandler.ReassignStaff)
+	personnelGroup.Get("/internal/staff", internalAuth, personnelHandler.ListStaffRefs)
 
+	return []jobs.Job{
+		// İzne çıkan ve izinden dönen personel gün başında poliklinik sayılarına yansıtılır
+		{Name: "leave staff counts", Hour: 0, Run: leaveUsecase.PublishLeaveCounts},
+		// Süresi yaklaşan sertifikalar her gün yetkililere bildirilir
+		{Name: "certification expiry notifications", Hour: deps.Config.Certification.NotifyHour, Run: certificationUsecase.NotifyExpiring},
+	}
 }
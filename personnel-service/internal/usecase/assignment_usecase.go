@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"personnel-service/internal/dto"
+	"personnel-service/internal/infrastructure/client"
+	"personnel-service/internal/models"
+	"personnel-service/internal/repository"
+)
+
+var ErrAssignmentBeforeCurrent = repository.ErrAssignmentBeforeCurrent
+
+type AssignmentUsecase interface {
+	TransferStaff(staffID, hospitalID, approverID uint, req *dto.TransferStaffRequest) (*dto.StaffAssignmentResponse, error)
+	ListStaffAssignments(staffID, hospitalID uint) ([]dto.StaffAssignmentResponse, error)
+	ListStaffAsOf(hospitalID uint, asOf string, filter dto.StaffListFilter) (*dto.StaffAsOfResponse, error)
+}
+
+type assignmentUsecase struct {
+	repo             repository.AssignmentRepository
+	personnelRepo    repository.PersonnelRepository
+	polyclinicClient client.PolyclinicClient
+}
+
+func NewAssignmentUsecase(repo repository.AssignmentRepository, personnelRepo repository.PersonnelRepository, pc client.PolyclinicClient) AssignmentUsecase {
+	return &assignmentUsecase{
+		repo:             repo,
+		personnelRepo:    personnelRepo,
+		polyclinicClient: pc,
+	}
+}
+
+func (u *assignmentUsecase) TransferStaff(staffID, hospitalID, approverID uint, req *dto.TransferStaffRequest) (*dto.StaffAssignmentResponse, error) {
+	staff, err := u.personnelRepo.GetStaffByID(staffID)
+	if err != nil {
+		return nil, errors.New("staff not found")
+	}
+	if staff.HospitalID != hospitalID {
+		return nil, errors.New("forbidden: staff belongs to another hospital")
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, errors.New("reason is required")
+	}
+
+	today := time.Now()
+	effective := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if req.EffectiveDate != "" {
+		d, err := time.Parse(dateLayout, req.EffectiveDate)
+		if err != nil {
+			return nil, errors.New("effective_date must be in YYYY-MM-DD format")
+		}
+		// İleri tarihli değişiklik personel kaydını o güne kadar yanlış gösterirdi
+		if d.After(effective) {
+			return nil, errors.New("effective_date cannot be in the future")
+		}
+		effective = d
+	}
+
+	// Poliklinik
+	if req.Unassign && req.HospitalPolyclinicID != nil {
+		return nil, errors.New("hospital_polyclinic_id and unassign cannot be used together")
+	}
+	hpID := staff.HospitalPolyclinicID
+	var polyName *string
+	switch {
+	case req.Unassign:
+		hpID = nil
+	case req.HospitalPolyclinicID != nil:
+		hp, err := u.polyclinicClient.GetHospitalPolyclinicByID(*req.HospitalPolyclinicID)
+		if err != nil {
+			return nil, errors.New("hospital polyclinic not found")
+		}
+		if hp.HospitalID != hospitalID {
+			return nil, errors.New("polyclinic does not belong to your hospital")
+		}
+		hpID = req.HospitalPolyclinicID
+		polyName = &hp.PolyclinicName
+	}
+
+	// Meslek grubu ve unvan
+	jobGroupID, titleID := staff.JobGroupID, staff.TitleID
+	if req.JobGroupID != nil {
+		if *req.JobGroupID != staff.JobGroupID && req.TitleID == nil {
+			return nil, errors.New("title_id is required when the job group changes")
+		}
+		jobGroupID = *req.JobGroupID
+	}
+	if req.TitleID != nil {
+		titleID = *req.TitleID
+	}
+	jobGroup, err := u.personnelRepo.GetJobGroupByID(jobGroupID)
+	if err != nil {
+		return nil, errors.New("job group not found")
+	}
+	title, err := u.personnelRepo.GetTitleByID(titleID)
+	if err != nil {
+		return nil, errors.New("title not found")
+	}
+	if title.JobGroupID != jobGroup.ID {
+		return nil, errors.New("title does not belong to the selected job group")
+	}
+
+	moved := !sameUintPtr(hpID, staff.HospitalPolyclinicID)
+	promoted := jobGroupID != staff.JobGroupID || titleID != staff.TitleID
+	if !moved && !promoted {
+		return nil, errors.New("transfer does not change polyclinic, job group or title")
+	}
+	if promoted && titleID != staff.TitleID && title.Name == "Başhekim" {
+		count, err := u.personnelRepo.CountHospitalHeads(hospitalID)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, errors.New("there can be only one Başhekim in a hospital")
+		}
+	}
+
+	changeType := models.AssignmentTransfer
+	switch {
+	case moved && promoted:
+		changeType = models.AssignmentTransferPromotion
+	case promoted:
+		changeType = models.AssignmentPromotion
+	}
+
+	before := *staff
+	staff.HospitalPolyclinicID = hpID
+	staff.JobGroupID = jobGroupID
+	staff.TitleID = titleID
+	assignment, err := u.repo.TransferStaff(staff, before, effective, repository.AssignmentChange{
+		Type:       changeType,
+		Reason:     reason,
+		ApprovedBy: &approverID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Poliklinik değişmediyse adı ayrıca çözülür
+	if hpID != nil && polyName == nil {
+		if name, ok := polyclinicNamesByID(u.polyclinicClient, hospitalID, []*uint{hpID})[*hpID]; ok {
+			polyName = &name
+		}
+	}
+	assignment.JobGroup = *jobGroup
+	assignment.Title = *title
+	resp := toAssignmentResponse(assignment, polyName)
+	return &resp, nil
+}
+
+func sameUintPtr(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func (u *assignmentUsecase) ListStaffAssignments(staffID, hospitalID uint) ([]dto.StaffAssignmentResponse, error) {
+	assignments, err := u.repo.ListStaffAssignments(staffID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	// Silinmiş personelin geçmişi de okunabilir; hiç kayıt yoksa personel bu hastanede çalışmamıştır
+	if len(assignments) == 0 {
+		return nil, errors.New("staff not found")
+	}
+
+	hpIDs := make([]*uint, len(assignments))
+	for i := range assignments {
+		hpIDs[i] = assignments[i].HospitalPolyclinicID
+	}
+	polyNames := polyclinicNamesByID(u.polyclinicClient, hospitalID, hpIDs)
+
+	resp := make([]dto.StaffAssignmentResponse, 0, len(assignments))
+	for i := range assignments {
+		resp = append(resp, toAssignmentResponse(&assignments[i], lookupName(polyNames, assignments[i].HospitalPolyclinicID)))
+	}
+	return resp, nil
+}
+
+func (u *assignmentUsecase) ListStaffAsOf(hospitalID uint, asOf string, filter dto.StaffListFilter) (*dto.StaffAsOfResponse, error) {
+	day, err := time.Parse(dateLayout, asOf)
+	if err != nil {
+		return nil, errors.New("as_of must be in YYYY-MM-DD format")
+	}
+
+	assignments, err := u.repo.ListAssignmentsAsOf(repository.AssignmentQuery{
+		HospitalID:           hospitalID,
+		AsOf:                 day,
+		HospitalPolyclinicID: filter.HospitalPolyclinicID,
+		Unassigned:           filter.Unassigned,
+		JobGroupID:           filter.JobGroupID,
+		TitleID:              filter.TitleID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hpIDs := make([]*uint, len(assignments))
+	for i := range assignments {
+		hpIDs[i] = assignments[i].HospitalPolyclinicID
+	}
+	polyNames := polyclinicNamesByID(u.polyclinicClient, hospitalID, hpIDs)
+
+	resp := &dto.StaffAsOfResponse{AsOf: asOf, Staff: make([]dto.StaffAsOf, 0, len(assignments))}
+	for i := range assignments {
+		a := &assignments[i]
+		resp.Staff = append(resp.Staff, dto.StaffAsOf{
+			StaffAssignmentResponse: toAssignmentResponse(a, lookupName(polyNames, a.HospitalPolyclinicID)),
+			FirstName:               a.Staff.FirstName,
+			LastName:                a.Staff.LastName,
+			Active:                  !a.Staff.DeletedAt.Valid,
+		})
+	}
+	return resp, nil
+}
+
+func lookupName(names map[uint]string, id *uint) *string {
+	if id == nil {
+		return nil
+	}
+	if name, ok := names[*id]; ok {
+		return &name
+	}
+	return nil
+}
+
+func toAssignmentResponse(a *models.StaffAssignment, polyName *string) dto.StaffAssignmentResponse {
+	resp := dto.StaffAssignmentResponse{
+		ID:                   a.ID,
+		StaffID:              a.StaffID,
+		HospitalPolyclinicID: a.HospitalPolyclinicID,
+		PolyclinicName:       polyName,
+		JobGroupID:           a.JobGroupID,
+		JobGroupName:         a.JobGroup.Name,
+		TitleID:              a.TitleID,
+		TitleName:            a.Title.Name,
+		ValidFrom:            a.ValidFrom.Format(dateLayout),
+		ChangeType:           a.ChangeType,
+		Reason:               a.Reason,
+		ApprovedBy:           a.ApprovedBy,
+	}
+	if a.ValidTo != nil {
+		validTo := a.ValidTo.Format(dateLayout)
+		resp.ValidTo = &validTo
+	}
+	return resp
+}
@@ -0,0 +1,504 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"personnel-service/internal/dto"
+	"personnel-service/internal/models"
+	"personnel-service/internal/repository"
+	"personnel-service/pkg/utils"
+)
+
+const (
+	defaultQRStep = 30 * time.Second
+	// Aynı personelin art arda iki kaydı arasında en az bu kadar süre olmalı (çift okutma)
+	minClockInterval = time.Minute
+	// Giriş ile çıkış arası bundan uzunsa çıkış unutulmuş sayılır
+	maxWorkSpan = 24 * time.Hour
+	// Elle girilen kayıtlarda saat farkı toleransı
+	manualFutureTolerance = 5 * time.Minute
+	maxEventRangeDays     = 93
+)
+
+var (
+	ErrInvalidKioskKey = errors.New("invalid kiosk key")
+	ErrInvalidQRToken  = errors.New("invalid or expired qr token")
+	ErrQRTokenUsed     = repository.ErrAttendanceTokenUsed
+)
+
+type AttendanceUsecase interface {
+	CreateKiosk(hospitalID uint, req *dto.CreateKioskRequest) (*dto.KioskResponse, error)
+	ListKiosks(hospitalID uint) ([]dto.KioskResponse, error)
+	DeactivateKiosk(id, hospitalID uint) error
+	GetKioskQR(apiKey string) (*dto.KioskQRResponse, error)
+	ClockViaKiosk(req *dto.KioskClockRequest) (*dto.AttendanceEventResponse, error)
+
+	RecordManual(hospitalID, authorityID uint, req *dto.ManualAttendanceRequest) (*dto.AttendanceEventResponse, error)
+	ListEvents(hospitalID uint, filter dto.AttendanceEventFilter) ([]dto.AttendanceEventResponse, error)
+	GetTimesheet(hospitalID uint, filter dto.TimesheetFilter) (*dto.TimesheetResponse, error)
+	ExportTimesheetCSV(hospitalID uint, filter dto.TimesheetFilter, w io.Writer) error
+}
+
+type attendanceUsecase struct {
+	repo          repository.AttendanceRepository
+	personnelRepo repository.PersonnelRepository
+	step          time.Duration
+	loc           *time.Location
+}
+
+// NewAttendanceUsecase QR adım süresi ve puantajın gün sınırlarını belirleyen saat dilimiyle çalışır
+func NewAttendanceUsecase(repo repository.AttendanceRepository, personnelRepo repository.PersonnelRepository, step time.Duration, loc *time.Location) AttendanceUsecase {
+	if step <= 0 {
+		step = defaultQRStep
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	return &attendanceUsecase{
+		repo:          repo,
+		personnelRepo: personnelRepo,
+		step:          step,
+		loc:           loc,
+	}
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashKioskKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func (u *attendanceUsecase) CreateKiosk(hospitalID uint, req *dto.CreateKioskRequest) (*dto.KioskResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	key, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+
+	kiosk := models.Kiosk{
+		HospitalID: hospitalID,
+		Name:       name,
+		Location:   strings.TrimSpace(req.Location),
+		KeyHash:    hashKioskKey(key),
+		Secret:     secret,
+		Active:     true,
+	}
+	if err := u.repo.CreateKiosk(&kiosk); err != nil {
+		return nil, err
+	}
+
+	resp := toKioskResponse(&kiosk)
+	resp.APIKey = key
+	return &resp, nil
+}
+
+func (u *attendanceUsecase) ListKiosks(hospitalID uint) ([]dto.KioskResponse, error) {
+	kiosks, err := u.repo.ListKiosks(hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]dto.KioskResponse, 0, len(kiosks))
+	for i := range kiosks {
+		resp = append(resp, toKioskResponse(&kiosks[i]))
+	}
+	return resp, nil
+}
+
+func (u *attendanceUsecase) DeactivateKiosk(id, hospitalID uint) error {
+	kiosk, err := u.repo.GetKioskByID(id)
+	if err != nil {
+		return errors.New("kiosk not found")
+	}
+	if kiosk.HospitalID != hospitalID {
+		return errors.New("forbidden: kiosk belongs to another hospital")
+	}
+	return u.repo.DeactivateKiosk(kiosk)
+}
+
+// GetKioskQR kiosk ekranındaki QR içeriğini döner; içerik her adımda değişir
+func (u *attendanceUsecase) GetKioskQR(apiKey string) (*dto.KioskQRResponse, error) {
+	if apiKey == "" {
+		return nil, ErrInvalidKioskKey
+	}
+	kiosk, err := u.repo.GetKioskByKeyHash(hashKioskKey(apiKey))
+	if err != nil {
+		return nil, ErrInvalidKioskKey
+	}
+
+	step := u.currentStep(time.Now())
+	return &dto.KioskQRResponse{
+		Token:     qrToken(kiosk, step),
+		ExpiresAt: time.Unix(0, (step+1)*int64(u.step)).Format(time.RFC3339),
+	}, nil
+}
+
+func (u *attendanceUsecase) currentStep(t time.Time) int64 {
+	return t.UnixNano() / int64(u.step)
+}
+
+// qrToken "<kioskID>.<adım>.<imza>" biçimindedir; imza kioskun gizli anahtarıyla HMAC-SHA256'dır
+func qrToken(kiosk *models.Kiosk, step int64) string {
+	return fmt.Sprintf("%d.%d.%s", kiosk.ID, step, qrSignature(kiosk, step))
+}
+
+func qrSignature(kiosk *models.Kiosk, step int64) string {
+	mac := hmac.New(sha256.New, []byte(kiosk.Secret))
+	fmt.Fprintf(mac, "%d.%d", kiosk.ID, step)
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// verifyQRToken imzayı ve adımı doğrular; okutma gecikmesi için bir önceki adım da kabul edilir
+func (u *attendanceUsecase) verifyQRToken(token string) (*models.Kiosk, int64, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, 0, ErrInvalidQRToken
+	}
+	kioskID, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, 0, ErrInvalidQRToken
+	}
+	step, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, 0, ErrInvalidQRToken
+	}
+	current := u.currentStep(time.Now())
+	if step != current && step != current-1 {
+		return nil, 0, ErrInvalidQRToken
+	}
+
+	kiosk, err := u.repo.GetKioskByID(uint(kioskID))
+	if err != nil || !kiosk.Active {
+		return nil, 0, ErrInvalidQRToken
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(qrSignature(kiosk, step))) {
+		return nil, 0, ErrInvalidQRToken
+	}
+	return kiosk, step, nil
+}
+
+func (u *attendanceUsecase) ClockViaKiosk(req *dto.KioskClockRequest) (*dto.AttendanceEventResponse, error) {
+	kiosk, step, err := u.verifyQRToken(req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hangi bilginin yanlış olduğu söylenmez; telefon kayıtlardaki gibi normalize edilerek aranır
+	phone, ok := utils.NormalizePhone(req.Phone)
+	if !ok {
+		return nil, errors.New("staff not found")
+	}
+	staff, err := u.repo.FindStaffByTCAndPhone(kiosk.HospitalID, strings.TrimSpace(req.TC), phone)
+	if err != nil {
+		return nil, errors.New("staff not found")
+	}
+
+	now := time.Now()
+	last, err := u.repo.GetLastEvent(staff.ID)
+	if err == nil && now.Sub(last.OccurredAt) < minClockInterval {
+		return nil, errors.New("clocked too recently, try again later")
+	}
+
+	eventType := req.Type
+	if eventType == "" {
+		// Son kayıt giriş ise çıkış, değilse giriş yapılır
+		eventType = models.AttendanceIn
+		if last != nil && last.Type == models.AttendanceIn && now.Sub(last.OccurredAt) < maxWorkSpan {
+			eventType = models.AttendanceOut
+		}
+	}
+	if eventType != models.AttendanceIn && eventType != models.AttendanceOut {
+		return nil, errors.New("type must be in or out")
+	}
+
+	event := models.AttendanceEvent{
+		StaffID:    staff.ID,
+		HospitalID: staff.HospitalID,
+		Type:       eventType,
+		OccurredAt: now,
+		Source:     models.AttendanceSourceKiosk,
+		KioskID:    &kiosk.ID,
+		TokenStep:  &step,
+	}
+	if err := u.repo.CreateEvent(&event); err != nil {
+		return nil, err
+	}
+	return toAttendanceEventResponse(&event), nil
+}
+
+// RecordManual kiosk kullanılamadığında yetkilinin gerekçeyle girdiği kayıttır
+func (u *attendanceUsecase) RecordManual(hospitalID, authorityID uint, req *dto.ManualAttendanceRequest) (*dto.AttendanceEventResponse, error) {
+	staff, err := u.personnelRepo.GetStaffByID(req.StaffID)
+	if err != nil {
+		return nil, errors.New("staff not found")
+	}
+	if staff.HospitalID != hospitalID {
+		return nil, errors.New("forbidden: staff belongs to another hospital")
+	}
+	if req.Type != models.AttendanceIn && req.Type != models.AttendanceOut {
+		return nil, errors.New("type must be in or out")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, errors.New("reason is required for manual entries")
+	}
+	occurredAt, err := time.Parse(time.RFC3339, req.OccurredAt)
+	if err != nil {
+		return nil, errors.New("occurred_at must be in RFC3339 format")
+	}
+	if occurredAt.After(time.Now().Add(manualFutureTolerance)) {
+		return nil, errors.New("occurred_at cannot be in the future")
+	}
+
+	event := models.AttendanceEvent{
+		StaffID:    staff.ID,
+		HospitalID: hospitalID,
+		Type:       req.Type,
+		OccurredAt: occurredAt,
+		Source:     models.AttendanceSourceManual,
+		Reason:     reason,
+		CreatedBy:  &authorityID,
+	}
+	if err := u.repo.CreateEvent(&event); err != nil {
+		return nil, err
+	}
+	return toAttendanceEventResponse(&event), nil
+}
+
+func (u *attendanceUsecase) ListEvents(hospitalID uint, filter dto.AttendanceEventFilter) ([]dto.AttendanceEventResponse, error) {
+	today := u.localDay(time.Now())
+	from, to := today.AddDate(0, 0, -7), today
+	if filter.From != "" {
+		d, err := time.ParseInLocation(dateLayout, filter.From, u.loc)
+		if err != nil {
+			return nil, errors.New("from must be in YYYY-MM-DD format")
+		}
+		from = d
+	}
+	if filter.To != "" {
+		d, err := time.ParseInLocation(dateLayout, filter.To, u.loc)
+		if err != nil {
+			return nil, errors.New("to must be in YYYY-MM-DD format")
+		}
+		to = d
+	}
+	if to.Before(from) {
+		return nil, errors.New("from must not be after to")
+	}
+	if to.Sub(from) > maxEventRangeDays*24*time.Hour {
+		return nil, fmt.Errorf("date range cannot exceed %d days", maxEventRangeDays)
+	}
+
+	events, err := u.repo.ListEvents(hospitalID, filter.StaffID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]dto.AttendanceEventResponse, 0, len(events))
+	for i := range events {
+		resp = append(resp, *toAttendanceEventResponse(&events[i]))
+	}
+	return resp, nil
+}
+
+func (u *attendanceUsecase) localDay(t time.Time) time.Time {
+	t = t.In(u.loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, u.loc)
+}
+
+// GetTimesheet ayın her günü için vardiyaya göre planlanan süreyi, giriş/çıkış çiftlerinden çalışılan
+// süreyi, fazla mesaiyi ve devamsızlığı hesaplar. Çalışma, girişin yapıldığı güne yazılır.
+func (u *attendanceUsecase) GetTimesheet(hospitalID uint, filter dto.TimesheetFilter) (*dto.TimesheetResponse, error) {
+	monthStart, err := time.ParseInLocation("2006-01", filter.Month, u.loc)
+	if err != nil {
+		return nil, errors.New("month must be in YYYY-MM format")
+	}
+	monthEnd := monthStart.AddDate(0, 1, 0)
+
+	// Vardiya ve izin tarihleri saat dilimsiz (UTC gece yarısı) tutulur
+	firstDay := time.Date(monthStart.Year(), monthStart.Month(), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstDay.AddDate(0, 1, -1)
+
+	staffs, err := u.repo.ListTimesheetStaff(hospitalID, filter.StaffID, firstDay, lastDay)
+	if err != nil {
+		return nil, err
+	}
+	// Ay sınırını aşan çalışmaların eşi diğer ayda kalır; aralık iki yönde en uzun çalışma
+	// süresi kadar genişletilir. Çalışma girişin gününe yazıldığından ay dışındaki günler yok sayılır.
+	events, err := u.repo.ListEvents(hospitalID, filter.StaffID, monthStart.Add(-maxWorkSpan), monthEnd.Add(maxWorkSpan))
+	if err != nil {
+		return nil, err
+	}
+	eventsByStaff := make(map[uint][]models.AttendanceEvent)
+	for _, e := range events {
+		eventsByStaff[e.StaffID] = append(eventsByStaff[e.StaffID], e)
+	}
+
+	now := time.Now()
+	todayKey := now.In(u.loc).Format(dateLayout)
+	resp := &dto.TimesheetResponse{Month: monthStart.Format("2006-01"), Staffs: make([]dto.StaffTimesheet, 0, len(staffs))}
+	for i := range staffs {
+		s := &staffs[i]
+		worked, warnings := u.pairEvents(eventsByStaff[s.ID], now)
+
+		sheet := dto.StaffTimesheet{
+			StaffID:   s.ID,
+			TC:        s.TC,
+			FirstName: s.FirstName,
+			LastName:  s.LastName,
+			Days:      make([]dto.TimesheetDay, 0, lastDay.Day()),
+		}
+		for d := firstDay; !d.After(lastDay); d = d.AddDate(0, 0, 1) {
+			key := d.Format(dateLayout)
+			day := dto.TimesheetDay{
+				Date:          key,
+				WorkedMinutes: worked[key],
+				OnLeave:       onLeave(s, d),
+				Warnings:      warnings[key],
+			}
+			for _, shift := range effectiveShifts(s, d) {
+				if r, err := shiftRange(shift.startTime, shift.endTime); err == nil {
+					day.ScheduledMinutes += r.end - r.start
+				}
+			}
+			if day.WorkedMinutes > day.ScheduledMinutes {
+				day.OvertimeMinutes = day.WorkedMinutes - day.ScheduledMinutes
+			}
+			// Bugün ve sonrası henüz tamamlanmadığı için devamsızlık sayılmaz
+			day.Absent = day.ScheduledMinutes > 0 && day.WorkedMinutes == 0 && key < todayKey
+
+			sheet.ScheduledMinutes += day.ScheduledMinutes
+			sheet.WorkedMinutes += day.WorkedMinutes
+			sheet.OvertimeMinutes += day.OvertimeMinutes
+			if day.Absent {
+				sheet.AbsentDays++
+			}
+			if day.OnLeave {
+				sheet.LeaveDays++
+			}
+			sheet.Days = append(sheet.Days, day)
+		}
+		resp.Staffs = append(resp.Staffs, sheet)
+	}
+	return resp, nil
+}
+
+// pairEvents giriş/çıkış kayıtlarını eşleştirip gün bazında çalışılan dakikayı döner.
+// Eşi olmayan kayıtlar ilgili güne uyarı olarak eklenir.
+func (u *attendanceUsecase) pairEvents(events []models.AttendanceEvent, now time.Time) (map[string]int, map[string][]string) {
+	worked := make(map[string]int)
+	warnings := make(map[string][]string)
+	dayOf := func(t time.Time) string { return t.In(u.loc).Format(dateLayout) }
+
+	var open *time.Time
+	for i := range events {
+		e := &events[i]
+		switch e.Type {
+		case models.AttendanceIn:
+			if open != nil {
+				warnings[dayOf(*open)] = append(warnings[dayOf(*open)], "missing_clock_out")
+			}
+			open = &e.OccurredAt
+		case models.AttendanceOut:
+			if open == nil {
+				warnings[dayOf(e.OccurredAt)] = append(warnings[dayOf(e.OccurredAt)], "missing_clock_in")
+				continue
+			}
+			if span := e.OccurredAt.Sub(*open); span > maxWorkSpan {
+				warnings[dayOf(*open)] = append(warnings[dayOf(*open)], "missing_clock_out")
+				warnings[dayOf(e.OccurredAt)] = append(warnings[dayOf(e.OccurredAt)], "missing_clock_in")
+			} else {
+				worked[dayOf(*open)] += int(span / time.Minute)
+			}
+			open = nil
+		}
+	}
+	// Hâlâ içeride olan personel için uyarı verilmez
+	if open != nil && now.Sub(*open) > maxWorkSpan {
+		warnings[dayOf(*open)] = append(warnings[dayOf(*open)], "missing_clock_out")
+	}
+	return worked, warnings
+}
+
+// ExportTimesheetCSV bordro için personel başına aylık özet üretir; Excel'in Türkçe karakterleri
+// doğru açması için UTF-8 BOM ile başlar
+func (u *attendanceUsecase) ExportTimesheetCSV(hospitalID uint, filter dto.TimesheetFilter, w io.Writer) error {
+	sheet, err := u.GetTimesheet(hospitalID, filter)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"TC", "Ad", "Soyad", "Planlanan Saat", "Çalışılan Saat", "Fazla Mesai Saat", "Devamsızlık Gün", "İzin Gün"}); err != nil {
+		return err
+	}
+	for _, s := range sheet.Staffs {
+		err := cw.Write([]string{
+			s.TC,
+			csvSafe(s.FirstName),
+			csvSafe(s.LastName),
+			formatHours(s.ScheduledMinutes),
+			formatHours(s.WorkedMinutes),
+			formatHours(s.OvertimeMinutes),
+			strconv.Itoa(s.AbsentDays),
+			strconv.Itoa(s.LeaveDays),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatHours(minutes int) string {
+	return strconv.FormatFloat(float64(minutes)/60, 'f', 2, 64)
+}
+
+func toKioskResponse(k *models.Kiosk) dto.KioskResponse {
+	return dto.KioskResponse{
+		ID:       k.ID,
+		Name:     k.Name,
+		Location: k.Location,
+		Active:   k.Active,
+	}
+}
+
+func toAttendanceEventResponse(e *models.AttendanceEvent) *dto.AttendanceEventResponse {
+	return &dto.AttendanceEventResponse{
+		ID:         e.ID,
+		StaffID:    e.StaffID,
+		Type:       e.Type,
+		OccurredAt: e.OccurredAt.Format(time.RFC3339),
+		Source:     e.Source,
+		KioskID:    e.KioskID,
+		Reason:     e.Reason,
+		CreatedBy:  e.CreatedBy,
+	}
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"personnel-service/internal/models"
+	"personnel-service/internal/repository"
+)
+
+func TestPairEvents(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Istanbul")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := &attendanceUsecase{loc: loc}
+	at := func(day, hour, min int) time.Time { return time.Date(2026, 3, day, hour, min, 0, 0, loc) }
+	in := func(t time.Time) models.AttendanceEvent {
+		return models.AttendanceEvent{Type: models.AttendanceIn, OccurredAt: t}
+	}
+	out := func(t time.Time) models.AttendanceEvent {
+		return models.AttendanceEvent{Type: models.AttendanceOut, OccurredAt: t}
+	}
+	now := at(10, 12, 0)
+
+	tests := []struct {
+		name         string
+		events       []models.AttendanceEvent
+		wantWorked   map[string]int
+		wantWarnings map[string][]string
+	}{
+		{
+			name:         "day shift",
+			events:       []models.AttendanceEvent{in(at(2, 8, 0)), out(at(2, 17, 30))},
+			wantWorked:   map[string]int{"2026-03-02": 570},
+			wantWarnings: map[string][]string{},
+		},
+		{
+			name:         "night shift is counted on the day it started",
+			events:       []models.AttendanceEvent{in(at(2, 22, 0)), out(at(3, 6, 0))},
+			wantWorked:   map[string]int{"2026-03-02": 480},
+			wantWarnings: map[string][]string{},
+		},
+		{
+			name: "day is taken in the local timezone",
+			// 22:30 UTC Istanbul'da ertesi günün 01:30'udur
+			events:       []models.AttendanceEvent{in(time.Date(2026, 3, 2, 22, 30, 0, 0, time.UTC)), out(at(3, 9, 30))},
+			wantWorked:   map[string]int{"2026-03-03": 480},
+			wantWarnings: map[string][]string{},
+		},
+		{
+			name:         "clock out without clock in",
+			events:       []models.AttendanceEvent{out(at(2, 17, 0))},
+			wantWorked:   map[string]int{},
+			wantWarnings: map[string][]string{"2026-03-02": {"missing_clock_in"}},
+		},
+		{
+			name:         "clock in twice",
+			events:       []models.AttendanceEvent{in(at(2, 8, 0)), in(at(3, 8, 0)), out(at(3, 16, 0))},
+			wantWorked:   map[string]int{"2026-03-03": 480},
+			wantWarnings: map[string][]string{"2026-03-02": {"missing_clock_out"}},
+		},
+		{
+			name:       "pair longer than a day is not counted",
+			events:     []models.AttendanceEvent{in(at(2, 8, 0)), out(at(4, 9, 0))},
+			wantWorked: map[string]int{},
+			wantWarnings: map[string][]string{
+				"2026-03-02": {"missing_clock_out"},
+				"2026-03-04": {"missing_clock_in"},
+			},
+		},
+		{
+			name:         "staff still inside",
+			events:       []models.AttendanceEvent{in(at(10, 8, 0))},
+			wantWorked:   map[string]int{},
+			wantWarnings: map[string][]string{},
+		},
+		{
+			name:         "clock in never closed",
+			events:       []models.AttendanceEvent{in(at(8, 8, 0))},
+			wantWorked:   map[string]int{},
+			wantWarnings: map[string][]string{"2026-03-08": {"missing_clock_out"}},
+		},
+	}
+	for _, tt := range tests {
+		worked, warnings := u.pairEvents(tt.events, now)
+		if !reflect.DeepEqual(worked, tt.wantWorked) {
+			t.Errorf("%s: worked = %v, want %v", tt.name, worked, tt.wantWorked)
+		}
+		if !reflect.DeepEqual(warnings, tt.wantWarnings) {
+			t.Errorf("%s: warnings = %v, want %v", tt.name, warnings, tt.wantWarnings)
+		}
+	}
+}
+
+// kioskRepoStub sadece verifyQRToken'ın kullandığı kiosk sorgusunu karşılar
+type kioskRepoStub struct {
+	repository.AttendanceRepository
+	kiosk *models.Kiosk
+}
+
+func (s kioskRepoStub) GetKioskByID(id uint) (*models.Kiosk, error) {
+	if id != s.kiosk.ID {
+		return nil, errors.New("record not found")
+	}
+	return s.kiosk, nil
+}
+
+func TestVerifyQRToken(t *testing.T) {
+	kiosk := &models.Kiosk{Secret: "secret", Active: true}
+	kiosk.ID = 7
+	other := &models.Kiosk{Secret: "other"}
+	other.ID = 7
+	inactive := &models.Kiosk{Secret: "secret"}
+	inactive.ID = 7
+
+	// Adım sınırına denk gelip testin oynamaması için uzun adım kullanılır
+	u := &attendanceUsecase{repo: kioskRepoStub{kiosk: kiosk}, step: time.Hour, loc: time.UTC}
+	current := u.currentStep(time.Now())
+
+	tests := []struct {
+		name  string
+		token string
+		ok    bool
+	}{
+		{"current step", qrToken(kiosk, current), true},
+		{"previous step", qrToken(kiosk, current-1), true},
+		{"two steps old", qrToken(kiosk, current-2), false},
+		{"future step", qrToken(kiosk, current+1), false},
+		{"signed with another secret", qrToken(other, current), false},
+		{"unknown kiosk", "8." + qrToken(kiosk, current)[2:], false},
+		{"malformed", "7.abc", false},
+	}
+	for _, tt := range tests {
+		got, step, err := u.verifyQRToken(tt.token)
+		if !tt.ok {
+			if !errors.Is(err, ErrInvalidQRToken) {
+				t.Errorf("%s: err = %v, want ErrInvalidQRToken", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if got.ID != kiosk.ID || (step != current && step != current-1) {
+			t.Errorf("%s: got kiosk %d step %d", tt.name, got.ID, step)
+		}
+	}
+
+	u.repo = kioskRepoStub{kiosk: inactive}
+	if _, _, err := u.verifyQRToken(qrToken(inactive, current)); !errors.Is(err, ErrInvalidQRToken) {
+		t.Errorf("inactive kiosk: err = %v, want ErrInvalidQRToken", err)
+	}
+}
@@ -0,0 +1,403 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"hospital-shared/notification"
+	"personnel-service/internal/dto"
+	"personnel-service/internal/infrastructure/client"
+	"personnel-service/internal/models"
+	"personnel-service/internal/repository"
+)
+
+const (
+	defaultExpiringDays = 30
+	maxExpiringDays     = 365
+	// Yüklenebilecek en büyük belge; Fiber'ın 4MB gövde sınırına multipart ek yükü için pay bırakılır
+	MaxCertificationDocumentSize = 3 << 20
+)
+
+var ErrCertificationDocumentNotFound = repository.ErrCertificationDocumentNotFound
+
+var certificationTypeNames = map[string]string{
+	models.CertificationDiploma:   "Diploma",
+	models.CertificationSpecialty: "Uzmanlık Belgesi",
+	models.CertificationBLS:       "BLS",
+	models.CertificationACLS:      "ACLS",
+	models.CertificationOther:     "Diğer",
+}
+
+// Taranmış belge olarak kabul edilen içerik türleri; uzantıya değil içeriğe bakılır
+var certificationDocumentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+}
+
+type CertificationUsecase interface {
+	AddCertification(staffID, hospitalID uint, req *dto.CertificationRequest) (*dto.CertificationResponse, error)
+	UpdateCertification(id, hospitalID uint, req *dto.CertificationRequest) (*dto.CertificationResponse, error)
+	DeleteCertification(id, hospitalID uint) error
+	ListStaffCertifications(staffID, hospitalID uint) ([]dto.CertificationResponse, error)
+
+	UploadDocument(id, hospitalID uint, fileName string, data []byte) (*dto.CertificationResponse, error)
+	GetDocument(id, hospitalID uint) (*models.CertificationDocument, error)
+
+	ListExpiring(hospitalID uint, days int) (*dto.ExpiringCertificationsResponse, error)
+	// NotifyExpiring süresi yaklaşan sertifikaları hastanelerin yetkililerine bildirir; her sertifika
+	// bitiş tarihi değişmedikçe bir kez bildirilir
+	NotifyExpiring(now time.Time) error
+}
+
+type certificationUsecase struct {
+	repo          repository.CertificationRepository
+	personnelRepo repository.PersonnelRepository
+	authClient    client.AuthClient
+	notifier      notification.Notifier
+	noticeDays    int
+	loc           *time.Location
+}
+
+func NewCertificationUsecase(repo repository.CertificationRepository, personnelRepo repository.PersonnelRepository,
+	authClient client.AuthClient, notifier notification.Notifier, noticeDays int, loc *time.Location) CertificationUsecase {
+	if noticeDays <= 0 {
+		noticeDays = defaultExpiringDays
+	}
+	return &certificationUsecase{
+		repo:          repo,
+		personnelRepo: personnelRepo,
+		authClient:    authClient,
+		notifier:      notifier,
+		noticeDays:    noticeDays,
+		loc:           loc,
+	}
+}
+
+// today hastanenin saat dilimindeki bugündür; tarih kolonlarıyla karşılaştırmak için UTC gece yarısı olarak döner
+func (u *certificationUsecase) today(now time.Time) time.Time {
+	d := now.In(u.loc)
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (u *certificationUsecase) ownedStaff(staffID, hospitalID uint) (*models.Staff, error) {
+	staff, err := u.personnelRepo.GetStaffByID(staffID)
+	if err != nil {
+		return nil, errors.New("staff not found")
+	}
+	if staff.HospitalID != hospitalID {
+		return nil, errors.New("forbidden: staff belongs to another hospital")
+	}
+	return staff, nil
+}
+
+func (u *certificationUsecase) ownedCertification(id, hospitalID uint) (*models.Certification, error) {
+	c, err := u.repo.GetCertificationByID(id)
+	if err != nil {
+		return nil, errors.New("certification not found")
+	}
+	if c.HospitalID != hospitalID {
+		return nil, errors.New("forbidden: certification belongs to another hospital")
+	}
+	return c, nil
+}
+
+// applyCertificationRequest isteği doğrulayıp sertifikaya uygular
+func (u *certificationUsecase) applyCertificationRequest(c *models.Certification, req *dto.CertificationRequest) error {
+	certType := strings.ToLower(strings.TrimSpace(req.Type))
+	if _, ok := certificationTypeNames[certType]; !ok {
+		return errors.New("type must be one of diploma, specialty, bls, acls, other")
+	}
+	issuer := strings.TrimSpace(req.Issuer)
+	if issuer == "" {
+		return errors.New("issuer is required")
+	}
+	issuedAt, err := time.Parse(dateLayout, req.IssuedAt)
+	if err != nil {
+		return errors.New("issued_at must be in YYYY-MM-DD format")
+	}
+	if issuedAt.After(u.today(time.Now())) {
+		return errors.New("issued_at cannot be in the future")
+	}
+
+	var expiresAt *time.Time
+	if req.ExpiresAt != nil && *req.ExpiresAt != "" {
+		d, err := time.Parse(dateLayout, *req.ExpiresAt)
+		if err != nil {
+			return errors.New("expires_at must be in YYYY-MM-DD format")
+		}
+		if !d.After(issuedAt) {
+			return errors.New("expires_at must be after issued_at")
+		}
+		expiresAt = &d
+	}
+	if expiresAt == nil && (certType == models.CertificationBLS || certType == models.CertificationACLS) {
+		return errors.New("expires_at is required for bls and acls certificates")
+	}
+
+	// Bitiş tarihi değişen sertifika yeniden bildirilebilir
+	if !sameDate(c.ExpiresAt, expiresAt) {
+		c.ExpiryNotifiedAt = nil
+	}
+	c.Type = certType
+	c.Number = strings.TrimSpace(req.Number)
+	c.Issuer = issuer
+	c.IssuedAt = issuedAt
+	c.ExpiresAt = expiresAt
+	return nil
+}
+
+func sameDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
+
+func (u *certificationUsecase) AddCertification(staffID, hospitalID uint, req *dto.CertificationRequest) (*dto.CertificationResponse, error) {
+	if _, err := u.ownedStaff(staffID, hospitalID); err != nil {
+		return nil, err
+	}
+
+	c := &models.Certification{StaffID: staffID, HospitalID: hospitalID}
+	if err := u.applyCertificationRequest(c, req); err != nil {
+		return nil, err
+	}
+	if err := u.repo.CreateCertification(c); err != nil {
+		return nil, err
+	}
+	resp := u.toCertificationResponse(c, u.today(time.Now()))
+	return &resp, nil
+}
+
+func (u *certificationUsecase) UpdateCertification(id, hospitalID uint, req *dto.CertificationRequest) (*dto.CertificationResponse, error) {
+	c, err := u.ownedCertification(id, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.applyCertificationRequest(c, req); err != nil {
+		return nil, err
+	}
+	if err := u.repo.UpdateCertification(c); err != nil {
+		return nil, err
+	}
+	resp := u.toCertificationResponse(c, u.today(time.Now()))
+	return &resp, nil
+}
+
+func (u *certificationUsecase) DeleteCertification(id, hospitalID uint) error {
+	c, err := u.ownedCertification(id, hospitalID)
+	if err != nil {
+		return err
+	}
+	return u.repo.DeleteCertification(c)
+}
+
+func (u *certificationUsecase) ListStaffCertifications(staffID, hospitalID uint) ([]dto.CertificationResponse, error) {
+	if _, err := u.ownedStaff(staffID, hospitalID); err != nil {
+		return nil, err
+	}
+	certs, err := u.repo.ListStaffCertifications(staffID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := u.today(time.Now())
+	resp := make([]dto.CertificationResponse, 0, len(certs))
+	for i := range certs {
+		resp = append(resp, u.toCertificationResponse(&certs[i], today))
+	}
+	return resp, nil
+}
+
+func (u *certificationUsecase) UploadDocument(id, hospitalID uint, fileName string, data []byte) (*dto.CertificationResponse, error) {
+	c, err := u.ownedCertification(id, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("document is empty")
+	}
+	if len(data) > MaxCertificationDocumentSize {
+		return nil, fmt.Errorf("document cannot exceed %d MB", MaxCertificationDocumentSize>>20)
+	}
+	contentType := http.DetectContentType(data)
+	if !certificationDocumentTypes[contentType] {
+		return nil, errors.New("document must be a PDF, JPEG or PNG file")
+	}
+
+	doc := &models.CertificationDocument{
+		CertificationID: c.ID,
+		FileName:        fileName,
+		ContentType:     contentType,
+		Size:            int64(len(data)),
+		Data:            data,
+		CreatedAt:       time.Now(),
+	}
+	if err := u.repo.SaveDocument(doc); err != nil {
+		return nil, err
+	}
+
+	doc.Data = nil
+	c.Document = doc
+	resp := u.toCertificationResponse(c, u.today(time.Now()))
+	return &resp, nil
+}
+
+func (u *certificationUsecase) GetDocument(id, hospitalID uint) (*models.CertificationDocument, error) {
+	c, err := u.ownedCertification(id, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	return u.repo.GetDocument(c.ID)
+}
+
+func (u *certificationUsecase) ListExpiring(hospitalID uint, days int) (*dto.ExpiringCertificationsResponse, error) {
+	if days <= 0 {
+		days = defaultExpiringDays
+	}
+	if days > maxExpiringDays {
+		return nil, fmt.Errorf("days cannot exceed %d", maxExpiringDays)
+	}
+
+	today := u.today(time.Now())
+	certs, err := u.repo.ListExpiring(hospitalID, today.AddDate(0, 0, days))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.ExpiringCertificationsResponse{Days: days, Certifications: make([]dto.ExpiringCertification, 0, len(certs))}
+	for i := range certs {
+		c := &certs[i]
+		resp.Certifications = append(resp.Certifications, dto.ExpiringCertification{
+			CertificationResponse: u.toCertificationResponse(c, today),
+			FirstName:             c.Staff.FirstName,
+			LastName:              c.Staff.LastName,
+			JobGroupName:          c.Staff.JobGroup.Name,
+			TitleName:             c.Staff.Title.Name,
+		})
+	}
+	return resp, nil
+}
+
+func (u *certificationUsecase) NotifyExpiring(now time.Time) error {
+	today := u.today(now)
+	certs, err := u.repo.ClaimExpiryNotifications(today.AddDate(0, 0, u.noticeDays), now)
+	if err != nil {
+		return err
+	}
+
+	byHospital := make(map[uint][]models.Certification)
+	var hospitalIDs []uint
+	for _, c := range certs {
+		if _, ok := byHospital[c.HospitalID]; !ok {
+			hospitalIDs = append(hospitalIDs, c.HospitalID)
+		}
+		byHospital[c.HospitalID] = append(byHospital[c.HospitalID], c)
+	}
+	sort.Slice(hospitalIDs, func(i, j int) bool { return hospitalIDs[i] < hospitalIDs[j] })
+
+	// Bir hastaneye gönderilemeyen bildirimler diğerlerini etkilemez, ertesi gün yeniden denenir
+	for _, hospitalID := range hospitalIDs {
+		list := byHospital[hospitalID]
+		if err := u.notifyHospital(hospitalID, list, today); err != nil {
+			log.Printf("certification expiry notification for hospital %d failed: %v", hospitalID, err)
+			ids := make([]uint, len(list))
+			for i, c := range list {
+				ids[i] = c.ID
+			}
+			if err := u.repo.ReleaseExpiryNotifications(ids); err != nil {
+				log.Printf("certification expiry notifications of hospital %d could not be released: %v", hospitalID, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (u *certificationUsecase) notifyHospital(hospitalID uint, certs []models.Certification, today time.Time) error {
+	contacts, err := u.authClient.ListHospitalContacts(hospitalID, "yetkili")
+	if err != nil {
+		return err
+	}
+	if len(contacts) == 0 {
+		return errors.New("hospital has no yetkili user to notify")
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Aşağıdaki sertifikaların süresi %d gün içinde doluyor ya da dolmuş durumda:\n\n", u.noticeDays)
+	for _, c := range certs {
+		fmt.Fprintf(&body, "- %s %s", c.Staff.FirstName, c.Staff.LastName)
+		if c.Staff.Title.Name != "" {
+			fmt.Fprintf(&body, " (%s)", c.Staff.Title.Name)
+		}
+		fmt.Fprintf(&body, ": %s", certificationTypeNames[c.Type])
+		if c.Number != "" {
+			fmt.Fprintf(&body, " no %s", c.Number)
+		}
+		fmt.Fprintf(&body, ", bitiş %s, %s\n", c.ExpiresAt.Format("02.01.2006"), expiryStatus(daysBetween(today, *c.ExpiresAt)))
+	}
+	subject := fmt.Sprintf("Süresi dolan sertifikalar (%d)", len(certs))
+
+	sent := 0
+	for _, contact := range contacts {
+		if contact.Email == "" {
+			continue
+		}
+		if err := u.notifier.Send(contact.Email, subject, body.String()); err != nil {
+			log.Printf("certification expiry notification to authority %d failed: %v", contact.ID, err)
+			continue
+		}
+		sent++
+	}
+	if sent == 0 {
+		return errors.New("notification could not be sent to any yetkili user")
+	}
+	return nil
+}
+
+func expiryStatus(daysLeft int) string {
+	switch {
+	case daysLeft < 0:
+		return "süresi doldu"
+	case daysLeft == 0:
+		return "bugün doluyor"
+	default:
+		return fmt.Sprintf("%d gün kaldı", daysLeft)
+	}
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from) / (24 * time.Hour))
+}
+
+func (u *certificationUsecase) toCertificationResponse(c *models.Certification, today time.Time) dto.CertificationResponse {
+	resp := dto.CertificationResponse{
+		ID:       c.ID,
+		StaffID:  c.StaffID,
+		Type:     c.Type,
+		Number:   c.Number,
+		Issuer:   c.Issuer,
+		IssuedAt: c.IssuedAt.Format(dateLayout),
+	}
+	if c.ExpiresAt != nil {
+		expiresAt := c.ExpiresAt.Format(dateLayout)
+		daysLeft := daysBetween(today, *c.ExpiresAt)
+		resp.ExpiresAt = &expiresAt
+		resp.DaysLeft = &daysLeft
+		resp.Expired = daysLeft < 0
+	}
+	if c.Document != nil {
+		resp.Document = &dto.CertificationDocumentInfo{
+			FileName:    c.Document.FileName,
+			ContentType: c.Document.ContentType,
+			Size:        c.Document.Size,
+			UploadedAt:  c.Document.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	return resp
+}
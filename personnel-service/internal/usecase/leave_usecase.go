@@ -0,0 +1,409 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"personnel-service/internal/dto"
+	"personnel-service/internal/models"
+	"personnel-service/internal/repository"
+)
+
+// Tek bir izin talebinin kapsayabileceği en uzun süre
+const maxLeaveDays = 366
+
+var (
+	// ErrLeaveConflictsWithRoster izin yayınlanmış bir nöbetle çakışırken zorlamadan onaylanmak istendiğinde döner
+	ErrLeaveConflictsWithRoster = errors.New("leave overlaps published on-call duties")
+	ErrInsufficientLeaveBalance = repository.ErrInsufficientLeaveBalance
+	ErrLeaveNotPending          = repository.ErrLeaveNotPending
+)
+
+type LeaveUsecase interface {
+	ListLeaveTypes() ([]dto.LeaveTypeResponse, error)
+	SetEntitlement(staffID, hospitalID uint, req *dto.SetLeaveEntitlementRequest) error
+	GetBalance(staffID, hospitalID uint, year int) (*dto.LeaveBalanceResponse, error)
+
+	CreateLeaveRequest(hospitalID uint, req *dto.CreateLeaveRequest) (*dto.LeaveRequestResponse, error)
+	ListLeaveRequests(hospitalID uint, filter dto.LeaveRequestListFilter, page, size int) (*dto.LeaveRequestListResponse, error)
+	ApproveLeaveRequest(id, hospitalID, reviewerID uint, note string, force bool) (*dto.LeaveRequestResponse, error)
+	RejectLeaveRequest(id, hospitalID, reviewerID uint, note string) (*dto.LeaveRequestResponse, error)
+	CancelLeaveRequest(id, hospitalID, reviewerID uint) (*dto.LeaveRequestResponse, error)
+
+	PublishLeaveCounts(now time.Time) error
+}
+
+type leaveUsecase struct {
+	repo          repository.LeaveRepository
+	personnelRepo repository.PersonnelRepository
+}
+
+func NewLeaveUsecase(repo repository.LeaveRepository, personnelRepo repository.PersonnelRepository) LeaveUsecase {
+	return &leaveUsecase{
+		repo:          repo,
+		personnelRepo: personnelRepo,
+	}
+}
+
+func (u *leaveUsecase) ownedStaff(staffID, hospitalID uint) (*models.Staff, error) {
+	staff, err := u.personnelRepo.GetStaffByID(staffID)
+	if err != nil {
+		return nil, errors.New("staff not found")
+	}
+	if staff.HospitalID != hospitalID {
+		return nil, errors.New("forbidden: staff belongs to another hospital")
+	}
+	return staff, nil
+}
+
+func (u *leaveUsecase) ownedRequest(id, hospitalID uint) (*models.LeaveRequest, error) {
+	req, err := u.repo.GetLeaveRequestByID(id)
+	if err != nil {
+		return nil, errors.New("leave request not found")
+	}
+	if req.HospitalID != hospitalID {
+		return nil, errors.New("forbidden: leave request belongs to another hospital")
+	}
+	return req, nil
+}
+
+func (u *leaveUsecase) ListLeaveTypes() ([]dto.LeaveTypeResponse, error) {
+	types, err := u.repo.ListLeaveTypes()
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]dto.LeaveTypeResponse, 0, len(types))
+	for _, t := range types {
+		resp = append(resp, dto.LeaveTypeResponse{ID: t.ID, Code: t.Code, Name: t.Name, YearlyDays: t.YearlyDays})
+	}
+	return resp, nil
+}
+
+func (u *leaveUsecase) SetEntitlement(staffID, hospitalID uint, req *dto.SetLeaveEntitlementRequest) error {
+	staff, err := u.ownedStaff(staffID, hospitalID)
+	if err != nil {
+		return err
+	}
+	if _, err := u.repo.GetLeaveTypeByID(req.LeaveTypeID); err != nil {
+		return errors.New("leave type not found")
+	}
+	if req.Year < 2000 || req.Year > 2100 {
+		return errors.New("invalid year")
+	}
+	if req.Days < 0 || req.Days > maxLeaveDays {
+		return errors.New("invalid days")
+	}
+
+	return u.repo.UpsertEntitlement(&models.LeaveEntitlement{
+		StaffID:     staff.ID,
+		LeaveTypeID: req.LeaveTypeID,
+		Year:        req.Year,
+		Days:        req.Days,
+	})
+}
+
+func (u *leaveUsecase) GetBalance(staffID, hospitalID uint, year int) (*dto.LeaveBalanceResponse, error) {
+	staff, err := u.ownedStaff(staffID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	if year == 0 {
+		year = time.Now().Year()
+	}
+
+	types, err := u.repo.ListLeaveTypes()
+	if err != nil {
+		return nil, err
+	}
+	entitlements, err := u.repo.GetEntitlements(staff.ID, year)
+	if err != nil {
+		return nil, err
+	}
+	sums, err := u.repo.SumLeaveDays(staff.ID, year)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.LeaveBalanceResponse{StaffID: staff.ID, Year: year, Balances: make([]dto.LeaveBalance, 0, len(types))}
+	for _, t := range types {
+		allowance := entitlementFor(&t, entitlements)
+		b := dto.LeaveBalance{
+			LeaveTypeID:   t.ID,
+			LeaveTypeName: t.Name,
+			Tracked:       allowance >= 0,
+			Used:          sums[t.ID][models.LeaveStatusApproved],
+			Pending:       sums[t.ID][models.LeaveStatusPending],
+		}
+		if b.Tracked {
+			b.Entitlement = allowance
+			b.Remaining = allowance - b.Used
+		}
+		resp.Balances = append(resp.Balances, b)
+	}
+	return resp, nil
+}
+
+// entitlementFor personele özel hak yoksa türün yıllık hakkını döner; takip edilmeyen türler için -1
+func entitlementFor(t *models.LeaveType, entitlements map[uint]int) int {
+	if days, ok := entitlements[t.ID]; ok {
+		return days
+	}
+	if t.YearlyDays == 0 {
+		return -1
+	}
+	return t.YearlyDays
+}
+
+func (u *leaveUsecase) CreateLeaveRequest(hospitalID uint, req *dto.CreateLeaveRequest) (*dto.LeaveRequestResponse, error) {
+	staff, err := u.ownedStaff(req.StaffID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	leaveType, err := u.repo.GetLeaveTypeByID(req.LeaveTypeID)
+	if err != nil {
+		return nil, errors.New("leave type not found")
+	}
+
+	start, err := time.Parse(dateLayout, req.StartDate)
+	if err != nil {
+		return nil, errors.New("start_date must be in YYYY-MM-DD format")
+	}
+	end, err := time.Parse(dateLayout, req.EndDate)
+	if err != nil {
+		return nil, errors.New("end_date must be in YYYY-MM-DD format")
+	}
+	if end.Before(start) {
+		return nil, errors.New("start_date must not be after end_date")
+	}
+	// Bakiye yıl bazında tutulur; yıl sonunu aşan izin iki talebe bölünür
+	if start.Year() != end.Year() {
+		return nil, errors.New("leave cannot span two calendar years, split it into two requests")
+	}
+
+	overlapping, err := u.repo.HasOverlappingLeave(staff.ID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if overlapping {
+		return nil, errors.New("staff already has a pending or approved leave in this period")
+	}
+
+	days, err := u.workingDays(staff.ID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if days == 0 {
+		return nil, errors.New("leave does not cover any working day of the staff")
+	}
+
+	leave := models.LeaveRequest{
+		StaffID:     staff.ID,
+		HospitalID:  hospitalID,
+		LeaveTypeID: leaveType.ID,
+		StartDate:   start,
+		EndDate:     end,
+		Days:        days,
+		Status:      models.LeaveStatusPending,
+		Reason:      req.Reason,
+		LeaveType:   *leaveType,
+	}
+	if err := u.repo.CreateLeaveRequest(&leave); err != nil {
+		return nil, err
+	}
+	return u.toLeaveResponse(&leave, staff)
+}
+
+// workingDays aralıkta personelin vardiyası olan günleri sayar.
+// Haftalık vardiyası tanımlanmamış personel için hafta içi günler sayılır.
+func (u *leaveUsecase) workingDays(staffID uint, start, end time.Time) (int, error) {
+	staff, err := u.repo.GetStaffWithSchedule(staffID, start, end)
+	if err != nil {
+		return 0, err
+	}
+
+	days := 0
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		if len(staff.Shifts) == 0 && len(staff.Overrides) == 0 {
+			if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+				days++
+			}
+			continue
+		}
+		if len(effectiveShifts(staff, d)) > 0 {
+			days++
+		}
+	}
+	return days, nil
+}
+
+func (u *leaveUsecase) ListLeaveRequests(hospitalID uint, filter dto.LeaveRequestListFilter, page, size int) (*dto.LeaveRequestListResponse, error) {
+	q := repository.LeaveRequestQuery{
+		HospitalID: hospitalID,
+		Status:     filter.Status,
+		StaffID:    filter.StaffID,
+	}
+	switch filter.Status {
+	case "", models.LeaveStatusPending, models.LeaveStatusApproved, models.LeaveStatusRejected, models.LeaveStatusCancelled:
+	default:
+		return nil, errors.New("invalid status")
+	}
+	if filter.From != "" {
+		d, err := time.Parse(dateLayout, filter.From)
+		if err != nil {
+			return nil, errors.New("from must be in YYYY-MM-DD format")
+		}
+		q.From = &d
+	}
+	if filter.To != "" {
+		d, err := time.Parse(dateLayout, filter.To)
+		if err != nil {
+			return nil, errors.New("to must be in YYYY-MM-DD format")
+		}
+		q.To = &d
+	}
+
+	reqs, total, err := u.repo.ListLeaveRequests(q, page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.LeaveRequestResponse, 0, len(reqs))
+	staffCache := make(map[uint]*models.Staff)
+	for i := range reqs {
+		staff, ok := staffCache[reqs[i].StaffID]
+		if !ok {
+			// Silinmiş personelin izin kaydı isimsiz döner
+			staff, _ = u.personnelRepo.GetStaffByID(reqs[i].StaffID)
+			staffCache[reqs[i].StaffID] = staff
+		}
+		item := toLeaveRequestResponse(&reqs[i], staff)
+		resp = append(resp, *item)
+	}
+	return &dto.LeaveRequestListResponse{
+		Requests: resp,
+		Total:    int(total),
+		Page:     page,
+		Size:     size,
+	}, nil
+}
+
+// ApproveLeaveRequest bakiyeyi kontrol ederek talebi onaylar. Yayınlanmış nöbetle çakışan izin
+// force olmadan onaylanmaz; çakışmalar yanıtta döner.
+func (u *leaveUsecase) ApproveLeaveRequest(id, hospitalID, reviewerID uint, note string, force bool) (*dto.LeaveRequestResponse, error) {
+	leave, err := u.ownedRequest(id, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	if leave.Status != models.LeaveStatusPending {
+		return nil, ErrLeaveNotPending
+	}
+	staff, err := u.ownedStaff(leave.StaffID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := u.toLeaveResponse(leave, staff)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Conflicts) > 0 && !force {
+		return resp, ErrLeaveConflictsWithRoster
+	}
+
+	entitlements, err := u.repo.GetEntitlements(leave.StaffID, leave.StartDate.Year())
+	if err != nil {
+		return nil, err
+	}
+	allowance := entitlementFor(&leave.LeaveType, entitlements)
+	if err := u.repo.ApproveLeaveRequest(leave.ID, reviewerID, note, allowance); err != nil {
+		return nil, err
+	}
+	return u.reload(leave.ID, staff)
+}
+
+func (u *leaveUsecase) RejectLeaveRequest(id, hospitalID, reviewerID uint, note string) (*dto.LeaveRequestResponse, error) {
+	leave, err := u.ownedRequest(id, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.repo.CloseLeaveRequest(leave.ID, []string{models.LeaveStatusPending}, models.LeaveStatusRejected, reviewerID, note); err != nil {
+		return nil, err
+	}
+	staff, _ := u.personnelRepo.GetStaffByID(leave.StaffID)
+	return u.reload(leave.ID, staff)
+}
+
+// Bekleyen ya da onaylı izin iptal edilebilir; onaylı iznin günleri bakiyeye geri döner
+func (u *leaveUsecase) CancelLeaveRequest(id, hospitalID, reviewerID uint) (*dto.LeaveRequestResponse, error) {
+	leave, err := u.ownedRequest(id, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	err = u.repo.CloseLeaveRequest(leave.ID, []string{models.LeaveStatusPending, models.LeaveStatusApproved}, models.LeaveStatusCancelled, reviewerID, "")
+	if errors.Is(err, ErrLeaveNotPending) {
+		return nil, errors.New("only pending or approved leave can be cancelled")
+	}
+	if err != nil {
+		return nil, err
+	}
+	staff, _ := u.personnelRepo.GetStaffByID(leave.StaffID)
+	return u.reload(leave.ID, staff)
+}
+
+func (u *leaveUsecase) reload(id uint, staff *models.Staff) (*dto.LeaveRequestResponse, error) {
+	leave, err := u.repo.GetLeaveRequestByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return toLeaveRequestResponse(leave, staff), nil
+}
+
+// toLeaveResponse izin aralığındaki yayınlanmış nöbetleri çakışma olarak ekler
+func (u *leaveUsecase) toLeaveResponse(leave *models.LeaveRequest, staff *models.Staff) (*dto.LeaveRequestResponse, error) {
+	resp := toLeaveRequestResponse(leave, staff)
+	duties, err := u.repo.ListPublishedDutiesOfStaff(leave.StaffID, leave.StartDate, leave.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range duties {
+		resp.Conflicts = append(resp.Conflicts, dto.LeaveConflict{Date: d.Date.Format(dateLayout), RosterID: d.RosterID})
+	}
+	return resp, nil
+}
+
+func toLeaveRequestResponse(leave *models.LeaveRequest, staff *models.Staff) *dto.LeaveRequestResponse {
+	resp := &dto.LeaveRequestResponse{
+		ID:            leave.ID,
+		StaffID:       leave.StaffID,
+		LeaveTypeID:   leave.LeaveTypeID,
+		LeaveTypeName: leave.LeaveType.Name,
+		StartDate:     leave.StartDate.Format(dateLayout),
+		EndDate:       leave.EndDate.Format(dateLayout),
+		Days:          leave.Days,
+		Status:        leave.Status,
+		Reason:        leave.Reason,
+		ReviewedBy:    leave.ReviewedBy,
+		ReviewedAt:    formatTime(leave.ReviewedAt),
+		ReviewNote:    leave.ReviewNote,
+		Conflicts:     []dto.LeaveConflict{},
+	}
+	if staff != nil {
+		resp.FirstName = staff.FirstName
+		resp.LastName = staff.LastName
+	}
+	return resp
+}
+
+// PublishLeaveCounts günlük çalışır; izni bugün başlayan ya da dün biten personelin
+// polikliniklerindeki sayılar hospital servisine yeniden yayınlanır
+func (u *leaveUsecase) PublishLeaveCounts(now time.Time) error {
+	hospitals, err := u.repo.PublishLeaveBoundaryCounts(now)
+	if err != nil {
+		return err
+	}
+	if hospitals > 0 {
+		log.Printf("published staff counts of %d hospitals for leave starting or ending today", hospitals)
+	}
+	return nil
+}
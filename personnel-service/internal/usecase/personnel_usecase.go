@@ -2,16 +2,20 @@ package usecase
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"log"
+	"strings"
+	"time"
 
+	"hospital-shared/cache"
 	dt "hospital-shared/dto"
 	"personnel-service/internal/dto"
 	"personnel-service/internal/infrastructure/client"
 	"personnel-service/internal/models"
 	"personnel-service/internal/repository"
-
-	"github.com/go-redis/redis/v8"
+	"personnel-service/pkg/utils"
 )
 
 type PersonnelUsecase interface {
@@ -21,97 +25,97 @@ type PersonnelUsecase interface {
 	AddStaff(req *dto.AddStaffRequest, hospitalID uint) (*dto.StaffResponse, error)
 	UpdateStaff(id uint, req *dto.UpdateStaffRequest, hospitalID uint) (*dto.StaffResponse, error)
 	DeleteStaff(id, hospitalID uint) error
-	ListStaff(hospitalID uint, filter dto.StaffListFilter, page, size int) (*dto.StaffListResponse, error)
+	ListStaff(hospitalID uint, role string, filter dto.StaffListFilter, page dto.StaffListPage) (*dto.StaffListResponse, error)
+	SearchStaff(hospitalID uint, role string, query string, limit int) (*dto.StaffSearchResponse, error)
 
-	CountPersonnelByHpID(hpID uint) (int64, error)
+	CountPersonnelByHpID(hpID uint, includeOnLeave bool) (int64, error)
 	GetGroupCountsByHpID(hpID uint) ([]dt.PolyclinicPersonnelGroup, error)
+	GetPersonnelStatsBatch(hpIDs []uint) ([]dt.PolyclinicPersonnelStats, error)
+	ReassignStaff(req *dt.ReassignStaffRequest) (*dt.ReassignStaffResponse, error)
+	ListStaffRefs(afterID uint, limit int) (*dt.StaffRefPage, error)
 }
 
+// Toplu istatistik isteğinde kabul edilen en fazla poliklinik sayısı
+const maxStatsBatchSize = 200
+
+// Meslek grubu ve unvanlar seed ile gelir, nadiren değişir
+var (
+	JobGroupCacheEntity = cache.Entity{Name: "job_groups", Version: 1, TTL: 12 * time.Hour}
+	TitleCacheEntity    = cache.Entity{Name: "titles_by_job_group", Version: 1, TTL: 12 * time.Hour}
+)
+
 type personnelUsecase struct {
 	repo             repository.PersonnelRepository
-	redis            *redis.Client
+	cache            *cache.Cache
 	polyclinicClient client.PolyclinicClient
 }
 
-func NewPersonnelUsecase(repo repository.PersonnelRepository, redis *redis.Client, pc client.PolyclinicClient) PersonnelUsecase {
+func NewPersonnelUsecase(repo repository.PersonnelRepository, c *cache.Cache, pc client.PolyclinicClient) PersonnelUsecase {
 	return &personnelUsecase{
 		repo:             repo,
-		redis:            redis,
+		cache:            c,
 		polyclinicClient: pc,
 	}
 }
 
 func (u *personnelUsecase) ListAllJobGroups() ([]dto.JobGroupLookup, error) {
-	ctx := context.Background()
-	cacheKey := "job_groups"
-
-	// Önce Redis'te var mı bak
-	cached, err := u.redis.Get(ctx, cacheKey).Result()
-	if err == nil && cached != "" {
-		var resp []dto.JobGroupLookup
-		if err := json.Unmarshal([]byte(cached), &resp); err == nil {
-			return resp, nil
+	return cache.GetOrLoad(context.Background(), u.cache, JobGroupCacheEntity, nil, func() ([]dto.JobGroupLookup, error) {
+		groups, err := u.repo.GetAllJobGroups()
+		if err != nil {
+			return nil, err
 		}
-	}
-
-	// 2. Yoksa DB'den çek
-	groups, err := u.repo.GetAllJobGroups()
-	if err != nil {
-		return nil, err
-	}
 
-	resp := make([]dto.JobGroupLookup, 0, len(groups))
-	for _, g := range groups {
-		resp = append(resp, dto.JobGroupLookup{
-			ID:   g.ID,
-			Name: g.Name,
-		})
-	}
-
-	// 3. Redis'e yaz
-	if data, err := json.Marshal(resp); err == nil {
-		_ = u.redis.Set(ctx, cacheKey, data, 0).Err() // Hata olursa cache'siz devam et
-	}
-	return resp, nil
+		resp := make([]dto.JobGroupLookup, 0, len(groups))
+		for _, g := range groups {
+			resp = append(resp, dto.JobGroupLookup{
+				ID:   g.ID,
+				Name: g.Name,
+			})
+		}
+		return resp, nil
+	})
 }
 
 func (u *personnelUsecase) ListTitleByJobGroup(jobGroupID uint) ([]dto.TitleLookup, error) {
-	ctx := context.Background()
-	cacheKey := "titles_by_jobgroup_" + string(rune(jobGroupID))
-
-	// Önce Redis'te var mı bak
-	cached, err := u.redis.Get(ctx, cacheKey).Result()
-	if err == nil && cached != "" {
-		var resp []dto.TitleLookup
-		if err := json.Unmarshal([]byte(cached), &resp); err == nil {
-			return resp, nil
+	return cache.GetOrLoad(context.Background(), u.cache, TitleCacheEntity, []interface{}{jobGroupID}, func() ([]dto.TitleLookup, error) {
+		titles, err := u.repo.GetAllTitlesByJobGroup(jobGroupID)
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	// Yoksa DB'den çek
-	titles, err := u.repo.GetAllTitlesByJobGroup(jobGroupID)
-	if err != nil {
-		return nil, err
-	}
+		resp := make([]dto.TitleLookup, 0, len(titles))
+		for _, t := range titles {
+			resp = append(resp, dto.TitleLookup{
+				ID:   t.ID,
+				Name: t.Name,
+			})
+		}
+		return resp, nil
+	})
+}
 
-	resp := make([]dto.TitleLookup, 0, len(titles))
-	for _, t := range titles {
-		resp = append(resp, dto.TitleLookup{
-			ID:   t.ID,
-			Name: t.Name,
-		})
+// staffIdentity TC ve telefonu toplu içe aktarmayla aynı kurallarla doğrular; telefonun
+// saklanacak ve aranacak normalize halini döner
+func staffIdentity(tc, phone string) (string, string, error) {
+	tc = strings.TrimSpace(tc)
+	if !utils.ValidTC(tc) {
+		return "", "", errors.New("invalid TC number")
 	}
-
-	// Redis'e yaz
-	if data, err := json.Marshal(resp); err == nil {
-		_ = u.redis.Set(ctx, cacheKey, data, 0).Err()
+	normalized, ok := utils.NormalizePhone(phone)
+	if !ok {
+		return "", "", errors.New("invalid phone number")
 	}
-	return resp, nil
+	return tc, normalized, nil
 }
 
 func (u *personnelUsecase) AddStaff(req *dto.AddStaffRequest, hospitalID uint) (*dto.StaffResponse, error) {
+	tc, phone, err := staffIdentity(req.TC, req.Phone)
+	if err != nil {
+		return nil, err
+	}
+
 	// TC ve telefon benzersiz mi?
-	exists, err := u.repo.IsTCOrPhoneExists(req.TC, req.Phone)
+	exists, err := u.repo.IsTCOrPhoneExists(tc, phone)
 	if err != nil {
 		return nil, err
 	}
@@ -158,16 +162,21 @@ func (u *personnelUsecase) AddStaff(req *dto.AddStaffRequest, hospitalID uint) (
 		polyName = &hp.PolyclinicName
 	}
 
+	shifts, err := buildWeeklyShifts(u.polyclinicClient, hospitalID, req.Schedule)
+	if err != nil {
+		return nil, err
+	}
+
 	staff := models.Staff{
 		FirstName:            req.FirstName,
 		LastName:             req.LastName,
-		TC:                   req.TC,
-		Phone:                req.Phone,
+		TC:                   tc,
+		Phone:                phone,
 		JobGroupID:           req.JobGroupID,
 		TitleID:              req.TitleID,
 		HospitalID:           hospitalID,
 		HospitalPolyclinicID: req.HospitalPolyclinicID,
-		WorkingDays:          req.WorkingDays,
+		Shifts:               shifts,
 	}
 
 	if err := u.repo.CreateStaff(&staff); err != nil {
@@ -186,10 +195,13 @@ func (u *personnelUsecase) AddStaff(req *dto.AddStaffRequest, hospitalID uint) (
 		TitleName:            title.Name,
 		HospitalPolyclinicID: staff.HospitalPolyclinicID,
 		PolyclinicName:       polyName,
-		WorkingDays:          staff.WorkingDays,
+		Schedule:             toWeeklyShifts(staff.Shifts),
 	}, nil
 }
 
+// ErrAssignmentChangeNeedsTransfer personel düzenlemesiyle poliklinik, meslek grubu ya da unvan değiştirilmek istendiğinde döner
+var ErrAssignmentChangeNeedsTransfer = errors.New("polyclinic, job group and title can only be changed with a transfer (POST /api/personnel/staff/{id}/transfers)")
+
 func (u *personnelUsecase) UpdateStaff(id uint, req *dto.UpdateStaffRequest, hospitalID uint) (*dto.StaffResponse, error) {
 	staff, err := u.repo.GetStaffByID(id)
 	if err != nil {
@@ -199,8 +211,13 @@ func (u *personnelUsecase) UpdateStaff(id uint, req *dto.UpdateStaffRequest, hos
 		return nil, errors.New("forbidden: cannot update staff from another hospital")
 	}
 
+	tc, phone, err := staffIdentity(req.TC, req.Phone)
+	if err != nil {
+		return nil, err
+	}
+
 	// TC ve telefon benzersiz mi? (kendisi hariç)
-	exists, err := u.repo.IsTCOrPhoneExistsExcludeID(id, req.TC, req.Phone)
+	exists, err := u.repo.IsTCOrPhoneExistsExcludeID(id, tc, phone)
 	if err != nil {
 		return nil, err
 	}
@@ -208,50 +225,43 @@ func (u *personnelUsecase) UpdateStaff(id uint, req *dto.UpdateStaffRequest, hos
 		return nil, errors.New("another staff with given TC or phone already exists")
 	}
 
-	// Meslek grubu ve unvan var mı, ilişkili mi?
-	jobGroup, err := u.repo.GetJobGroupByID(req.JobGroupID)
+	// Atama değişiklikleri geçmişe tarih, gerekçe ve onaylayanla yazılabilsin diye yalnızca transferle yapılır
+	if req.JobGroupID != staff.JobGroupID || req.TitleID != staff.TitleID ||
+		!sameUintPtr(req.HospitalPolyclinicID, staff.HospitalPolyclinicID) {
+		return nil, ErrAssignmentChangeNeedsTransfer
+	}
+
+	jobGroup, err := u.repo.GetJobGroupByID(staff.JobGroupID)
 	if err != nil {
 		return nil, errors.New("job group not found")
 	}
-
-	title, err := u.repo.GetTitleByID(req.TitleID)
+	title, err := u.repo.GetTitleByID(staff.TitleID)
 	if err != nil {
 		return nil, errors.New("title not found")
 	}
-	if title.JobGroupID != jobGroup.ID {
-		return nil, errors.New("title does not belong to the selected job group")
-	}
 
-	if title.Name == "Başhekim" {
-		count, err := u.repo.CountHospitalHeads(hospitalID)
-		if err != nil {
-			return nil, err
-		}
-		if count > 0 {
-			return nil, errors.New("there can be only one Başhekim in a hospital")
+	var polyName *string
+	if staff.HospitalPolyclinicID != nil {
+		if name, ok := polyclinicNamesByID(u.polyclinicClient, hospitalID, []*uint{staff.HospitalPolyclinicID})[*staff.HospitalPolyclinicID]; ok {
+			polyName = &name
 		}
 	}
 
-	var polyName *string
-	if req.HospitalPolyclinicID != nil {
-		hp, err := u.polyclinicClient.GetHospitalPolyclinicByID(*req.HospitalPolyclinicID)
-		if err != nil {
-			return nil, errors.New("hospital polyclinic not found")
-		}
-		if hp.HospitalID != hospitalID {
-			return nil, errors.New("polyclinic does not belong to your hospital")
-		}
-		polyName = &hp.PolyclinicName
+	// Schedule gönderilmezse haftalık vardiyalar olduğu gibi kalır
+	shifts, err := buildWeeklyShifts(u.polyclinicClient, hospitalID, req.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	schedule := staff.Shifts
+	if shifts != nil {
+		schedule = shifts
 	}
 
 	staff.FirstName = req.FirstName
 	staff.LastName = req.LastName
-	staff.TC = req.TC
-	staff.Phone = req.Phone
-	staff.JobGroupID = req.JobGroupID
-	staff.TitleID = req.TitleID
-	staff.HospitalPolyclinicID = req.HospitalPolyclinicID
-	staff.WorkingDays = req.WorkingDays
+	staff.TC = tc
+	staff.Phone = phone
+	staff.Shifts = shifts
 
 	if err := u.repo.UpdateStaff(staff); err != nil {
 		return nil, err
@@ -269,7 +279,7 @@ func (u *personnelUsecase) UpdateStaff(id uint, req *dto.UpdateStaffRequest, hos
 		TitleName:            title.Name,
 		HospitalPolyclinicID: staff.HospitalPolyclinicID,
 		PolyclinicName:       polyName,
-		WorkingDays:          staff.WorkingDays,
+		Schedule:             toWeeklyShifts(schedule),
 	}, nil
 }
 
@@ -284,71 +294,327 @@ func (u *personnelUsecase) DeleteStaff(id, hospitalID uint) error {
 	return u.repo.DeleteStaff(staff)
 }
 
-func (u *personnelUsecase) ListStaff(hospitalID uint, filter dto.StaffListFilter, page, size int) (*dto.StaffListResponse, error) {
-	// 1. Personelleri filtreyle listele
-	staffs, err := u.repo.ListStaffWithFilter(hospitalID, filter, page, size)
+// Liste sorgusunun doğrulama hataları; handler bunları 400 olarak döner
+var (
+	ErrInvalidStaffSort   = errors.New("sort must be one of name, title, created_at, polyclinic and order asc or desc")
+	ErrInvalidStaffCursor = repository.ErrInvalidStaffCursor
+)
+
+const (
+	defaultStaffPageSize = 10
+	maxStaffPageSize     = 100
+)
+
+// staffCursor istemciye opak olarak verilen imleçtir; sıralama değiştirilirse geçersiz sayılır
+type staffCursor struct {
+	Sort   string   `json:"s"`
+	Order  string   `json:"o"`
+	Values []string `json:"v"`
+}
+
+func encodeStaffCursor(c staffCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeStaffCursor(s string) (*staffCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
 	if err != nil {
-		return nil, err
+		return nil, ErrInvalidStaffCursor
 	}
+	var c staffCursor
+	if err := json.Unmarshal(b, &c); err != nil || len(c.Values) == 0 {
+		return nil, ErrInvalidStaffCursor
+	}
+	return &c, nil
+}
 
-	// 2. Toplam kayıt sayısını al
-	totalCount, err := u.repo.CountStaffWithFilter(hospitalID, filter)
-	if err != nil {
+func (u *personnelUsecase) ListStaff(hospitalID uint, role string, filter dto.StaffListFilter, page dto.StaffListPage) (*dto.StaffListResponse, error) {
+	if err := checkTCFilter(role, filter); err != nil {
 		return nil, err
 	}
+	// 1. Sıralama ve sayfa doğrulanır; varsayılan soyada göre artan sıradır
+	if page.Sort == "" {
+		page.Sort = dto.StaffSortName
+	}
+	if page.Order == "" {
+		page.Order = "asc"
+	}
+	if !repository.IsStaffSortField(page.Sort) || (page.Order != "asc" && page.Order != "desc") {
+		return nil, ErrInvalidStaffSort
+	}
+	if page.Size <= 0 {
+		page.Size = defaultStaffPageSize
+	}
+	if page.Size > maxStaffPageSize {
+		page.Size = maxStaffPageSize
+	}
+	if page.Page <= 0 {
+		page.Page = 1
+	}
 
-	// 3. Dönüştürülecek response dizisi hazırlanıyor
-	resp := make([]dto.StaffResponse, 0, len(staffs))
-	for _, s := range staffs {
-		// Her staff için jobGroup ve title bilgilerini getir
-		jobGroup, err := u.repo.GetJobGroupByID(s.JobGroupID)
+	// Bir fazlası okunarak sonraki sayfanın olup olmadığı anlaşılır
+	q := repository.StaffListQuery{
+		Sort:  page.Sort,
+		Desc:  page.Order == "desc",
+		Limit: page.Size + 1,
+	}
+	if page.Cursor != "" {
+		cursor, err := decodeStaffCursor(page.Cursor)
 		if err != nil {
-			return nil, errors.New("job group not found for staff")
+			return nil, err
 		}
-
-		title, err := u.repo.GetTitleByID(s.TitleID)
+		if cursor.Sort != page.Sort || cursor.Order != page.Order {
+			return nil, ErrInvalidStaffCursor
+		}
+		q.After = cursor.Values
+		// İmleçle gezinmede sayfa numarası anlamsızdır
+		page.Page = 0
+	} else {
+		q.Offset = (page.Page - 1) * page.Size
+	}
+	// Poliklinik adları hospital servisinde tutulduğundan ada göre sıralamada sorgudan önce alınır
+	if q.Sort == dto.StaffSortPolyclinic {
+		hps, err := u.polyclinicClient.ListHospitalPolyclinics(hospitalID)
 		if err != nil {
-			return nil, errors.New("title not found for staff")
+			return nil, errors.New("polyclinic names could not be loaded, try again later")
+		}
+		q.PolyclinicNames = make(map[uint]string, len(hps))
+		for _, hp := range hps {
+			q.PolyclinicNames[hp.ID] = hp.PolyclinicName
 		}
+	}
 
-		// Eğer poliklinik ID varsa, poliklinik adını al
+	// 2. Personelleri filtreyle listele
+	staffs, err := u.repo.ListStaffWithFilter(hospitalID, filter, q)
+	if err != nil {
+		return nil, err
+	}
+	var nextCursor string
+	if len(staffs) > page.Size {
+		staffs = staffs[:page.Size]
+		nextCursor = encodeStaffCursor(staffCursor{
+			Sort:   page.Sort,
+			Order:  page.Order,
+			Values: repository.StaffSortValues(&staffs[len(staffs)-1], q),
+		})
+	}
+
+	// 3. Toplam kayıt sayısını al
+	totalCount, err := u.repo.CountStaffWithFilter(hospitalID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Poliklinik adları tek istekte çözülür; hospital servisi cevap vermezse liste adsız döner
+	polyNames := q.PolyclinicNames
+	if polyNames == nil {
+		polyNames = u.polyclinicNames(hospitalID, staffs)
+	}
+
+	// 5. Dönüştürülecek response dizisi hazırlanıyor
+	resp := make([]dto.StaffResponse, 0, len(staffs))
+	for _, s := range staffs {
 		var polyName *string
 		if s.HospitalPolyclinicID != nil {
-			hp, err := u.polyclinicClient.GetHospitalPolyclinicByID(*s.HospitalPolyclinicID)
-			if err != nil {
-				return nil, err
+			if name, ok := polyNames[*s.HospitalPolyclinicID]; ok {
+				polyName = &name
 			}
-			polyName = &hp.PolyclinicName
 		}
 
 		resp = append(resp, dto.StaffResponse{
 			ID:                   s.ID,
 			FirstName:            s.FirstName,
 			LastName:             s.LastName,
-			TC:                   s.TC,
+			TC:                   visibleTC(role, s.TC),
 			Phone:                s.Phone,
-			JobGroupID:           jobGroup.ID,
-			JobGroupName:         jobGroup.Name,
-			TitleID:              title.ID,
-			TitleName:            title.Name,
+			JobGroupID:           s.JobGroupID,
+			JobGroupName:         s.JobGroup.Name,
+			TitleID:              s.TitleID,
+			TitleName:            s.Title.Name,
 			HospitalPolyclinicID: s.HospitalPolyclinicID,
 			PolyclinicName:       polyName,
-			WorkingDays:          s.WorkingDays,
+			Schedule:             toWeeklyShifts(s.Shifts),
 		})
 	}
 
 	return &dto.StaffListResponse{
-		Staff: resp,
-		Total: int(totalCount),
-		Page:  page,
-		Size:  size,
+		Staff:      resp,
+		Total:      int(totalCount),
+		Page:       page.Page,
+		Size:       page.Size,
+		NextCursor: nextCursor,
 	}, nil
 }
 
-func (u *personnelUsecase) CountPersonnelByHpID(hpID uint) (int64, error) {
-	return u.repo.CountPersonnel(hpID)
+func (u *personnelUsecase) polyclinicNames(hospitalID uint, staffs []models.Staff) map[uint]string {
+	hpIDs := make([]*uint, len(staffs))
+	for i := range staffs {
+		hpIDs[i] = staffs[i].HospitalPolyclinicID
+	}
+	return polyclinicNamesByID(u.polyclinicClient, hospitalID, hpIDs)
+}
+
+// polyclinicNamesByID poliklinik adlarını tek istekte çözer; hospital servisi cevap vermezse
+// bulunamayan adlar boş kalır
+func polyclinicNamesByID(pc client.PolyclinicClient, hospitalID uint, hpIDs []*uint) map[uint]string {
+	seen := make(map[uint]bool)
+	var ids []uint
+	for _, id := range hpIDs {
+		if id != nil && !seen[*id] {
+			seen[*id] = true
+			ids = append(ids, *id)
+		}
+	}
+
+	names := make(map[uint]string, len(ids))
+	hps, err := pc.GetHospitalPolyclinicsByIDs(hospitalID, ids)
+	if err != nil {
+		log.Printf("polyclinic names could not be resolved: %v", err)
+	}
+	for id, hp := range hps {
+		names[id] = hp.PolyclinicName
+	}
+	return names
+}
+
+const (
+	minStaffSearchLength   = 2
+	defaultStaffSearchSize = 20
+	maxStaffSearchSize     = 50
+)
+
+var ErrStaffSearchTooShort = errors.New("search query must contain at least 2 letters or digits")
+
+// SearchStaff sorguyu Türkçe karakterlerden arındırıp kelimelere böler; "sukru yil" hem
+// "Şükrü Yılmaz"ı hem "Yıldız Şükrü"yü bulur
+func (u *personnelUsecase) SearchStaff(hospitalID uint, role string, query string, limit int) (*dto.StaffSearchResponse, error) {
+	folded := utils.FoldTurkish(query)
+	if len(strings.ReplaceAll(folded, " ", "")) < minStaffSearchLength {
+		return nil, ErrStaffSearchTooShort
+	}
+	if limit <= 0 {
+		limit = defaultStaffSearchSize
+	}
+	if limit > maxStaffSearchSize {
+		limit = maxStaffSearchSize
+	}
+
+	// TC'yi maskeli gören roller TC ön ekiyle arama yapamaz
+	hits, err := u.repo.SearchStaff(hospitalID, strings.Fields(folded), limit, canSeeFullTC(role))
+	if err != nil {
+		return nil, err
+	}
+
+	hpIDs := make([]*uint, len(hits))
+	for i := range hits {
+		hpIDs[i] = hits[i].HospitalPolyclinicID
+	}
+	polyNames := polyclinicNamesByID(u.polyclinicClient, hospitalID, hpIDs)
+
+	results := make([]dto.StaffSearchHit, 0, len(hits))
+	for _, h := range hits {
+		var polyName *string
+		if h.HospitalPolyclinicID != nil {
+			if name, ok := polyNames[*h.HospitalPolyclinicID]; ok {
+				polyName = &name
+			}
+		}
+		results = append(results, dto.StaffSearchHit{
+			ID:                   h.ID,
+			FirstName:            h.FirstName,
+			LastName:             h.LastName,
+			TC:                   visibleTC(role, h.TC),
+			Phone:                h.Phone,
+			JobGroupID:           h.JobGroupID,
+			JobGroupName:         h.JobGroupName,
+			TitleID:              h.TitleID,
+			TitleName:            h.TitleName,
+			HospitalPolyclinicID: h.HospitalPolyclinicID,
+			PolyclinicName:       polyName,
+			Score:                h.Score,
+		})
+	}
+	return &dto.StaffSearchResponse{Query: folded, Results: results}, nil
+}
+
+func (u *personnelUsecase) CountPersonnelByHpID(hpID uint, includeOnLeave bool) (int64, error) {
+	return u.repo.CountPersonnel(hpID, includeOnLeave)
 }
 
 func (u *personnelUsecase) GetGroupCountsByHpID(hpID uint) ([]dt.PolyclinicPersonnelGroup, error) {
 	return u.repo.GetGroupCountsByHospitalPolyclinicID(hpID)
 }
+
+func (u *personnelUsecase) GetPersonnelStatsBatch(hpIDs []uint) ([]dt.PolyclinicPersonnelStats, error) {
+	ids := make([]uint, 0, len(hpIDs))
+	seen := make(map[uint]bool, len(hpIDs))
+	for _, id := range hpIDs {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if len(ids) > maxStatsBatchSize {
+		return nil, errors.New("too many hospital polyclinic ids")
+	}
+
+	stats, err := u.repo.GetPersonnelStatsByHospitalPolyclinicIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// İstekteki sırayı korur
+	resp := make([]dt.PolyclinicPersonnelStats, 0, len(ids))
+	for _, id := range ids {
+		resp = append(resp, *stats[id])
+	}
+	return resp, nil
+}
+
+func (u *personnelUsecase) ReassignStaff(req *dt.ReassignStaffRequest) (*dt.ReassignStaffResponse, error) {
+	if req.HospitalID == 0 {
+		return nil, errors.New("hospital_id is required")
+	}
+	if req.FromHospitalPolyclinicID == nil && len(req.StaffIDs) == 0 {
+		return nil, errors.New("from_hospital_polyclinic_id or staff_ids is required")
+	}
+	if req.FromHospitalPolyclinicID != nil && req.ToHospitalPolyclinicID != nil &&
+		*req.FromHospitalPolyclinicID == *req.ToHospitalPolyclinicID {
+		return nil, errors.New("source and target polyclinic must be different")
+	}
+
+	ids, err := u.repo.ReassignStaff(req.HospitalID, req.FromHospitalPolyclinicID, req.ToHospitalPolyclinicID, req.StaffIDs)
+	if err != nil {
+		return nil, err
+	}
+	if ids == nil {
+		ids = []uint{}
+	}
+	return &dt.ReassignStaffResponse{StaffIDs: ids}, nil
+}
+
+func (u *personnelUsecase) ListStaffRefs(afterID uint, limit int) (*dt.StaffRefPage, error) {
+	if limit < 1 || limit > dt.MaxRefPageSize {
+		limit = dt.MaxRefPageSize
+	}
+
+	staffs, err := u.repo.ListStaffAfter(afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &dt.StaffRefPage{Items: make([]dt.StaffRef, 0, len(staffs))}
+	for _, s := range staffs {
+		page.Items = append(page.Items, dt.StaffRef{
+			ID:                   s.ID,
+			HospitalID:           s.HospitalID,
+			HospitalPolyclinicID: s.HospitalPolyclinicID,
+		})
+	}
+	if len(staffs) == limit {
+		page.NextAfterID = staffs[len(staffs)-1].ID
+	}
+	return page, nil
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+
+	"personnel-service/internal/dto"
+)
+
+func TestStaffCursorRoundTrip(t *testing.T) {
+	want := staffCursor{Sort: dto.StaffSortName, Order: "desc", Values: []string{"Yılmaz", "Şükrü", "42"}}
+	got, err := decodeStaffCursor(encodeStaffCursor(want))
+	if err != nil {
+		t.Fatalf("decodeStaffCursor returned error: %v", err)
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("decoded cursor = %+v, want %+v", *got, want)
+	}
+}
+
+func TestDecodeStaffCursorRejectsInvalid(t *testing.T) {
+	for _, s := range []string{
+		"not base64!",
+		base64.RawURLEncoding.EncodeToString([]byte("not json")),
+		encodeStaffCursor(staffCursor{Sort: dto.StaffSortName, Order: "asc"}),
+	} {
+		if _, err := decodeStaffCursor(s); !errors.Is(err, ErrInvalidStaffCursor) {
+			t.Errorf("decodeStaffCursor(%q) error = %v, want ErrInvalidStaffCursor", s, err)
+		}
+	}
+}
@@ -0,0 +1,354 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"personnel-service/internal/dto"
+	"personnel-service/internal/models"
+	"personnel-service/internal/repository"
+)
+
+// rosterPlan bir nöbet listesinin kurallarını ve o ana kadarki atamalarını tutar.
+// Otomatik üretim ve elle düzenlenen taslağın doğrulanması aynı kuralları kullanır.
+type rosterPlan struct {
+	roster      *models.Roster
+	dutyStart   time.Duration
+	dutyLength  time.Duration
+	minRest     time.Duration
+	holidays    map[string]bool
+	staff       map[uint]*models.Staff
+	unavailable map[uint]map[string]bool
+	duties      map[uint][]plannedDuty
+	load        map[uint]*dutyLoad
+	days        map[string][]uint
+}
+
+type plannedDuty struct {
+	date  time.Time
+	start time.Time
+	end   time.Time
+	fixed bool // başka bir yayınlanmış listeden gelir
+}
+
+type dutyLoad struct {
+	total   int
+	heavy   int
+	lastEnd time.Time
+}
+
+// dutyRange nöbet saatlerini çözer. Vardiyalardan farklı olarak başlangıçla aynı bitiş
+// (08:00-08:00) 24 saatlik nöbettir; bitiş başlangıçtan önce ya da ona eşitse ertesi gündedir.
+func dutyRange(dutyStart, dutyEnd string) (minuteRange, error) {
+	start, err := parseClock(dutyStart, "duty_start")
+	if err != nil {
+		return minuteRange{}, err
+	}
+	end, err := parseClock(dutyEnd, "duty_end")
+	if err != nil {
+		return minuteRange{}, err
+	}
+	if end <= start {
+		end += minutesPerDay
+	}
+	return minuteRange{start: start, end: end}, nil
+}
+
+func newRosterPlan(roster *models.Roster, staffs []models.Staff, published []repository.PublishedDuty) (*rosterPlan, error) {
+	window, err := dutyRange(roster.DutyStart, roster.DutyEnd)
+	if err != nil {
+		return nil, err
+	}
+	p := &rosterPlan{
+		roster:      roster,
+		dutyStart:   time.Duration(window.start) * time.Minute,
+		dutyLength:  time.Duration(window.end-window.start) * time.Minute,
+		minRest:     time.Duration(roster.MinRestHours) * time.Hour,
+		holidays:    make(map[string]bool, len(roster.Holidays)),
+		staff:       make(map[uint]*models.Staff, len(staffs)),
+		unavailable: make(map[uint]map[string]bool),
+		duties:      make(map[uint][]plannedDuty),
+		load:        make(map[uint]*dutyLoad),
+		days:        make(map[string][]uint),
+	}
+	for _, h := range roster.Holidays {
+		p.holidays[h.Date.Format(dateLayout)] = true
+	}
+	for i := range staffs {
+		s := &staffs[i]
+		p.staff[s.ID] = s
+		p.unavailable[s.ID] = make(map[string]bool)
+		for _, o := range s.Overrides {
+			if o.Off {
+				p.unavailable[s.ID][o.Date.Format(dateLayout)] = true
+			}
+		}
+		for _, d := range p.dates() {
+			if onLeave(s, d) {
+				p.unavailable[s.ID][d.Format(dateLayout)] = true
+			}
+		}
+	}
+	for _, d := range published {
+		r, err := dutyRange(d.DutyStart, d.DutyEnd)
+		if err != nil {
+			continue
+		}
+		start := d.Date.Add(time.Duration(r.start) * time.Minute)
+		p.duties[d.StaffID] = append(p.duties[d.StaffID], plannedDuty{
+			date:  d.Date,
+			start: start,
+			end:   start.Add(time.Duration(r.end-r.start) * time.Minute),
+			fixed: true,
+		})
+	}
+	return p, nil
+}
+
+func (p *rosterPlan) dates() []time.Time {
+	var dates []time.Time
+	for d := p.roster.StartDate; !d.After(p.roster.EndDate); d = d.AddDate(0, 0, 1) {
+		dates = append(dates, d)
+	}
+	return dates
+}
+
+func (p *rosterPlan) isWeekend(date time.Time) bool {
+	return date.Weekday() == time.Saturday || date.Weekday() == time.Sunday
+}
+
+// Hafta sonu ve tatil nöbetleri adalet hesabında ayrıca sayılır
+func (p *rosterPlan) isHeavy(date time.Time) bool {
+	return p.isWeekend(date) || p.holidays[date.Format(dateLayout)]
+}
+
+func (p *rosterPlan) duty(date time.Time) plannedDuty {
+	start := date.Add(p.dutyStart)
+	return plannedDuty{date: date, start: start, end: start.Add(p.dutyLength)}
+}
+
+func (p *rosterPlan) loadOf(staffID uint) *dutyLoad {
+	l, ok := p.load[staffID]
+	if !ok {
+		l = &dutyLoad{}
+		p.load[staffID] = l
+	}
+	return l
+}
+
+func (p *rosterPlan) assign(staffID uint, date time.Time) {
+	d := p.duty(date)
+	p.duties[staffID] = append(p.duties[staffID], d)
+	key := date.Format(dateLayout)
+	p.days[key] = append(p.days[key], staffID)
+
+	l := p.loadOf(staffID)
+	l.total++
+	if p.isHeavy(date) {
+		l.heavy++
+	}
+	if d.end.After(l.lastEnd) {
+		l.lastEnd = d.end
+	}
+}
+
+// restConflict iki nöbet arasında en az dinlenme süresi yoksa ya da nöbetler çakışıyorsa true döner
+func (p *rosterPlan) restConflict(a, b plannedDuty) bool {
+	if a.start.After(b.start) {
+		a, b = b, a
+	}
+	return b.start.Sub(a.end) < p.minRest
+}
+
+func (p *rosterPlan) canTake(staffID uint, date time.Time) bool {
+	if p.unavailable[staffID][date.Format(dateLayout)] {
+		return false
+	}
+	if p.roster.MaxShiftsPerStaff > 0 && p.loadOf(staffID).total >= p.roster.MaxShiftsPerStaff {
+		return false
+	}
+	d := p.duty(date)
+	for _, existing := range p.duties[staffID] {
+		if p.restConflict(existing, d) {
+			return false
+		}
+	}
+	return true
+}
+
+func requirementMatches(req models.RosterRequirement, s *models.Staff) bool {
+	if req.TitleID != nil && s.TitleID != *req.TitleID {
+		return false
+	}
+	if req.JobGroupID != nil && s.JobGroupID != *req.JobGroupID {
+		return false
+	}
+	return true
+}
+
+// Unvan şartı daha dar olduğu için önce karşılanır; unvanla seçilen personel meslek grubu şartına da sayılır
+func (p *rosterPlan) orderedRequirements() []models.RosterRequirement {
+	reqs := append([]models.RosterRequirement(nil), p.roster.Requirements...)
+	sort.SliceStable(reqs, func(i, j int) bool {
+		return reqs[i].TitleID != nil && reqs[j].TitleID == nil
+	})
+	return reqs
+}
+
+func (p *rosterPlan) countMatching(req models.RosterRequirement, staffIDs []uint) int {
+	n := 0
+	for _, id := range staffIDs {
+		if s, ok := p.staff[id]; ok && requirementMatches(req, s) {
+			n++
+		}
+	}
+	return n
+}
+
+// generate her gün için şartları en az yüklü uygun personelle doldurur.
+// Hafta sonu ve tatillerde önce bu günlerde en az nöbet tutan seçilir; eşitlikte toplam yük,
+// sonra son nöbetin üzerinden geçen süre belirler.
+func (p *rosterPlan) generate() {
+	ids := make([]uint, 0, len(p.staff))
+	for id := range p.staff {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, date := range p.dates() {
+		heavy := p.isHeavy(date)
+		key := date.Format(dateLayout)
+		for _, req := range p.orderedRequirements() {
+			for p.countMatching(req, p.days[key]) < req.MinStaff {
+				best := uint(0)
+				for _, id := range ids {
+					if slices.Contains(p.days[key], id) || !requirementMatches(req, p.staff[id]) || !p.canTake(id, date) {
+						continue
+					}
+					if best == 0 || p.lessLoaded(id, best, heavy) {
+						best = id
+					}
+				}
+				if best == 0 {
+					break
+				}
+				p.assign(best, date)
+			}
+		}
+	}
+}
+
+func (p *rosterPlan) lessLoaded(a, b uint, heavy bool) bool {
+	la, lb := p.loadOf(a), p.loadOf(b)
+	if heavy && la.heavy != lb.heavy {
+		return la.heavy < lb.heavy
+	}
+	if la.total != lb.total {
+		return la.total < lb.total
+	}
+	if !heavy && la.heavy != lb.heavy {
+		return la.heavy < lb.heavy
+	}
+	if !la.lastEnd.Equal(lb.lastEnd) {
+		return la.lastEnd.Before(lb.lastEnd)
+	}
+	return a < b
+}
+
+// issues taslaktaki kural ihlallerini listeler; otomatik üretimde yalnızca karşılanamayan şartlar çıkar
+func (p *rosterPlan) issues() []dto.RosterIssue {
+	issues := make([]dto.RosterIssue, 0)
+
+	for _, date := range p.dates() {
+		key := date.Format(dateLayout)
+		for _, req := range p.roster.Requirements {
+			if have := p.countMatching(req, p.days[key]); have < req.MinStaff {
+				issues = append(issues, dto.RosterIssue{
+					Type:       dto.RosterIssueUnderstaffed,
+					Date:       key,
+					JobGroupID: req.JobGroupID,
+					TitleID:    req.TitleID,
+					Missing:    req.MinStaff - have,
+					Message:    fmt.Sprintf("%d more staff needed for requirement", req.MinStaff-have),
+				})
+			}
+		}
+		for _, id := range p.days[key] {
+			if _, ok := p.staff[id]; !ok {
+				issues = append(issues, dto.RosterIssue{
+					Type:    dto.RosterIssueNotEligible,
+					Date:    key,
+					StaffID: id,
+					Message: "staff is no longer eligible for this roster",
+				})
+				continue
+			}
+			if p.unavailable[id][key] {
+				issues = append(issues, dto.RosterIssue{
+					Type:    dto.RosterIssueUnavailable,
+					Date:    key,
+					StaffID: id,
+					Message: "staff is off or on leave on this date",
+				})
+			}
+		}
+	}
+
+	staffIDs := make([]uint, 0, len(p.load))
+	for id := range p.load {
+		staffIDs = append(staffIDs, id)
+	}
+	sort.Slice(staffIDs, func(i, j int) bool { return staffIDs[i] < staffIDs[j] })
+
+	for _, id := range staffIDs {
+		if max := p.roster.MaxShiftsPerStaff; max > 0 && p.load[id].total > max {
+			issues = append(issues, dto.RosterIssue{
+				Type:    dto.RosterIssueMaxShifts,
+				StaffID: id,
+				Message: fmt.Sprintf("staff has %d shifts, maximum is %d", p.load[id].total, max),
+			})
+		}
+
+		duties := append([]plannedDuty(nil), p.duties[id]...)
+		sort.Slice(duties, func(i, j int) bool { return duties[i].start.Before(duties[j].start) })
+		for i := 1; i < len(duties); i++ {
+			prev, cur := duties[i-1], duties[i]
+			if prev.fixed && cur.fixed {
+				continue
+			}
+			if p.restConflict(prev, cur) {
+				issues = append(issues, dto.RosterIssue{
+					Type:    dto.RosterIssueRest,
+					Date:    cur.date.Format(dateLayout),
+					StaffID: id,
+					Message: fmt.Sprintf("less than %d hours of rest after the duty on %s", p.roster.MinRestHours, prev.date.Format(dateLayout)),
+				})
+			}
+		}
+	}
+	return issues
+}
+
+func (p *rosterPlan) summary() []dto.RosterStaffLoad {
+	resp := make([]dto.RosterStaffLoad, 0, len(p.load))
+	for id, l := range p.load {
+		if l.total == 0 {
+			continue
+		}
+		item := dto.RosterStaffLoad{StaffID: id, Total: l.total, WeekendHoliday: l.heavy}
+		if s, ok := p.staff[id]; ok {
+			item.FirstName = s.FirstName
+			item.LastName = s.LastName
+		}
+		resp = append(resp, item)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		if resp[i].Total != resp[j].Total {
+			return resp[i].Total > resp[j].Total
+		}
+		return resp[i].StaffID < resp[j].StaffID
+	})
+	return resp
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"personnel-service/internal/models"
+	"personnel-service/internal/repository"
+)
+
+func TestDutyRange(t *testing.T) {
+	tests := []struct {
+		start, end string
+		want       time.Duration
+	}{
+		{"08:00", "08:00", 24 * time.Hour},
+		{"08:00", "17:00", 9 * time.Hour},
+		{"20:00", "08:00", 12 * time.Hour},
+	}
+	for _, tt := range tests {
+		r, err := dutyRange(tt.start, tt.end)
+		if err != nil {
+			t.Fatalf("dutyRange(%s, %s) returned error: %v", tt.start, tt.end, err)
+		}
+		if got := time.Duration(r.end-r.start) * time.Minute; got != tt.want {
+			t.Errorf("dutyRange(%s, %s) length = %v, want %v", tt.start, tt.end, got, tt.want)
+		}
+	}
+
+	if _, err := dutyRange("8", "08:00"); err == nil {
+		t.Error("dutyRange accepted an invalid duty_start")
+	}
+}
+
+func TestRosterPlanFullDayDuty(t *testing.T) {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	roster := &models.Roster{
+		StartDate:    day,
+		EndDate:      day.AddDate(0, 0, 1),
+		DutyStart:    "08:00",
+		DutyEnd:      "08:00",
+		MinRestHours: 24,
+	}
+	published := []repository.PublishedDuty{
+		{StaffID: 2, Date: day.AddDate(0, 0, -1), DutyStart: "08:00", DutyEnd: "08:00"},
+	}
+	staffs := make([]models.Staff, 2)
+	staffs[0].ID, staffs[1].ID = 1, 2
+	plan, err := newRosterPlan(roster, staffs, published)
+	if err != nil {
+		t.Fatalf("newRosterPlan returned error: %v", err)
+	}
+
+	d := plan.duty(day)
+	if want := day.Add(8 * time.Hour); !d.start.Equal(want) {
+		t.Errorf("duty start = %v, want %v", d.start, want)
+	}
+	if want := day.AddDate(0, 0, 1).Add(8 * time.Hour); !d.end.Equal(want) {
+		t.Errorf("duty end = %v, want %v", d.end, want)
+	}
+
+	// Önceki listeden gelen 24 saatlik nöbet de tam süresiyle sayılır
+	if len(plan.duties[2]) != 1 || !plan.duties[2][0].end.Equal(day.Add(8*time.Hour)) {
+		t.Fatalf("published duty = %+v, want one duty ending at %v", plan.duties[2], day.Add(8*time.Hour))
+	}
+	if plan.canTake(2, day) {
+		t.Error("staff coming off a full-day duty was allowed to take the next day without rest")
+	}
+
+	plan.assign(1, day)
+	if plan.canTake(1, day.AddDate(0, 0, 1)) {
+		t.Error("back-to-back full-day duties were allowed")
+	}
+}
@@ -0,0 +1,472 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"personnel-service/internal/dto"
+	"personnel-service/internal/infrastructure/client"
+	"personnel-service/internal/models"
+	"personnel-service/internal/repository"
+)
+
+const (
+	// Tek seferde üretilebilecek en uzun nöbet listesi
+	maxRosterDays = 62
+
+	defaultDutyStart         = "08:00"
+	defaultDutyEnd           = "08:00"
+	defaultMinRestHours      = 24
+	defaultMaxShiftsPerStaff = 8
+)
+
+// ErrRosterHasIssues taslakta kural ihlali varken zorlamadan yayınlanmak istendiğinde döner
+var ErrRosterHasIssues = errors.New("roster has unresolved issues")
+
+type RosterUsecase interface {
+	GenerateRoster(hospitalID uint, req *dto.GenerateRosterRequest) (*dto.RosterResponse, error)
+	GetRoster(id, hospitalID uint, includeDrafts bool) (*dto.RosterResponse, error)
+	ListRosters(hospitalID uint, filter dto.RosterListFilter, page, size int) (*dto.RosterListResponse, error)
+	AddAssignment(rosterID, hospitalID uint, req *dto.RosterAssignmentRequest) (*dto.RosterResponse, error)
+	RemoveAssignment(rosterID, assignmentID, hospitalID uint) (*dto.RosterResponse, error)
+	PublishRoster(id, hospitalID uint, force bool) (*dto.RosterResponse, error)
+	DeleteRoster(id, hospitalID uint) error
+}
+
+type rosterUsecase struct {
+	repo             repository.RosterRepository
+	personnelRepo    repository.PersonnelRepository
+	polyclinicClient client.PolyclinicClient
+}
+
+func NewRosterUsecase(repo repository.RosterRepository, personnelRepo repository.PersonnelRepository, pc client.PolyclinicClient) RosterUsecase {
+	return &rosterUsecase{
+		repo:             repo,
+		personnelRepo:    personnelRepo,
+		polyclinicClient: pc,
+	}
+}
+
+func (u *rosterUsecase) GenerateRoster(hospitalID uint, req *dto.GenerateRosterRequest) (*dto.RosterResponse, error) {
+	roster, err := u.buildRoster(hospitalID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := u.repo.ListRosterStaff(repository.RosterStaffQuery{
+		HospitalID:           hospitalID,
+		HospitalPolyclinicID: roster.HospitalPolyclinicID,
+		From:                 roster.StartDate,
+		To:                   roster.EndDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	published, err := u.publishedDutiesAround(roster)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := newRosterPlan(roster, candidates, published)
+	if err != nil {
+		return nil, err
+	}
+	plan.generate()
+	for _, date := range plan.dates() {
+		for _, staffID := range plan.days[date.Format(dateLayout)] {
+			roster.Assignments = append(roster.Assignments, models.RosterAssignment{StaffID: staffID, Date: date})
+		}
+	}
+
+	if err := u.repo.CreateRoster(roster); err != nil {
+		return nil, err
+	}
+	return u.toRosterResponse(roster, plan), nil
+}
+
+// buildRoster istekteki kuralları doğrulayıp varsayılanlarla tamamlar
+func (u *rosterUsecase) buildRoster(hospitalID uint, req *dto.GenerateRosterRequest) (*models.Roster, error) {
+	start, err := time.Parse(dateLayout, req.StartDate)
+	if err != nil {
+		return nil, errors.New("start_date must be in YYYY-MM-DD format")
+	}
+	end, err := time.Parse(dateLayout, req.EndDate)
+	if err != nil {
+		return nil, errors.New("end_date must be in YYYY-MM-DD format")
+	}
+	if end.Before(start) {
+		return nil, errors.New("start_date must not be after end_date")
+	}
+	if end.Sub(start) >= maxRosterDays*24*time.Hour {
+		return nil, fmt.Errorf("roster cannot be longer than %d days", maxRosterDays)
+	}
+
+	roster := &models.Roster{
+		HospitalID:           hospitalID,
+		HospitalPolyclinicID: req.HospitalPolyclinicID,
+		StartDate:            start,
+		EndDate:              end,
+		DutyStart:            req.DutyStart,
+		DutyEnd:              req.DutyEnd,
+		MinRestHours:         defaultMinRestHours,
+		MaxShiftsPerStaff:    req.MaxShiftsPerStaff,
+		Status:               models.RosterStatusDraft,
+	}
+	if roster.DutyStart == "" {
+		roster.DutyStart = defaultDutyStart
+	}
+	if roster.DutyEnd == "" {
+		roster.DutyEnd = defaultDutyEnd
+	}
+	if _, err := dutyRange(roster.DutyStart, roster.DutyEnd); err != nil {
+		return nil, err
+	}
+	if req.MinRestHours != nil {
+		if *req.MinRestHours < 0 {
+			return nil, errors.New("min_rest_hours cannot be negative")
+		}
+		roster.MinRestHours = *req.MinRestHours
+	}
+	if roster.MaxShiftsPerStaff < 0 {
+		return nil, errors.New("max_shifts_per_staff cannot be negative")
+	}
+	if roster.MaxShiftsPerStaff == 0 {
+		roster.MaxShiftsPerStaff = defaultMaxShiftsPerStaff
+	}
+
+	if req.HospitalPolyclinicID != nil {
+		if err := checkShiftPolyclinics(u.polyclinicClient, hospitalID, []*uint{req.HospitalPolyclinicID}); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(req.Requirements) == 0 {
+		return nil, errors.New("at least one requirement is required")
+	}
+	for _, r := range req.Requirements {
+		if r.JobGroupID == nil && r.TitleID == nil {
+			return nil, errors.New("requirement needs job_group_id or title_id")
+		}
+		if r.MinStaff < 1 {
+			return nil, errors.New("requirement min_staff must be at least 1")
+		}
+		if r.JobGroupID != nil {
+			if _, err := u.personnelRepo.GetJobGroupByID(*r.JobGroupID); err != nil {
+				return nil, errors.New("job group not found")
+			}
+		}
+		if r.TitleID != nil {
+			title, err := u.personnelRepo.GetTitleByID(*r.TitleID)
+			if err != nil {
+				return nil, errors.New("title not found")
+			}
+			if r.JobGroupID != nil && title.JobGroupID != *r.JobGroupID {
+				return nil, errors.New("title does not belong to the selected job group")
+			}
+		}
+		roster.Requirements = append(roster.Requirements, models.RosterRequirement{
+			JobGroupID: r.JobGroupID,
+			TitleID:    r.TitleID,
+			MinStaff:   r.MinStaff,
+		})
+	}
+
+	seen := make(map[string]bool)
+	for _, h := range req.Holidays {
+		date, err := time.Parse(dateLayout, h)
+		if err != nil {
+			return nil, errors.New("holidays must be in YYYY-MM-DD format")
+		}
+		if date.Before(start) || date.After(end) || seen[h] {
+			continue
+		}
+		seen[h] = true
+		roster.Holidays = append(roster.Holidays, models.RosterHoliday{Date: date})
+	}
+	return roster, nil
+}
+
+// Listenin hemen öncesi ve sonrasındaki yayınlanmış nöbetler dinlenme kuralı için yüklenir
+func (u *rosterUsecase) publishedDutiesAround(roster *models.Roster) ([]repository.PublishedDuty, error) {
+	margin := roster.MinRestHours/24 + 2
+	return u.repo.ListPublishedDuties(roster.HospitalID, roster.StartDate.AddDate(0, 0, -margin), roster.EndDate.AddDate(0, 0, margin), roster.ID)
+}
+
+func (u *rosterUsecase) ownedRoster(id, hospitalID uint) (*models.Roster, error) {
+	roster, err := u.repo.GetRosterByID(id)
+	if err != nil {
+		return nil, errors.New("roster not found")
+	}
+	if roster.HospitalID != hospitalID {
+		return nil, errors.New("forbidden: roster belongs to another hospital")
+	}
+	return roster, nil
+}
+
+func (u *rosterUsecase) ownedDraft(id, hospitalID uint) (*models.Roster, error) {
+	roster, err := u.ownedRoster(id, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	if roster.Status != models.RosterStatusDraft {
+		return nil, errors.New("only draft rosters can be changed")
+	}
+	return roster, nil
+}
+
+// planFor kayıtlı listenin atamalarını güncel personel ve izin bilgisiyle yeniden değerlendirir
+func (u *rosterUsecase) planFor(roster *models.Roster) (*rosterPlan, error) {
+	staffIDs := make([]uint, 0, len(roster.Assignments))
+	seen := make(map[uint]bool)
+	for _, a := range roster.Assignments {
+		if !seen[a.StaffID] {
+			seen[a.StaffID] = true
+			staffIDs = append(staffIDs, a.StaffID)
+		}
+	}
+
+	// Kapsam dışına çıkan (silinen ya da poliklinik değiştiren) personel not_eligible olarak raporlanır
+	staffs, err := u.repo.ListRosterStaff(repository.RosterStaffQuery{
+		HospitalID:           roster.HospitalID,
+		HospitalPolyclinicID: roster.HospitalPolyclinicID,
+		StaffIDs:             staffIDs,
+		From:                 roster.StartDate,
+		To:                   roster.EndDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	published, err := u.publishedDutiesAround(roster)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := newRosterPlan(roster, staffs, published)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range roster.Assignments {
+		plan.assign(a.StaffID, a.Date)
+	}
+	return plan, nil
+}
+
+func (u *rosterUsecase) reviewResponse(roster *models.Roster) (*dto.RosterResponse, error) {
+	plan, err := u.planFor(roster)
+	if err != nil {
+		return nil, err
+	}
+	return u.toRosterResponse(roster, plan), nil
+}
+
+func (u *rosterUsecase) GetRoster(id, hospitalID uint, includeDrafts bool) (*dto.RosterResponse, error) {
+	roster, err := u.ownedRoster(id, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	if !includeDrafts && roster.Status != models.RosterStatusPublished {
+		return nil, errors.New("roster not found")
+	}
+	return u.reviewResponse(roster)
+}
+
+func (u *rosterUsecase) ListRosters(hospitalID uint, filter dto.RosterListFilter, page, size int) (*dto.RosterListResponse, error) {
+	if filter.Status != "" && filter.Status != models.RosterStatusDraft && filter.Status != models.RosterStatusPublished {
+		return nil, errors.New("status must be draft or published")
+	}
+
+	rosters, total, err := u.repo.ListRosters(hospitalID, filter.Status, page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(rosters))
+	for _, r := range rosters {
+		ids = append(ids, r.ID)
+	}
+	counts, err := u.repo.CountAssignments(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.RosterSummary, 0, len(rosters))
+	for _, r := range rosters {
+		resp = append(resp, dto.RosterSummary{
+			ID:                   r.ID,
+			HospitalPolyclinicID: r.HospitalPolyclinicID,
+			StartDate:            r.StartDate.Format(dateLayout),
+			EndDate:              r.EndDate.Format(dateLayout),
+			Status:               r.Status,
+			PublishedAt:          formatTime(r.PublishedAt),
+			AssignmentCount:      counts[r.ID],
+		})
+	}
+	return &dto.RosterListResponse{
+		Rosters: resp,
+		Total:   int(total),
+		Page:    page,
+		Size:    size,
+	}, nil
+}
+
+// AddAssignment taslağa elle nöbet ekler; kural ihlali engellemez, yanıttaki issues ile raporlanır
+func (u *rosterUsecase) AddAssignment(rosterID, hospitalID uint, req *dto.RosterAssignmentRequest) (*dto.RosterResponse, error) {
+	roster, err := u.ownedDraft(rosterID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	date, err := time.Parse(dateLayout, req.Date)
+	if err != nil {
+		return nil, errors.New("date must be in YYYY-MM-DD format")
+	}
+	if date.Before(roster.StartDate) || date.After(roster.EndDate) {
+		return nil, errors.New("date is outside the roster range")
+	}
+
+	staff, err := u.personnelRepo.GetStaffByID(req.StaffID)
+	if err != nil {
+		return nil, errors.New("staff not found")
+	}
+	if staff.HospitalID != hospitalID {
+		return nil, errors.New("staff does not belong to your hospital")
+	}
+	for _, a := range roster.Assignments {
+		if a.StaffID == staff.ID && a.Date.Equal(date) {
+			return nil, errors.New("staff is already on duty on this date")
+		}
+	}
+
+	assignment := models.RosterAssignment{RosterID: roster.ID, StaffID: staff.ID, Date: date}
+	if err := u.repo.AddAssignment(&assignment); err != nil {
+		return nil, err
+	}
+	roster.Assignments = append(roster.Assignments, assignment)
+	return u.reviewResponse(roster)
+}
+
+func (u *rosterUsecase) RemoveAssignment(rosterID, assignmentID, hospitalID uint) (*dto.RosterResponse, error) {
+	roster, err := u.ownedDraft(rosterID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := u.repo.DeleteAssignment(roster.ID, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if deleted == 0 {
+		return nil, errors.New("assignment not found")
+	}
+
+	remaining := roster.Assignments[:0]
+	for _, a := range roster.Assignments {
+		if a.ID != assignmentID {
+			remaining = append(remaining, a)
+		}
+	}
+	roster.Assignments = remaining
+	return u.reviewResponse(roster)
+}
+
+// PublishRoster taslağı yayınlar; kural ihlali varsa force olmadan yayınlamaz ve ihlalleri döner
+func (u *rosterUsecase) PublishRoster(id, hospitalID uint, force bool) (*dto.RosterResponse, error) {
+	roster, err := u.ownedDraft(id, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := u.reviewResponse(roster)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Issues) > 0 && !force {
+		return resp, ErrRosterHasIssues
+	}
+
+	now := time.Now()
+	updated, err := u.repo.PublishRoster(roster.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if updated == 0 {
+		return nil, errors.New("only draft rosters can be changed")
+	}
+	roster.Status = models.RosterStatusPublished
+	roster.PublishedAt = &now
+	resp.Status = roster.Status
+	resp.PublishedAt = formatTime(roster.PublishedAt)
+	return resp, nil
+}
+
+func (u *rosterUsecase) DeleteRoster(id, hospitalID uint) error {
+	roster, err := u.ownedDraft(id, hospitalID)
+	if err != nil {
+		return err
+	}
+	return u.repo.DeleteRoster(roster)
+}
+
+func (u *rosterUsecase) toRosterResponse(roster *models.Roster, plan *rosterPlan) *dto.RosterResponse {
+	resp := &dto.RosterResponse{
+		ID:                   roster.ID,
+		HospitalPolyclinicID: roster.HospitalPolyclinicID,
+		StartDate:            roster.StartDate.Format(dateLayout),
+		EndDate:              roster.EndDate.Format(dateLayout),
+		DutyStart:            roster.DutyStart,
+		DutyEnd:              roster.DutyEnd,
+		MinRestHours:         roster.MinRestHours,
+		MaxShiftsPerStaff:    roster.MaxShiftsPerStaff,
+		Status:               roster.Status,
+		PublishedAt:          formatTime(roster.PublishedAt),
+		Requirements:         make([]dto.RosterRequirement, 0, len(roster.Requirements)),
+		Holidays:             make([]string, 0, len(roster.Holidays)),
+		Summary:              plan.summary(),
+		Issues:               plan.issues(),
+	}
+	for _, r := range roster.Requirements {
+		resp.Requirements = append(resp.Requirements, dto.RosterRequirement{
+			JobGroupID: r.JobGroupID,
+			TitleID:    r.TitleID,
+			MinStaff:   r.MinStaff,
+		})
+	}
+	for _, h := range roster.Holidays {
+		resp.Holidays = append(resp.Holidays, h.Date.Format(dateLayout))
+	}
+
+	byDate := make(map[string][]models.RosterAssignment)
+	for _, a := range roster.Assignments {
+		key := a.Date.Format(dateLayout)
+		byDate[key] = append(byDate[key], a)
+	}
+	for _, date := range plan.dates() {
+		key := date.Format(dateLayout)
+		day := dto.RosterDay{
+			Date:        key,
+			Weekend:     plan.isWeekend(date),
+			Holiday:     plan.holidays[key],
+			Assignments: make([]dto.RosterAssignmentResponse, 0, len(byDate[key])),
+		}
+		for _, a := range byDate[key] {
+			item := dto.RosterAssignmentResponse{ID: a.ID, StaffID: a.StaffID}
+			if s, ok := plan.staff[a.StaffID]; ok {
+				item.FirstName = s.FirstName
+				item.LastName = s.LastName
+				item.JobGroupID = s.JobGroupID
+				item.TitleID = s.TitleID
+			}
+			day.Assignments = append(day.Assignments, item)
+		}
+		resp.Days = append(resp.Days, day)
+	}
+	return resp
+}
+
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(time.RFC3339)
+	return &s
+}
@@ -0,0 +1,476 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"personnel-service/internal/dto"
+	"personnel-service/internal/infrastructure/client"
+	"personnel-service/internal/models"
+	"personnel-service/internal/repository"
+)
+
+const (
+	clockLayout   = "15:04"
+	dateLayout    = "2006-01-02"
+	minutesPerDay = 24 * 60
+
+	// Vardiya takvimi sorgusunda izin verilen en uzun aralık
+	maxScheduleRangeDays = 92
+	// Tarih verilmezse takvim bugünden itibaren bu kadar gün için döner
+	defaultScheduleRangeDays = 30
+)
+
+type ScheduleUsecase interface {
+	GetSchedule(staffID, hospitalID uint, from, to string) (*dto.StaffScheduleResponse, error)
+	UpdateWeeklySchedule(staffID, hospitalID uint, req *dto.UpdateScheduleRequest) ([]dto.WeeklyShift, error)
+	SetOverride(staffID, hospitalID uint, date string, req *dto.ShiftOverrideRequest) (*dto.ShiftOverrideResponse, error)
+	DeleteOverride(staffID, hospitalID uint, date string) error
+	ListOnDuty(hospitalID uint, filter dto.OnDutyFilter) ([]dto.OnDutyStaff, error)
+}
+
+type scheduleUsecase struct {
+	repo             repository.ScheduleRepository
+	personnelRepo    repository.PersonnelRepository
+	polyclinicClient client.PolyclinicClient
+}
+
+func NewScheduleUsecase(repo repository.ScheduleRepository, personnelRepo repository.PersonnelRepository, pc client.PolyclinicClient) ScheduleUsecase {
+	return &scheduleUsecase{
+		repo:             repo,
+		personnelRepo:    personnelRepo,
+		polyclinicClient: pc,
+	}
+}
+
+func (u *scheduleUsecase) ownedStaff(staffID, hospitalID uint) (*models.Staff, error) {
+	staff, err := u.personnelRepo.GetStaffByID(staffID)
+	if err != nil {
+		return nil, errors.New("staff not found")
+	}
+	if staff.HospitalID != hospitalID {
+		return nil, errors.New("forbidden: staff belongs to another hospital")
+	}
+	return staff, nil
+}
+
+func (u *scheduleUsecase) GetSchedule(staffID, hospitalID uint, from, to string) (*dto.StaffScheduleResponse, error) {
+	staff, err := u.ownedStaff(staffID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromDate, toDate, err := parseDateRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := u.repo.ListOverrides(staff.ID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.StaffScheduleResponse{
+		StaffID:   staff.ID,
+		Weekly:    toWeeklyShifts(staff.Shifts),
+		Overrides: toOverrideResponses(overrides),
+	}, nil
+}
+
+func (u *scheduleUsecase) UpdateWeeklySchedule(staffID, hospitalID uint, req *dto.UpdateScheduleRequest) ([]dto.WeeklyShift, error) {
+	staff, err := u.ownedStaff(staffID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+
+	shifts, err := buildWeeklyShifts(u.polyclinicClient, hospitalID, req.Shifts)
+	if err != nil {
+		return nil, err
+	}
+	if shifts == nil {
+		shifts = []models.ShiftTemplate{}
+	}
+
+	if err := u.repo.ReplaceShifts(staff.ID, shifts); err != nil {
+		return nil, err
+	}
+	return toWeeklyShifts(shifts), nil
+}
+
+func (u *scheduleUsecase) SetOverride(staffID, hospitalID uint, date string, req *dto.ShiftOverrideRequest) (*dto.ShiftOverrideResponse, error) {
+	staff, err := u.ownedStaff(staffID, hospitalID)
+	if err != nil {
+		return nil, err
+	}
+	day, err := time.Parse(dateLayout, date)
+	if err != nil {
+		return nil, errors.New("date must be in YYYY-MM-DD format")
+	}
+
+	var overrides []models.ShiftOverride
+	if req.Off {
+		if len(req.Shifts) > 0 {
+			return nil, errors.New("shifts must be empty when off is true")
+		}
+		overrides = []models.ShiftOverride{{Off: true, Note: req.Note}}
+	} else {
+		if len(req.Shifts) == 0 {
+			return nil, errors.New("at least one shift is required, use off for a day off")
+		}
+
+		ranges := make([]minuteRange, 0, len(req.Shifts))
+		hpIDs := make([]*uint, 0, len(req.Shifts))
+		for _, s := range req.Shifts {
+			r, err := shiftRange(s.StartTime, s.EndTime)
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, r)
+			hpIDs = append(hpIDs, s.HospitalPolyclinicID)
+			overrides = append(overrides, models.ShiftOverride{
+				StartTime:            s.StartTime,
+				EndTime:              s.EndTime,
+				HospitalPolyclinicID: s.HospitalPolyclinicID,
+				Note:                 req.Note,
+			})
+		}
+		if overlaps(ranges, 0) {
+			return nil, errors.New("shifts overlap")
+		}
+		if err := checkShiftPolyclinics(u.polyclinicClient, hospitalID, hpIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := u.repo.ReplaceOverrides(staff.ID, day, overrides); err != nil {
+		return nil, err
+	}
+	resp := toOverrideResponses(overrides)
+	return &resp[0], nil
+}
+
+func (u *scheduleUsecase) DeleteOverride(staffID, hospitalID uint, date string) error {
+	staff, err := u.ownedStaff(staffID, hospitalID)
+	if err != nil {
+		return err
+	}
+	day, err := time.Parse(dateLayout, date)
+	if err != nil {
+		return errors.New("date must be in YYYY-MM-DD format")
+	}
+
+	deleted, err := u.repo.DeleteOverrides(staff.ID, day)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return errors.New("override not found")
+	}
+	return nil
+}
+
+// ListOnDuty verilen gün ve saat aralığında polikliniğin vardiyasına denk gelen personeli döner.
+// Önceki günden taşan gece vardiyaları da hesaba katılır.
+func (u *scheduleUsecase) ListOnDuty(hospitalID uint, filter dto.OnDutyFilter) ([]dto.OnDutyStaff, error) {
+	if filter.HospitalPolyclinicID == 0 {
+		return nil, errors.New("hospital_polyclinic_id is required")
+	}
+	day, err := time.Parse(dateLayout, filter.Date)
+	if err != nil {
+		return nil, errors.New("date must be in YYYY-MM-DD format")
+	}
+
+	window := minuteRange{start: 0, end: minutesPerDay}
+	if filter.From != "" {
+		if window.start, err = parseClock(filter.From, "from"); err != nil {
+			return nil, err
+		}
+	}
+	if filter.To != "" {
+		if window.end, err = parseClock(filter.To, "to"); err != nil {
+			return nil, err
+		}
+	}
+	if window.start >= window.end {
+		return nil, errors.New("from must be before to")
+	}
+
+	previousDay := day.AddDate(0, 0, -1)
+	staffs, err := u.repo.ListScheduledStaff(hospitalID, filter.HospitalPolyclinicID, previousDay, day)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.OnDutyStaff, 0)
+	for i := range staffs {
+		s := &staffs[i]
+		var matched []dto.OnDutyShift
+		for _, d := range []struct {
+			date   time.Time
+			offset int
+		}{{previousDay, -minutesPerDay}, {day, 0}} {
+			for _, shift := range effectiveShifts(s, d.date) {
+				hpID := s.HospitalPolyclinicID
+				if shift.hospitalPolyclinicID != nil {
+					hpID = shift.hospitalPolyclinicID
+				}
+				if hpID == nil || *hpID != filter.HospitalPolyclinicID {
+					continue
+				}
+				r, err := shiftRange(shift.startTime, shift.endTime)
+				if err != nil {
+					// Kayıtlar yazılırken doğrulanır; bozuk kayıt sorguyu düşürmez
+					continue
+				}
+				if r.start+d.offset < window.end && r.end+d.offset > window.start {
+					matched = append(matched, dto.OnDutyShift{
+						Date:                 d.date.Format(dateLayout),
+						StartTime:            shift.startTime,
+						EndTime:              shift.endTime,
+						HospitalPolyclinicID: *hpID,
+					})
+				}
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+		resp = append(resp, dto.OnDutyStaff{
+			ID:         s.ID,
+			FirstName:  s.FirstName,
+			LastName:   s.LastName,
+			JobGroupID: s.JobGroupID,
+			TitleID:    s.TitleID,
+			Shifts:     matched,
+		})
+	}
+	return resp, nil
+}
+
+type plannedShift struct {
+	startTime            string
+	endTime              string
+	hospitalPolyclinicID *uint
+}
+
+// effectiveShifts o gün için istisna varsa istisnayı, yoksa haftalık şablonu döner.
+// Onaylı izindeki personelin o gün vardiyası yoktur
+func effectiveShifts(staff *models.Staff, day time.Time) []plannedShift {
+	if onLeave(staff, day) {
+		return nil
+	}
+	key := day.Format(dateLayout)
+	var shifts []plannedShift
+	hasOverride := false
+	for _, o := range staff.Overrides {
+		if o.Date.Format(dateLayout) != key {
+			continue
+		}
+		hasOverride = true
+		if o.Off {
+			return nil
+		}
+		shifts = append(shifts, plannedShift{o.StartTime, o.EndTime, o.HospitalPolyclinicID})
+	}
+	if hasOverride {
+		return shifts
+	}
+
+	for _, t := range staff.Shifts {
+		if t.Weekday == day.Weekday() {
+			shifts = append(shifts, plannedShift{t.StartTime, t.EndTime, t.HospitalPolyclinicID})
+		}
+	}
+	return shifts
+}
+
+// onLeave yalnızca yüklenmiş (onaylı) izinlere bakar
+func onLeave(staff *models.Staff, day time.Time) bool {
+	for _, l := range staff.Leaves {
+		if l.Status == models.LeaveStatusApproved && !day.Before(l.StartDate) && !day.After(l.EndDate) {
+			return true
+		}
+	}
+	return false
+}
+
+// minuteRange gün başından itibaren dakika cinsinden [start, end) aralığıdır
+type minuteRange struct {
+	start int
+	end   int
+}
+
+func parseClock(value, field string) (int, error) {
+	t, err := time.Parse(clockLayout, value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be in HH:MM format", field)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// shiftRange bitişi başlangıçtan önce olan vardiyayı ertesi güne taşır
+func shiftRange(startTime, endTime string) (minuteRange, error) {
+	start, err := parseClock(startTime, "start_time")
+	if err != nil {
+		return minuteRange{}, err
+	}
+	end, err := parseClock(endTime, "end_time")
+	if err != nil {
+		return minuteRange{}, err
+	}
+	if start == end {
+		return minuteRange{}, errors.New("start_time and end_time must differ")
+	}
+	if end < start {
+		end += minutesPerDay
+	}
+	return minuteRange{start: start, end: end}, nil
+}
+
+// overlaps aralıkların kesişip kesişmediğini kontrol eder; cycle > 0 ise son aralığın
+// başa sarması da (Cumartesi gecesinden Pazar'a taşan vardiya gibi) hesaba katılır
+func overlaps(ranges []minuteRange, cycle int) bool {
+	if len(ranges) < 2 {
+		return false
+	}
+	sorted := append([]minuteRange(nil), ranges...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].start < sorted[j].start })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].start < sorted[i-1].end {
+			return true
+		}
+	}
+	return cycle > 0 && sorted[len(sorted)-1].end > sorted[0].start+cycle
+}
+
+// buildWeeklyShifts istekteki haftalık vardiyaları doğrulayıp modele çevirir; nil girdi için nil döner
+func buildWeeklyShifts(pc client.PolyclinicClient, hospitalID uint, in []dto.WeeklyShift) ([]models.ShiftTemplate, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	shifts := make([]models.ShiftTemplate, 0, len(in))
+	ranges := make([]minuteRange, 0, len(in))
+	hpIDs := make([]*uint, 0, len(in))
+	for _, s := range in {
+		if s.Weekday < 0 || s.Weekday > 6 {
+			return nil, errors.New("weekday must be between 0 (Sunday) and 6 (Saturday)")
+		}
+		r, err := shiftRange(s.StartTime, s.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		offset := s.Weekday * minutesPerDay
+		ranges = append(ranges, minuteRange{start: r.start + offset, end: r.end + offset})
+		hpIDs = append(hpIDs, s.HospitalPolyclinicID)
+		shifts = append(shifts, models.ShiftTemplate{
+			Weekday:              time.Weekday(s.Weekday),
+			StartTime:            s.StartTime,
+			EndTime:              s.EndTime,
+			HospitalPolyclinicID: s.HospitalPolyclinicID,
+		})
+	}
+	if overlaps(ranges, 7*minutesPerDay) {
+		return nil, errors.New("weekly shifts overlap")
+	}
+	if err := checkShiftPolyclinics(pc, hospitalID, hpIDs); err != nil {
+		return nil, err
+	}
+	return shifts, nil
+}
+
+// Vardiyada belirtilen polikliniklerin personelin hastanesine ait olduğunu tek istekle doğrular;
+// toplu sorgu başka hastanenin polikliniğini döndürmediği için ikisi aynı hatayla karşılanır
+func checkShiftPolyclinics(pc client.PolyclinicClient, hospitalID uint, ids []*uint) error {
+	seen := make(map[uint]bool)
+	var unique []uint
+	for _, id := range ids {
+		if id != nil && !seen[*id] {
+			seen[*id] = true
+			unique = append(unique, *id)
+		}
+	}
+	if len(unique) == 0 {
+		return nil
+	}
+
+	hps, err := pc.GetHospitalPolyclinicsByIDs(hospitalID, unique)
+	if err != nil {
+		log.Printf("shift polyclinics could not be verified: %v", err)
+		return errors.New("hospital polyclinics could not be verified")
+	}
+	for _, id := range unique {
+		if _, ok := hps[id]; !ok {
+			return errors.New("hospital polyclinic not found in your hospital")
+		}
+	}
+	return nil
+}
+
+func parseDateRange(from, to string) (time.Time, time.Time, error) {
+	now := time.Now()
+	fromDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if from != "" {
+		d, err := time.Parse(dateLayout, from)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("from must be in YYYY-MM-DD format")
+		}
+		fromDate = d
+	}
+	toDate := fromDate.AddDate(0, 0, defaultScheduleRangeDays)
+	if to != "" {
+		d, err := time.Parse(dateLayout, to)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("to must be in YYYY-MM-DD format")
+		}
+		toDate = d
+	}
+	if toDate.Before(fromDate) {
+		return time.Time{}, time.Time{}, errors.New("from must not be after to")
+	}
+	if toDate.Sub(fromDate) > maxScheduleRangeDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("date range cannot exceed %d days", maxScheduleRangeDays)
+	}
+	return fromDate, toDate, nil
+}
+
+func toWeeklyShifts(shifts []models.ShiftTemplate) []dto.WeeklyShift {
+	resp := make([]dto.WeeklyShift, 0, len(shifts))
+	for _, s := range shifts {
+		resp = append(resp, dto.WeeklyShift{
+			Weekday:              int(s.Weekday),
+			StartTime:            s.StartTime,
+			EndTime:              s.EndTime,
+			HospitalPolyclinicID: s.HospitalPolyclinicID,
+		})
+	}
+	return resp
+}
+
+// Aynı güne ait istisna kayıtları tek yanıt altında toplanır
+func toOverrideResponses(overrides []models.ShiftOverride) []dto.ShiftOverrideResponse {
+	resp := make([]dto.ShiftOverrideResponse, 0)
+	index := make(map[string]int)
+	for _, o := range overrides {
+		key := o.Date.Format(dateLayout)
+		i, ok := index[key]
+		if !ok {
+			i = len(resp)
+			index[key] = i
+			resp = append(resp, dto.ShiftOverrideResponse{Date: key, Shifts: []dto.ShiftInterval{}, Note: o.Note})
+		}
+		if o.Off {
+			resp[i].Off = true
+			continue
+		}
+		resp[i].Shifts = append(resp[i].Shifts, dto.ShiftInterval{
+			StartTime:            o.StartTime,
+			EndTime:              o.EndTime,
+			HospitalPolyclinicID: o.HospitalPolyclinicID,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"personnel-service/internal/dto"
+	"personnel-service/internal/infrastructure/client"
+	"personnel-service/internal/infrastructure/pdf"
+	"personnel-service/internal/infrastructure/spreadsheet"
+	"personnel-service/internal/repository"
+)
+
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+	ExportFormatPDF  = "pdf"
+
+	exportBatchSize = 500
+)
+
+var staffExportColumns = []pdf.Column{
+	{Title: "Ad", Width: 95},
+	{Title: "Soyad", Width: 95},
+	{Title: "TC Kimlik No", Width: 80},
+	{Title: "Telefon", Width: 85},
+	{Title: "Meslek Grubu", Width: 110},
+	{Title: "Unvan", Width: 125},
+	{Title: "Poliklinik", Width: 180},
+}
+
+// StaffExport dışa aktarımın hazırlanmış halidir; Write satırları veritabanından okudukça yazar
+type StaffExport struct {
+	ContentType string
+	FileName    string
+	Write       func(w io.Writer) error
+}
+
+type StaffExportUsecase interface {
+	// PrepareExport formatı ve poliklinik adlarını yanıt başlamadan doğrular; yazma sırasında
+	// oluşan hatalar ancak loglanabilir
+	PrepareExport(hospitalID uint, role string, filter dto.StaffListFilter, format string) (*StaffExport, error)
+}
+
+type staffExportUsecase struct {
+	repo             repository.PersonnelRepository
+	polyclinicClient client.PolyclinicClient
+}
+
+func NewStaffExportUsecase(repo repository.PersonnelRepository, pc client.PolyclinicClient) StaffExportUsecase {
+	return &staffExportUsecase{
+		repo:             repo,
+		polyclinicClient: pc,
+	}
+}
+
+// staffRowWriter formatlar arasındaki ortak yazma arayüzüdür
+type staffRowWriter interface {
+	WriteRow(cells []string) error
+	Close() error
+}
+
+func (u *staffExportUsecase) PrepareExport(hospitalID uint, role string, filter dto.StaffListFilter, format string) (*StaffExport, error) {
+	format = strings.ToLower(format)
+	var contentType string
+	switch format {
+	case ExportFormatCSV:
+		contentType = "text/csv; charset=utf-8"
+	case ExportFormatXLSX:
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case ExportFormatPDF:
+		contentType = "application/pdf"
+	default:
+		return nil, errors.New("format must be one of csv, xlsx, pdf")
+	}
+	if err := checkTCFilter(role, filter); err != nil {
+		return nil, err
+	}
+
+	hps, err := u.polyclinicClient.ListHospitalPolyclinics(hospitalID)
+	if err != nil {
+		return nil, errors.New("polyclinic names could not be loaded, try again later")
+	}
+	polyclinicNames := make(map[uint]string, len(hps))
+	for _, hp := range hps {
+		polyclinicNames[hp.ID] = hp.PolyclinicName
+	}
+
+	return &StaffExport{
+		ContentType: contentType,
+		FileName:    fmt.Sprintf("personel-%s.%s", time.Now().Format("2006-01-02"), format),
+		Write: func(w io.Writer) error {
+			rw, err := newStaffRowWriter(w, format)
+			if err != nil {
+				return err
+			}
+			err = u.repo.StreamStaff(hospitalID, filter, exportBatchSize, func(rows []repository.StaffExportRow) error {
+				for _, r := range rows {
+					var polyclinic string
+					if r.HospitalPolyclinicID != nil {
+						polyclinic = polyclinicNames[*r.HospitalPolyclinicID]
+					}
+					if err := rw.WriteRow([]string{r.FirstName, r.LastName, visibleTC(role, r.TC), r.Phone, r.JobGroupName, r.TitleName, polyclinic}); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			return rw.Close()
+		},
+	}, nil
+}
+
+func newStaffRowWriter(w io.Writer, format string) (staffRowWriter, error) {
+	titles := make([]string, len(staffExportColumns))
+	for i, c := range staffExportColumns {
+		titles[i] = c.Title
+	}
+
+	switch format {
+	case ExportFormatXLSX:
+		x, err := spreadsheet.NewXLSXWriter(w, "Personel")
+		if err != nil {
+			return nil, err
+		}
+		return x, x.WriteRow(titles)
+	case ExportFormatPDF:
+		return pdf.NewTableWriter(w, "Personel Listesi", staffExportColumns)
+	default:
+		// Excel'in Türkçe karakterleri doğru açması için UTF-8 BOM
+		if _, err := io.WriteString(w, "\xef\xbb\xbf"); err != nil {
+			return nil, err
+		}
+		c := &csvRowWriter{w: csv.NewWriter(w)}
+		return c, c.WriteRow(titles)
+	}
+}
+
+type csvRowWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvRowWriter) WriteRow(cells []string) error {
+	safe := make([]string, len(cells))
+	for i, cell := range cells {
+		safe[i] = csvSafe(cell)
+	}
+	return c.w.Write(safe)
+}
+
+func (c *csvRowWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
@@ -0,0 +1,417 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"personnel-service/internal/dto"
+	"personnel-service/internal/infrastructure/client"
+	"personnel-service/internal/infrastructure/spreadsheet"
+	"personnel-service/internal/models"
+	"personnel-service/internal/repository"
+	"personnel-service/pkg/utils"
+)
+
+const (
+	// Tek dosyada kabul edilen en fazla personel satırı
+	maxImportRows = 5000
+	// Bu süreden uzun süredir güncellenmeyen bekleyen/çalışan işler yarıda kalmış sayılır
+	staleImportAfter = 30 * time.Minute
+)
+
+// İçe aktarılan dosyadaki sütunların eşleştiği AddStaffRequest alanları
+const (
+	importFirstName  = "first_name"
+	importLastName   = "last_name"
+	importTC         = "tc"
+	importPhone      = "phone"
+	importJobGroup   = "job_group"
+	importTitle      = "title"
+	importPolyclinic = "polyclinic"
+)
+
+var requiredImportColumns = []string{importFirstName, importLastName, importTC, importPhone, importJobGroup, importTitle}
+
+// importColumnAliases başlık satırındaki sütun adlarının normalize edilmiş karşılıklarıdır
+var importColumnAliases = map[string][]string{
+	importFirstName:  {"firstname", "ad", "adi", "isim"},
+	importLastName:   {"lastname", "soyad", "soyadi"},
+	importTC:         {"tc", "tckimlikno", "tckn", "tcno", "kimlikno"},
+	importPhone:      {"phone", "telefon", "tel", "ceptelefonu", "cep"},
+	importJobGroup:   {"jobgroup", "meslekgrubu", "meslek"},
+	importTitle:      {"title", "unvan"},
+	importPolyclinic: {"polyclinic", "poliklinik", "bolum"},
+}
+
+type StaffImportUsecase interface {
+	// StartImport dosyayı okuyup sütunları eşler ve doğrulama/aktarımı arka planda başlatır.
+	// columns verilirse alan -> başlık eşlemesi otomatik tespitin yerine geçer.
+	StartImport(hospitalID, authorityID uint, fileName string, data []byte, columns map[string]string, dryRun bool) (*dto.StaffImportJobResponse, error)
+	GetJob(id, hospitalID uint) (*dto.StaffImportJobResponse, error)
+}
+
+type staffImportUsecase struct {
+	repo             repository.StaffImportRepository
+	personnelRepo    repository.PersonnelRepository
+	polyclinicClient client.PolyclinicClient
+}
+
+func NewStaffImportUsecase(repo repository.StaffImportRepository, personnelRepo repository.PersonnelRepository, pc client.PolyclinicClient) StaffImportUsecase {
+	return &staffImportUsecase{
+		repo:             repo,
+		personnelRepo:    personnelRepo,
+		polyclinicClient: pc,
+	}
+}
+
+func (u *staffImportUsecase) StartImport(hospitalID, authorityID uint, fileName string, data []byte, columns map[string]string, dryRun bool) (*dto.StaffImportJobResponse, error) {
+	rows, err := spreadsheet.Read(fileName, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) < 2 {
+		return nil, errors.New("file must contain a header row and at least one staff row")
+	}
+	if len(rows)-1 > maxImportRows {
+		return nil, fmt.Errorf("file cannot contain more than %d staff rows", maxImportRows)
+	}
+
+	colIdx, err := mapImportColumns(rows[0], columns)
+	if err != nil {
+		return nil, err
+	}
+
+	job := models.StaffImportJob{
+		HospitalID: hospitalID,
+		CreatedBy:  authorityID,
+		FileName:   fileName,
+		DryRun:     dryRun,
+		Status:     models.StaffImportPending,
+	}
+	if err := u.repo.CreateJob(&job); err != nil {
+		return nil, err
+	}
+
+	go u.run(job, rows[1:], colIdx)
+
+	return toStaffImportJobResponse(&job), nil
+}
+
+func (u *staffImportUsecase) GetJob(id, hospitalID uint) (*dto.StaffImportJobResponse, error) {
+	job, err := u.repo.GetJobByID(id)
+	if err != nil || job.HospitalID != hospitalID {
+		return nil, errors.New("import job not found")
+	}
+
+	// İş çalışırken servis yeniden başladıysa sonuç hiç yazılmaz; uzun süredir ilerlemeyen iş başarısız sayılır
+	if (job.Status == models.StaffImportPending || job.Status == models.StaffImportRunning) && time.Since(job.UpdatedAt) > staleImportAfter {
+		u.finish(job, models.StaffImportFailed, "import was interrupted, please upload the file again", nil)
+	}
+	return toStaffImportJobResponse(job), nil
+}
+
+// mapImportColumns alanların başlık satırındaki sütun numaralarını bulur
+func mapImportColumns(header []string, columns map[string]string) (map[string]int, error) {
+	normalized := make(map[string]int, len(header))
+	for i, h := range header {
+		key := foldImportName(h)
+		if _, ok := normalized[key]; !ok && key != "" {
+			normalized[key] = i
+		}
+	}
+
+	colIdx := make(map[string]int)
+	for field, aliases := range importColumnAliases {
+		if name, ok := columns[field]; ok {
+			i, found := normalized[foldImportName(name)]
+			if !found {
+				return nil, fmt.Errorf("column %q mapped to %s not found in header", name, field)
+			}
+			colIdx[field] = i
+			continue
+		}
+		for _, alias := range aliases {
+			if i, found := normalized[alias]; found {
+				colIdx[field] = i
+				break
+			}
+		}
+	}
+	for field := range columns {
+		if _, ok := importColumnAliases[field]; !ok {
+			return nil, fmt.Errorf("unknown import field: %s", field)
+		}
+	}
+
+	var missing []string
+	for _, field := range requiredImportColumns {
+		if _, ok := colIdx[field]; !ok {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required columns: %s", strings.Join(missing, ", "))
+	}
+	return colIdx, nil
+}
+
+// foldImportName başlık ve isimleri büyük/küçük harf, Türkçe karakter ve boşluk farkı gözetmeden karşılaştırmak içindir
+func foldImportName(s string) string {
+	return strings.ReplaceAll(utils.FoldTurkish(s), " ", "")
+}
+
+func (u *staffImportUsecase) run(job models.StaffImportJob, rows [][]string, colIdx map[string]int) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("staff import %d: panic: %v", job.ID, r)
+			u.finish(&job, models.StaffImportFailed, "unexpected error while importing", nil)
+		}
+	}()
+
+	job.Status = models.StaffImportRunning
+	if err := u.repo.UpdateJob(&job); err != nil {
+		log.Printf("staff import %d: %v", job.ID, err)
+	}
+
+	staffs, rowErrors, err := u.validateRows(job.HospitalID, rows, colIdx, &job)
+	if err != nil {
+		u.finish(&job, models.StaffImportFailed, err.Error(), nil)
+		return
+	}
+
+	switch {
+	case job.DryRun:
+		u.finish(&job, models.StaffImportValidated, "", rowErrors)
+	case len(rowErrors) > 0:
+		u.finish(&job, models.StaffImportFailed, "file has invalid rows, no staff were imported", rowErrors)
+	default:
+		err := u.repo.CreateStaffBatch(staffs)
+		switch {
+		case errors.Is(err, repository.ErrHospitalHeadExists):
+			// Doğrulamadan sonra başka bir istek başhekim eklemiş
+			u.finish(&job, models.StaffImportFailed, "there can be only one Başhekim in a hospital, no staff were imported", nil)
+		case err != nil:
+			log.Printf("staff import %d: %v", job.ID, err)
+			u.finish(&job, models.StaffImportFailed, "import failed, no staff were imported", nil)
+		default:
+			job.ImportedRows = len(staffs)
+			u.finish(&job, models.StaffImportCompleted, "", nil)
+		}
+	}
+}
+
+func (u *staffImportUsecase) finish(job *models.StaffImportJob, status, message string, rowErrors []models.StaffImportRowError) {
+	now := time.Now()
+	job.Status = status
+	job.Error = message
+	job.FinishedAt = &now
+	if err := u.repo.FinishJob(job, rowErrors); err != nil {
+		log.Printf("staff import %d: failed to save result: %v", job.ID, err)
+	}
+}
+
+// importRow dosyadaki bir satırın doğrulanmış halidir
+type importRow struct {
+	line  int
+	staff models.Staff
+	head  bool
+}
+
+// validateRows satırları AddStaff ile aynı kurallarla doğrular. Dönen hata satırlardan bağımsız
+// (ör. poliklinik listesinin alınamaması) ve işi tamamen durdurur.
+func (u *staffImportUsecase) validateRows(hospitalID uint, rows [][]string, colIdx map[string]int, job *models.StaffImportJob) ([]models.Staff, []models.StaffImportRowError, error) {
+	groups, err := u.personnelRepo.GetAllJobGroups()
+	if err != nil {
+		return nil, nil, err
+	}
+	titles, err := u.repo.ListTitles()
+	if err != nil {
+		return nil, nil, err
+	}
+	groupByName := make(map[string]models.JobGroup, len(groups))
+	for _, g := range groups {
+		groupByName[foldImportName(g.Name)] = g
+	}
+	titleByName := make(map[string]models.Title, len(titles))
+	for _, t := range titles {
+		titleByName[foldImportName(t.Name)] = t
+	}
+
+	var polyclinicByName map[string]uint
+	if _, ok := colIdx[importPolyclinic]; ok {
+		hps, err := u.polyclinicClient.ListHospitalPolyclinics(hospitalID)
+		if err != nil {
+			return nil, nil, errors.New("hospital polyclinics could not be loaded, try again later")
+		}
+		polyclinicByName = make(map[string]uint, len(hps))
+		for _, hp := range hps {
+			polyclinicByName[foldImportName(hp.PolyclinicName)] = hp.ID
+		}
+	}
+
+	cell := func(row []string, field string) string {
+		i, ok := colIdx[field]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	var (
+		rowErrors []models.StaffImportRowError
+		valid     []importRow
+		tcLine    = make(map[string]int)
+		phoneLine = make(map[string]int)
+	)
+	for i, row := range rows {
+		line := i + 2 // başlık 1. satırdır
+		if isBlankRow(row) {
+			continue
+		}
+		job.TotalRows++
+
+		var errs []models.StaffImportRowError
+		fail := func(column, format string, args ...interface{}) {
+			errs = append(errs, models.StaffImportRowError{Row: line, Column: column, Message: fmt.Sprintf(format, args...)})
+		}
+
+		r := importRow{line: line, staff: models.Staff{
+			FirstName:  cell(row, importFirstName),
+			LastName:   cell(row, importLastName),
+			TC:         cell(row, importTC),
+			Phone:      cell(row, importPhone),
+			HospitalID: hospitalID,
+		}}
+
+		if r.staff.FirstName == "" {
+			fail(importFirstName, "first name is required")
+		}
+		if r.staff.LastName == "" {
+			fail(importLastName, "last name is required")
+		}
+
+		if !utils.ValidTC(r.staff.TC) {
+			fail(importTC, "invalid TC number")
+		} else if prev, dup := tcLine[r.staff.TC]; dup {
+			fail(importTC, "duplicate TC, already used in row %d", prev)
+		} else {
+			tcLine[r.staff.TC] = line
+		}
+
+		if phone, ok := utils.NormalizePhone(r.staff.Phone); !ok {
+			fail(importPhone, "invalid phone number")
+		} else if prev, dup := phoneLine[phone]; dup {
+			fail(importPhone, "duplicate phone, already used in row %d", prev)
+		} else {
+			phoneLine[phone] = line
+			r.staff.Phone = phone
+		}
+
+		group, groupOK := groupByName[foldImportName(cell(row, importJobGroup))]
+		if !groupOK {
+			fail(importJobGroup, "job group %q not found", cell(row, importJobGroup))
+		}
+		title, titleOK := titleByName[foldImportName(cell(row, importTitle))]
+		switch {
+		case !titleOK:
+			fail(importTitle, "title %q not found", cell(row, importTitle))
+		case groupOK && title.JobGroupID != group.ID:
+			fail(importTitle, "title does not belong to the selected job group")
+		}
+		r.staff.JobGroupID = group.ID
+		r.staff.TitleID = title.ID
+		r.head = titleOK && title.Name == "Başhekim"
+
+		if name := cell(row, importPolyclinic); name != "" {
+			if hpID, ok := polyclinicByName[foldImportName(name)]; ok {
+				r.staff.HospitalPolyclinicID = &hpID
+			} else {
+				fail(importPolyclinic, "polyclinic %q not found in your hospital", name)
+			}
+		}
+
+		if len(errs) > 0 {
+			rowErrors = append(rowErrors, errs...)
+			continue
+		}
+		valid = append(valid, r)
+	}
+
+	if job.TotalRows == 0 {
+		return nil, nil, errors.New("file does not contain any staff rows")
+	}
+
+	// Kayıtlı personelle çakışma ve başhekim kuralı geçerli satırlar üzerinden bakılır
+	tcs := make([]string, 0, len(valid))
+	phones := make([]string, 0, len(valid))
+	for _, r := range valid {
+		tcs = append(tcs, r.staff.TC)
+		phones = append(phones, r.staff.Phone)
+	}
+	existingTCs, existingPhones, err := u.repo.FindExistingTCsAndPhones(tcs, phones)
+	if err != nil {
+		return nil, nil, err
+	}
+	heads, err := u.personnelRepo.CountHospitalHeads(hospitalID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	staffs := make([]models.Staff, 0, len(valid))
+	for _, r := range valid {
+		ok := true
+		if existingTCs[r.staff.TC] {
+			rowErrors = append(rowErrors, models.StaffImportRowError{Row: r.line, Column: importTC, Message: "staff with given TC already exists"})
+			ok = false
+		}
+		if existingPhones[r.staff.Phone] {
+			rowErrors = append(rowErrors, models.StaffImportRowError{Row: r.line, Column: importPhone, Message: "staff with given phone already exists"})
+			ok = false
+		}
+		if r.head {
+			if heads > 0 {
+				rowErrors = append(rowErrors, models.StaffImportRowError{Row: r.line, Column: importTitle, Message: "there can be only one Başhekim in a hospital"})
+				ok = false
+			}
+			heads++
+		}
+		if ok {
+			staffs = append(staffs, r.staff)
+		}
+	}
+	job.ValidRows = len(staffs)
+
+	return staffs, rowErrors, nil
+}
+
+func isBlankRow(row []string) bool {
+	for _, c := range row {
+		if strings.TrimSpace(c) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func toStaffImportJobResponse(job *models.StaffImportJob) *dto.StaffImportJobResponse {
+	resp := &dto.StaffImportJobResponse{
+		ID:           job.ID,
+		FileName:     job.FileName,
+		DryRun:       job.DryRun,
+		Status:       job.Status,
+		TotalRows:    job.TotalRows,
+		ValidRows:    job.ValidRows,
+		ImportedRows: job.ImportedRows,
+		Error:        job.Error,
+		Errors:       make([]dto.StaffImportRowError, 0, len(job.RowErrors)),
+		CreatedAt:    job.CreatedAt.Format(time.RFC3339),
+		FinishedAt:   formatTime(job.FinishedAt),
+	}
+	for _, e := range job.RowErrors {
+		resp.Errors = append(resp.Errors, dto.StaffImportRowError{Row: e.Row, Column: e.Column, Message: e.Message})
+	}
+	return resp
+}
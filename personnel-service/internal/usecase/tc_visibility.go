@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+
+	"personnel-service/internal/dto"
+)
+
+// TC kimlik numarasını yalnızca yetkili tam görür. Diğer roller maskeli görür; maskeyi TC ile
+// filtreleyerek ya da arayarak aşamasınlar diye TC filtresi ve TC araması da onlara kapalıdır.
+// Liste, arama ve dışa aktarım bu kuralları buradan kullanır.
+
+var ErrTCFilterNotAllowed = errors.New("filtering by tc is not allowed for your role")
+
+func canSeeFullTC(role string) bool {
+	return role == "yetkili"
+}
+
+// visibleTC rolün görebileceği TC'yi döner
+func visibleTC(role, tc string) string {
+	if canSeeFullTC(role) {
+		return tc
+	}
+	return maskTCNumber(tc)
+}
+
+func checkTCFilter(role string, filter dto.StaffListFilter) error {
+	if filter.TC != "" && !canSeeFullTC(role) {
+		return ErrTCFilterNotAllowed
+	}
+	return nil
+}
+
+// maskTCNumber ilk üç ve son iki hane dışındaki haneleri gizler
+func maskTCNumber(tc string) string {
+	if len(tc) != 11 {
+		return strings.Repeat("*", len(tc))
+	}
+	return tc[:3] + strings.Repeat("*", 6) + tc[9:]
+}
+
+// csvSafe formül olarak yorumlanabilecek hücrelerin başına ' ekler; Excel dışa aktarılan
+// dosyadaki "=..." gibi değerleri çalıştırmasın diye
+func csvSafe(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
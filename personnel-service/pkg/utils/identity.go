@@ -0,0 +1,50 @@
+package utils
+
+import "strings"
+
+// ValidTC TC kimlik numarasının uzunluk, ilk hane ve kontrol hanelerini doğrular
+func ValidTC(tc string) bool {
+	if len(tc) != 11 || tc[0] == '0' {
+		return false
+	}
+	var d [11]int
+	for i := 0; i < 11; i++ {
+		if tc[i] < '0' || tc[i] > '9' {
+			return false
+		}
+		d[i] = int(tc[i] - '0')
+	}
+	odd := d[0] + d[2] + d[4] + d[6] + d[8]
+	even := d[1] + d[3] + d[5] + d[7]
+	if ((odd*7-even)%10+10)%10 != d[9] {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 10; i++ {
+		sum += d[i]
+	}
+	return sum%10 == d[10]
+}
+
+// NormalizePhone Türkiye cep telefonunu 5XXXXXXXXX biçimine getirir; +90 ve 0 öneki, boşluk, tire
+// ve parantez kabul edilir. Personel telefonları bu biçimde saklanır ve aranır, aynı numaranın
+// farklı yazımları ayrı kayıt sayılmaz.
+func NormalizePhone(phone string) (string, bool) {
+	var b strings.Builder
+	for _, r := range phone {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '(' || r == ')' || r == '+':
+		default:
+			return "", false
+		}
+	}
+	p := b.String()
+	p = strings.TrimPrefix(p, "90")
+	p = strings.TrimPrefix(p, "0")
+	if len(p) != 10 || p[0] != '5' {
+		return "", false
+	}
+	return p, true
+}
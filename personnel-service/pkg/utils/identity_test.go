@@ -0,0 +1,51 @@
+package utils
+
+import "testing"
+
+func TestValidTC(t *testing.T) {
+	tests := []struct {
+		tc   string
+		want bool
+	}{
+		{"10000000146", true},
+		{"12345678950", true},
+		{"12345678951", false}, // son kontrol hanesi yanlış
+		{"12345678940", false}, // 10. hane yanlış
+		{"02345678950", false}, // 0 ile başlayamaz
+		{"1234567895", false},
+		{"123456789501", false},
+		{"1234567895a", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := ValidTC(tt.tc); got != tt.want {
+			t.Errorf("ValidTC(%q) = %v, want %v", tt.tc, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		in, want string
+		ok       bool
+	}{
+		{"5321234567", "5321234567", true},
+		{"05321234567", "5321234567", true},
+		{"+905321234567", "5321234567", true},
+		{"905321234567", "5321234567", true},
+		{"+90 (532) 123-45-67", "5321234567", true},
+		{"0 532 123 45 67", "5321234567", true},
+		{"+90 0532 123 45 67", "5321234567", true},
+		{"02121234567", "", false}, // sabit hat
+		{"532123456", "", false},
+		{"53212345678", "", false},
+		{"0532.123.45.67", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := NormalizePhone(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("NormalizePhone(%q) = %q, %v, want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
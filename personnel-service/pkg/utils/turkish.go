@@ -0,0 +1,31 @@
+package utils
+
+import "strings"
+
+// Türkçe harfler ve şapkalı ünlüler ASCII karşılıklarına indirgenir. I/İ ayrımı aramada
+// önemsiz sayıldığından ikisi de "i" olur.
+var turkishFolder = strings.NewReplacer(
+	"ç", "c", "Ç", "c", "ğ", "g", "Ğ", "g", "ı", "i", "I", "i", "İ", "i",
+	"ö", "o", "Ö", "o", "ş", "s", "Ş", "s", "ü", "u", "Ü", "u",
+	"â", "a", "Â", "a", "î", "i", "Î", "i", "û", "u", "Û", "u",
+)
+
+// FoldTurkish metni küçük harfli ASCII'ye çevirir; harf ve rakam dışındaki karakterler tek
+// boşluğa indirgenir. "Şükrü  YILMAZ" -> "sukru yilmaz"
+func FoldTurkish(s string) string {
+	s = strings.ToLower(turkishFolder.Replace(s))
+	var b strings.Builder
+	space := false
+	for _, r := range s {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteRune(r)
+			space = false
+			continue
+		}
+		space = true
+	}
+	return b.String()
+}
@@ -0,0 +1,23 @@
+package utils
+
+import "testing"
+
+func TestFoldTurkish(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Şükrü  YILMAZ", "sukru yilmaz"},
+		{"İĞDE ığde", "igde igde"},
+		{"Çağrı Öztürk", "cagri ozturk"},
+		{"Hâkim Kâmil Rûhi", "hakim kamil ruhi"},
+		{"  0532-123 45 67 ", "0532 123 45 67"},
+		{"Ayşe-Gül O'Neil", "ayse gul o neil"},
+		{"...", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := FoldTurkish(tt.in); got != tt.want {
+			t.Errorf("FoldTurkish(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}